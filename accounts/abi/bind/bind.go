@@ -103,158 +103,13 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 		isLib = make(map[string]struct{})
 	)
 	for i := 0; i < len(types); i++ {
-		// Parse the actual ABI to generate the binding for
-		evmABI, err := abi.JSON(strings.NewReader(abis[i]))
+		contract, err := normalizeContract(types[i], abis[i], lang, aliases, structs)
 		if err != nil {
 			return "", err
 		}
-		// Strip any whitespace from the JSON ABI
-		strippedABI := strings.Map(func(r rune) rune {
-			if unicode.IsSpace(r) {
-				return -1
-			}
-			return r
-		}, abis[i])
-
-		// Extract the call and transact methods; events, struct definitions; and sort them alphabetically
-		var (
-			calls     = make(map[string]*tmplMethod)
-			transacts = make(map[string]*tmplMethod)
-			events    = make(map[string]*tmplEvent)
-			fallback  *tmplMethod
-			receive   *tmplMethod
-
-			// identifiers are used to detect duplicated identifiers of functions
-			// and events. For all calls, transacts and events, abigen will generate
-			// corresponding bindings. However we have to ensure there is no
-			// identifier collisions in the bindings of these categories.
-			callIdentifiers     = make(map[string]bool)
-			transactIdentifiers = make(map[string]bool)
-			eventIdentifiers    = make(map[string]bool)
-		)
-
-		for _, input := range evmABI.Constructor.Inputs {
-			if hasStruct(input.Type) {
-				bindStructType[lang](input.Type, structs)
-			}
-		}
+		contract.InputBin = strings.TrimPrefix(strings.TrimSpace(bytecodes[i]), "0x")
+		contracts[types[i]] = contract
 
-		for _, original := range evmABI.Methods {
-			// Normalize the method for capital cases and non-anonymous inputs/outputs
-			normalized := original
-			normalizedName := methodNormalizer[lang](alias(aliases, original.Name))
-			// Ensure there is no duplicated identifier
-			var identifiers = callIdentifiers
-			if !original.IsConstant() {
-				identifiers = transactIdentifiers
-			}
-			// Name shouldn't start with a digit. It will make the generated code invalid.
-			if len(normalizedName) > 0 && unicode.IsDigit(rune(normalizedName[0])) {
-				normalizedName = fmt.Sprintf("M%s", normalizedName)
-				normalizedName = abi.ResolveNameConflict(normalizedName, func(name string) bool {
-					_, ok := identifiers[name]
-					return ok
-				})
-			}
-			if identifiers[normalizedName] {
-				return "", fmt.Errorf("duplicated identifier \"%s\"(normalized \"%s\"), use --alias for renaming", original.Name, normalizedName)
-			}
-			identifiers[normalizedName] = true
-
-			normalized.Name = normalizedName
-			normalized.Inputs = make([]abi.Argument, len(original.Inputs))
-			copy(normalized.Inputs, original.Inputs)
-			for j, input := range normalized.Inputs {
-				if input.Name == "" || isKeyWord(input.Name) {
-					normalized.Inputs[j].Name = fmt.Sprintf("arg%d", j)
-				}
-				if hasStruct(input.Type) {
-					bindStructType[lang](input.Type, structs)
-				}
-			}
-			normalized.Outputs = make([]abi.Argument, len(original.Outputs))
-			copy(normalized.Outputs, original.Outputs)
-			for j, output := range normalized.Outputs {
-				if output.Name != "" {
-					normalized.Outputs[j].Name = capitalise(output.Name)
-				}
-				if hasStruct(output.Type) {
-					bindStructType[lang](output.Type, structs)
-				}
-			}
-			// Append the methods to the call or transact lists
-			if original.IsConstant() {
-				calls[original.Name] = &tmplMethod{Original: original, Normalized: normalized, Structured: structured(original.Outputs)}
-			} else {
-				transacts[original.Name] = &tmplMethod{Original: original, Normalized: normalized, Structured: structured(original.Outputs)}
-			}
-		}
-		for _, original := range evmABI.Events {
-			// Skip anonymous events as they don't support explicit filtering
-			if original.Anonymous {
-				continue
-			}
-			// Normalize the event for capital cases and non-anonymous outputs
-			normalized := original
-
-			// Ensure there is no duplicated identifier
-			normalizedName := methodNormalizer[lang](alias(aliases, original.Name))
-			// Name shouldn't start with a digit. It will make the generated code invalid.
-			if len(normalizedName) > 0 && unicode.IsDigit(rune(normalizedName[0])) {
-				normalizedName = fmt.Sprintf("E%s", normalizedName)
-				normalizedName = abi.ResolveNameConflict(normalizedName, func(name string) bool {
-					_, ok := eventIdentifiers[name]
-					return ok
-				})
-			}
-			if eventIdentifiers[normalizedName] {
-				return "", fmt.Errorf("duplicated identifier \"%s\"(normalized \"%s\"), use --alias for renaming", original.Name, normalizedName)
-			}
-			eventIdentifiers[normalizedName] = true
-			normalized.Name = normalizedName
-
-			used := make(map[string]bool)
-			normalized.Inputs = make([]abi.Argument, len(original.Inputs))
-			copy(normalized.Inputs, original.Inputs)
-			for j, input := range normalized.Inputs {
-				if input.Name == "" || isKeyWord(input.Name) {
-					normalized.Inputs[j].Name = fmt.Sprintf("arg%d", j)
-				}
-				// Event is a bit special, we need to define event struct in binding,
-				// ensure there is no camel-case-style name conflict.
-				for index := 0; ; index++ {
-					if !used[capitalise(normalized.Inputs[j].Name)] {
-						used[capitalise(normalized.Inputs[j].Name)] = true
-						break
-					}
-					normalized.Inputs[j].Name = fmt.Sprintf("%s%d", normalized.Inputs[j].Name, index)
-				}
-				if hasStruct(input.Type) {
-					bindStructType[lang](input.Type, structs)
-				}
-			}
-			// Append the event to the accumulator list
-			events[original.Name] = &tmplEvent{Original: original, Normalized: normalized}
-		}
-		// Add two special fallback functions if they exist
-		if evmABI.HasFallback() {
-			fallback = &tmplMethod{Original: evmABI.Fallback}
-		}
-		if evmABI.HasReceive() {
-			receive = &tmplMethod{Original: evmABI.Receive}
-		}
-		contracts[types[i]] = &tmplContract{
-			Type:        capitalise(types[i]),
-			InputABI:    strings.ReplaceAll(strippedABI, "\"", "\\\""),
-			InputBin:    strings.TrimPrefix(strings.TrimSpace(bytecodes[i]), "0x"),
-			Constructor: evmABI.Constructor,
-			Calls:       calls,
-			Transacts:   transacts,
-			Fallback:    fallback,
-			Receive:     receive,
-			Events:      events,
-			Libraries:   make(map[string]string),
-		}
 		// Function 4-byte signatures are stored in the same sequence
 		// as types, if available.
 		if len(fsigs) > i {
@@ -312,6 +167,163 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 	return buffer.String(), nil
 }
 
+// normalizeContract parses the ABI of the contract [typ] and normalizes its
+// methods and events to the naming conventions of [lang], recording the structs
+// they use in [structs].
+func normalizeContract(typ string, abiJSON string, lang Lang, aliases map[string]string, structs map[string]*tmplStruct) (*tmplContract, error) {
+	// Parse the actual ABI to generate the binding for
+	evmABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	// Strip any whitespace from the JSON ABI
+	strippedABI := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, abiJSON)
+
+	// Extract the call and transact methods; events, struct definitions; and sort them alphabetically
+	var (
+		calls     = make(map[string]*tmplMethod)
+		transacts = make(map[string]*tmplMethod)
+		events    = make(map[string]*tmplEvent)
+		fallback  *tmplMethod
+		receive   *tmplMethod
+
+		// identifiers are used to detect duplicated identifiers of functions
+		// and events. For all calls, transacts and events, abigen will generate
+		// corresponding bindings. However we have to ensure there is no
+		// identifier collisions in the bindings of these categories.
+		callIdentifiers     = make(map[string]bool)
+		transactIdentifiers = make(map[string]bool)
+		eventIdentifiers    = make(map[string]bool)
+	)
+
+	for _, input := range evmABI.Constructor.Inputs {
+		if hasStruct(input.Type) {
+			bindStructType[lang](input.Type, structs)
+		}
+	}
+
+	for _, original := range evmABI.Methods {
+		// Normalize the method for capital cases and non-anonymous inputs/outputs
+		normalized := original
+		normalizedName := methodNormalizer[lang](alias(aliases, original.Name))
+		// Ensure there is no duplicated identifier
+		var identifiers = callIdentifiers
+		if !original.IsConstant() {
+			identifiers = transactIdentifiers
+		}
+		// Name shouldn't start with a digit. It will make the generated code invalid.
+		if len(normalizedName) > 0 && unicode.IsDigit(rune(normalizedName[0])) {
+			normalizedName = fmt.Sprintf("M%s", normalizedName)
+			normalizedName = abi.ResolveNameConflict(normalizedName, func(name string) bool {
+				_, ok := identifiers[name]
+				return ok
+			})
+		}
+		if identifiers[normalizedName] {
+			return nil, fmt.Errorf("duplicated identifier \"%s\"(normalized \"%s\"), use --alias for renaming", original.Name, normalizedName)
+		}
+		identifiers[normalizedName] = true
+
+		normalized.Name = normalizedName
+		normalized.Inputs = make([]abi.Argument, len(original.Inputs))
+		copy(normalized.Inputs, original.Inputs)
+		for j, input := range normalized.Inputs {
+			if input.Name == "" || isKeyWord(input.Name) {
+				normalized.Inputs[j].Name = fmt.Sprintf("arg%d", j)
+			}
+			if hasStruct(input.Type) {
+				bindStructType[lang](input.Type, structs)
+			}
+		}
+		normalized.Outputs = make([]abi.Argument, len(original.Outputs))
+		copy(normalized.Outputs, original.Outputs)
+		for j, output := range normalized.Outputs {
+			if output.Name != "" {
+				normalized.Outputs[j].Name = capitalise(output.Name)
+			}
+			if hasStruct(output.Type) {
+				bindStructType[lang](output.Type, structs)
+			}
+		}
+		// Append the methods to the call or transact lists
+		if original.IsConstant() {
+			calls[original.Name] = &tmplMethod{Original: original, Normalized: normalized, Structured: structured(original.Outputs)}
+		} else {
+			transacts[original.Name] = &tmplMethod{Original: original, Normalized: normalized, Structured: structured(original.Outputs)}
+		}
+	}
+	for _, original := range evmABI.Events {
+		// Skip anonymous events as they don't support explicit filtering
+		if original.Anonymous {
+			continue
+		}
+		// Normalize the event for capital cases and non-anonymous outputs
+		normalized := original
+
+		// Ensure there is no duplicated identifier
+		normalizedName := methodNormalizer[lang](alias(aliases, original.Name))
+		// Name shouldn't start with a digit. It will make the generated code invalid.
+		if len(normalizedName) > 0 && unicode.IsDigit(rune(normalizedName[0])) {
+			normalizedName = fmt.Sprintf("E%s", normalizedName)
+			normalizedName = abi.ResolveNameConflict(normalizedName, func(name string) bool {
+				_, ok := eventIdentifiers[name]
+				return ok
+			})
+		}
+		if eventIdentifiers[normalizedName] {
+			return nil, fmt.Errorf("duplicated identifier \"%s\"(normalized \"%s\"), use --alias for renaming", original.Name, normalizedName)
+		}
+		eventIdentifiers[normalizedName] = true
+		normalized.Name = normalizedName
+
+		used := make(map[string]bool)
+		normalized.Inputs = make([]abi.Argument, len(original.Inputs))
+		copy(normalized.Inputs, original.Inputs)
+		for j, input := range normalized.Inputs {
+			if input.Name == "" || isKeyWord(input.Name) {
+				normalized.Inputs[j].Name = fmt.Sprintf("arg%d", j)
+			}
+			// Event is a bit special, we need to define event struct in binding,
+			// ensure there is no camel-case-style name conflict.
+			for index := 0; ; index++ {
+				if !used[capitalise(normalized.Inputs[j].Name)] {
+					used[capitalise(normalized.Inputs[j].Name)] = true
+					break
+				}
+				normalized.Inputs[j].Name = fmt.Sprintf("%s%d", normalized.Inputs[j].Name, index)
+			}
+			if hasStruct(input.Type) {
+				bindStructType[lang](input.Type, structs)
+			}
+		}
+		// Append the event to the accumulator list
+		events[original.Name] = &tmplEvent{Original: original, Normalized: normalized}
+	}
+	// Add two special fallback functions if they exist
+	if evmABI.HasFallback() {
+		fallback = &tmplMethod{Original: evmABI.Fallback}
+	}
+	if evmABI.HasReceive() {
+		receive = &tmplMethod{Original: evmABI.Receive}
+	}
+	return &tmplContract{
+		Type:        capitalise(typ),
+		InputABI:    strings.ReplaceAll(strippedABI, "\"", "\\\""),
+		Constructor: evmABI.Constructor,
+		Calls:       calls,
+		Transacts:   transacts,
+		Fallback:    fallback,
+		Receive:     receive,
+		Events:      events,
+		Libraries:   make(map[string]string),
+	}, nil
+}
+
 // bindType is a set of type binders that convert Solidity types to some supported
 // programming language types.
 var bindType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
@@ -0,0 +1,112 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bind
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errPrecompileNoMethods  = errors.New("precompile ABI has no methods")
+	errPrecompileFallback   = errors.New("fallback and receive functions are not supported by precompiles")
+	errPrecompileBadAddress = errors.New("invalid precompile address")
+)
+
+// tmplPrecompile is the data structure required to fill the precompile templates.
+type tmplPrecompile struct {
+	Package  string                 // Name of the package to place the generated files in
+	Contract *tmplContract          // Contract interface implemented by the precompile
+	Methods  []*tmplMethod          // Calls and transacts of the contract, sorted by name
+	Structs  map[string]*tmplStruct // Contract struct type definitions
+	Address  common.Address         // Address the precompile is registered at
+}
+
+// precompileFiles maps the files of a generated precompile package to their
+// templates.
+var precompileFiles = map[string]string{
+	"contract.go":      tmplPrecompileContract,
+	"config.go":        tmplPrecompileConfig,
+	"module.go":        tmplPrecompileModule,
+	"contract_test.go": tmplPrecompileContractTest,
+	"config_test.go":   tmplPrecompileConfigTest,
+}
+
+// BindPrecompile generates the skeleton of a stateful precompile package [pkg]
+// implementing the Solidity interface [abiJSON] at [address]: the ABI packing
+// and selector dispatch of the contract, its config, module registration and
+// tests built on precompile/testutils. It returns the contents of each file of
+// the package, keyed by file name, including the ABI embedded by the contract.
+func BindPrecompile(typ string, abiJSON string, pkg string, address string) (map[string][]byte, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: %q", errPrecompileBadAddress, address)
+	}
+	structs := make(map[string]*tmplStruct)
+	contract, err := normalizeContract(typ, abiJSON, LangGo, nil, structs)
+	if err != nil {
+		return nil, err
+	}
+	if contract.Fallback != nil || contract.Receive != nil {
+		return nil, errPrecompileFallback
+	}
+	methods := make([]*tmplMethod, 0, len(contract.Calls)+len(contract.Transacts))
+	for _, method := range contract.Calls {
+		methods = append(methods, method)
+	}
+	for _, method := range contract.Transacts {
+		methods = append(methods, method)
+	}
+	if len(methods) == 0 {
+		return nil, errPrecompileNoMethods
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Original.Name < methods[j].Original.Name
+	})
+	// Multiple outputs are packed from a struct, so they all need a field name.
+	for _, method := range methods {
+		if len(method.Normalized.Outputs) < 2 {
+			continue
+		}
+		for j, output := range method.Normalized.Outputs {
+			if output.Name == "" {
+				method.Normalized.Outputs[j].Name = fmt.Sprintf("Output%d", j)
+			}
+		}
+	}
+	data := &tmplPrecompile{
+		Package:  pkg,
+		Contract: contract,
+		Methods:  methods,
+		Structs:  structs,
+		Address:  common.HexToAddress(address),
+	}
+	funcs := map[string]interface{}{
+		"bindtype":     bindType[LangGo],
+		"capitalise":   capitalise,
+		"decapitalise": decapitalise,
+	}
+	files := map[string][]byte{
+		"contract.abi": []byte(strings.TrimSpace(abiJSON) + "\n"),
+	}
+	for name, source := range precompileFiles {
+		buffer := new(bytes.Buffer)
+		tmpl := template.Must(template.New(name).Funcs(funcs).Parse(source))
+		if err := tmpl.Execute(buffer, data); err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", name, err)
+		}
+		code, err := format.Source(buffer.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %v\n%s", name, err, buffer)
+		}
+		files[name] = code
+	}
+	return files, nil
+}
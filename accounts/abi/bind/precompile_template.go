@@ -0,0 +1,454 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bind
+
+// tmplPrecompileHeader is the notice at the top of every generated precompile
+// file. The files are skeletons to be completed, so they are not marked as
+// generated code which should not be edited.
+const tmplPrecompileHeader = `// Code generated by precompilegen from the {{.Contract.Type}} interface.
+// This file is a skeleton: implement the TODOs and review the gas costs
+// before activating the precompile.
+`
+
+// tmplPrecompileContract is the template of the contract.go file of a
+// generated precompile, packing the ABI and dispatching the selectors.
+const tmplPrecompileContract = tmplPrecompileHeader + `
+package {{.Package}}
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/accounts/abi"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/vmerrs"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Gas costs of the functions of the precompile.
+const (
+{{- range .Methods}}
+	{{.Normalized.Name}}GasCost uint64 = {{if .Original.IsConstant}}contract.ReadGasCostPerSlot{{else}}contract.WriteGasCostPerSlot{{end}} // TODO: set the gas cost of {{.Original.Name}}
+{{- end}}
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = abi.ConvertType
+	_ = big.NewInt
+	_ = common.Big0
+	_ = vmerrs.ErrWriteProtection
+)
+
+var errNotImplemented = errors.New("not implemented")
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// {{.Contract.Type}}RawABI contains the raw ABI of {{.Contract.Type}} contract.
+	//go:embed contract.abi
+	{{.Contract.Type}}RawABI string
+
+	{{.Contract.Type}}ABI = contract.ParseABI({{.Contract.Type}}RawABI)
+
+	{{.Contract.Type}}Precompile = create{{.Contract.Type}}Precompile()
+)
+{{range .Structs}}
+// {{.Name}} is an auto generated low-level Go binding around an user-defined struct.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+{{end}}
+{{- range .Methods}}
+{{- $inputs := len .Normalized.Inputs}}
+{{- $outputs := len .Normalized.Outputs}}
+{{- if gt $inputs 1}}
+
+// {{.Normalized.Name}}Input is the input of {{.Original.Name}}.
+type {{.Normalized.Name}}Input struct {
+{{- range .Normalized.Inputs}}
+	{{capitalise .Name}} {{bindtype .Type $.Structs}}
+{{- end}}
+}
+{{- end}}
+{{- if gt $outputs 1}}
+
+// {{.Normalized.Name}}Output is the output of {{.Original.Name}}.
+type {{.Normalized.Name}}Output struct {
+{{- range .Normalized.Outputs}}
+	{{capitalise .Name}} {{bindtype .Type $.Structs}}
+{{- end}}
+}
+{{- end}}
+{{- if eq $inputs 0}}
+
+// Pack{{.Normalized.Name}} packs the selector of {{.Original.Name}}.
+// This function is mostly used for tests.
+func Pack{{.Normalized.Name}}() ([]byte, error) {
+	return {{$.Contract.Type}}ABI.Pack("{{.Original.Name}}")
+}
+{{- else if eq $inputs 1}}
+{{- $input := index .Normalized.Inputs 0}}
+
+// Unpack{{.Normalized.Name}}Input attempts to unpack [input] into the {{bindtype $input.Type $.Structs}} type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func Unpack{{.Normalized.Name}}Input(input []byte) ({{bindtype $input.Type $.Structs}}, error) {
+	var unpacked {{bindtype $input.Type $.Structs}}
+	// Strict mode is not used since it was disabled with Durango.
+	res, err := {{$.Contract.Type}}ABI.UnpackInput("{{.Original.Name}}", input, false)
+	if err != nil {
+		return unpacked, err
+	}
+	unpacked = *abi.ConvertType(res[0], new({{bindtype $input.Type $.Structs}})).(*{{bindtype $input.Type $.Structs}})
+	return unpacked, nil
+}
+
+// Pack{{.Normalized.Name}} packs [{{$input.Name}}] of type {{bindtype $input.Type $.Structs}} into the appropriate arguments for {{.Original.Name}}.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func Pack{{.Normalized.Name}}({{$input.Name}} {{bindtype $input.Type $.Structs}}) ([]byte, error) {
+	return {{$.Contract.Type}}ABI.Pack("{{.Original.Name}}", {{$input.Name}})
+}
+{{- else}}
+
+// Unpack{{.Normalized.Name}}Input attempts to unpack [input] as {{.Normalized.Name}}Input
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func Unpack{{.Normalized.Name}}Input(input []byte) ({{.Normalized.Name}}Input, error) {
+	inputStruct := {{.Normalized.Name}}Input{}
+	// Strict mode is not used since it was disabled with Durango.
+	err := {{$.Contract.Type}}ABI.UnpackInputIntoInterface(&inputStruct, "{{.Original.Name}}", input, false)
+
+	return inputStruct, err
+}
+
+// Pack{{.Normalized.Name}} packs [inputStruct] of type {{.Normalized.Name}}Input into the appropriate arguments for {{.Original.Name}}.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func Pack{{.Normalized.Name}}(inputStruct {{.Normalized.Name}}Input) ([]byte, error) {
+	return {{$.Contract.Type}}ABI.Pack("{{.Original.Name}}",
+{{- range .Normalized.Inputs}}
+		inputStruct.{{capitalise .Name}},
+{{- end}}
+	)
+}
+{{- end}}
+{{- if eq $outputs 1}}
+{{- $output := index .Normalized.Outputs 0}}
+
+// Pack{{.Normalized.Name}}Output attempts to pack given [output] of type {{bindtype $output.Type $.Structs}}
+// to conform the ABI outputs.
+func Pack{{.Normalized.Name}}Output(output {{bindtype $output.Type $.Structs}}) ([]byte, error) {
+	return {{$.Contract.Type}}ABI.PackOutput("{{.Original.Name}}", output)
+}
+
+// Unpack{{.Normalized.Name}}Output attempts to unpack given [output] into the {{bindtype $output.Type $.Structs}} type output
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func Unpack{{.Normalized.Name}}Output(output []byte) ({{bindtype $output.Type $.Structs}}, error) {
+	var unpacked {{bindtype $output.Type $.Structs}}
+	res, err := {{$.Contract.Type}}ABI.Unpack("{{.Original.Name}}", output)
+	if err != nil {
+		return unpacked, err
+	}
+	unpacked = *abi.ConvertType(res[0], new({{bindtype $output.Type $.Structs}})).(*{{bindtype $output.Type $.Structs}})
+	return unpacked, nil
+}
+{{- else if gt $outputs 1}}
+
+// Pack{{.Normalized.Name}}Output attempts to pack given [outputStruct] of type {{.Normalized.Name}}Output
+// to conform the ABI outputs.
+func Pack{{.Normalized.Name}}Output(outputStruct {{.Normalized.Name}}Output) ([]byte, error) {
+	return {{$.Contract.Type}}ABI.PackOutput("{{.Original.Name}}",
+{{- range .Normalized.Outputs}}
+		outputStruct.{{capitalise .Name}},
+{{- end}}
+	)
+}
+
+// Unpack{{.Normalized.Name}}Output attempts to unpack [output] as {{.Normalized.Name}}Output
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func Unpack{{.Normalized.Name}}Output(output []byte) ({{.Normalized.Name}}Output, error) {
+	outputStruct := {{.Normalized.Name}}Output{}
+	err := {{$.Contract.Type}}ABI.UnpackIntoInterface(&outputStruct, "{{.Original.Name}}", output)
+
+	return outputStruct, err
+}
+{{- end}}
+
+// {{decapitalise .Normalized.Name}} implements {{.Original.Sig}} of the precompile.
+func {{decapitalise .Normalized.Name}}(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, {{.Normalized.Name}}GasCost); err != nil {
+		return nil, 0, err
+	}
+{{- if not .Original.IsConstant}}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+{{- end}}
+{{- if gt $inputs 0}}
+	inputStruct, err := Unpack{{.Normalized.Name}}Input(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("invalid {{.Original.Name}} input: %w", err)
+	}
+	_ = inputStruct
+{{- end}}
+
+	// TODO: implement {{.Original.Name}}{{if gt $outputs 0}} and return its output packed with Pack{{.Normalized.Name}}Output{{end}}.
+	return nil, remainingGas, errNotImplemented
+}
+{{- end}}
+{{- range .Contract.Events}}
+
+// Pack{{.Normalized.Name}}Event packs the given arguments into {{.Original.Name}} events including topics and data.
+func Pack{{.Normalized.Name}}Event({{range $i, $arg := .Normalized.Inputs}}{{if $i}}, {{end}}{{$arg.Name}} {{bindtype $arg.Type $.Structs}}{{end}}) ([]common.Hash, []byte, error) {
+	return {{$.Contract.Type}}ABI.PackEvent("{{.Original.Name}}"{{range .Normalized.Inputs}}, {{.Name}}{{end}})
+}
+
+// Emit{{.Normalized.Name}}Event adds a {{.Original.Name}} log by the precompile to the state of
+// [accessibleState], after deducting its log gas from [suppliedGas].
+func Emit{{.Normalized.Name}}Event(accessibleState contract.AccessibleState, suppliedGas uint64{{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $.Structs}}{{end}}) (uint64, error) {
+	return contract.EmitEvent(accessibleState, ContractAddress, {{$.Contract.Type}}ABI.Events["{{.Original.Name}}"], suppliedGas{{range .Normalized.Inputs}}, {{.Name}}{{end}})
+}
+{{- end}}
+
+// create{{.Contract.Type}}Precompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+func create{{.Contract.Type}}Precompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+{{- range .Methods}}
+		"{{.Original.Name}}": {{decapitalise .Normalized.Name}},
+{{- end}}
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := {{.Contract.Type}}ABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
+`
+
+// tmplPrecompileConfig is the template of the config.go file of a generated
+// precompile.
+const tmplPrecompileConfig = tmplPrecompileHeader + `
+package {{.Package}}
+
+import (
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for {{.Contract.Type}}.
+type Config struct {
+	precompileconfig.Upgrade
+	// TODO: add the configuration of {{.Contract.Type}}
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// {{.Contract.Type}}.
+func NewConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables {{.Contract.Type}}.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the {{.Contract.Type}} precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	// TODO: verify the configuration of {{.Contract.Type}}
+	return nil
+}
+
+// Equal returns true if [s] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(s precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (s).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade)
+}
+`
+
+// tmplPrecompileModule is the template of the module.go file of a generated
+// precompile, registering it with the modules registerer.
+const tmplPrecompileModule = tmplPrecompileHeader + `
+package {{.Package}}
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/modules"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ contract.Configurator = &configurator{}
+
+// ConfigKey is the key used in json config files to specify this precompile config.
+// must be unique across all precompiles.
+const ConfigKey = "{{decapitalise .Contract.Type}}Config"
+
+// ContractAddress is the address of the {{.Contract.Type}} precompile contract
+var ContractAddress = common.HexToAddress("{{.Address.Hex}}")
+
+// Module is the precompile module. It is used to register the precompile contract.
+var Module = modules.Module{
+	ConfigKey:    ConfigKey,
+	Address:      ContractAddress,
+	Contract:     {{.Contract.Type}}Precompile,
+	ABI:          {{.Contract.Type}}ABI,
+	Configurator: &configurator{},
+}
+
+type configurator struct{}
+
+func init() {
+	// Register the precompile module.
+	// Each precompile contract registers itself through [RegisterModule] function.
+	if err := modules.RegisterModule(Module); err != nil {
+		panic(err)
+	}
+}
+
+// MakeConfig returns a new precompile config instance.
+// This is required to Marshal/Unmarshal the precompile config.
+func (*configurator) MakeConfig() precompileconfig.Config {
+	return new(Config)
+}
+
+// Configure configures [state] with the given [cfg] config when the precompile
+// is enabled.
+func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg precompileconfig.Config, state contract.StateDB, _ contract.ConfigurationBlockContext) error {
+	if _, ok := cfg.(*Config); !ok {
+		return fmt.Errorf("expected config type %T, got %T: %v", &Config{}, cfg, cfg)
+	}
+	// TODO: initialize the state of {{.Contract.Type}}
+	return nil
+}
+`
+
+// tmplPrecompileContractTest is the template of the contract_test.go file of
+// a generated precompile, checking the gas and read-only handling of every
+// function.
+const tmplPrecompileContractTest = tmplPrecompileHeader + `
+package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TODO: add tests of the behavior of each function once implemented.
+func Test{{.Contract.Type}}Run(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+
+	tests := map[string]testutils.PrecompileTest{
+{{- range .Methods}}
+		"{{.Original.Name}} insufficient gas": {
+			Caller:      callerAddr,
+			Input:       {{$.Contract.Type}}ABI.Methods["{{.Original.Name}}"].ID,
+			SuppliedGas: {{.Normalized.Name}}GasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+{{- if not .Original.IsConstant}}
+		"{{.Original.Name}} readOnly": {
+			Caller:      callerAddr,
+			Input:       {{$.Contract.Type}}ABI.Methods["{{.Original.Name}}"].ID,
+			SuppliedGas: {{.Normalized.Name}}GasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+{{- end}}
+{{- end}}
+	}
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+`
+
+// tmplPrecompileConfigTest is the template of the config_test.go file of a
+// generated precompile.
+const tmplPrecompileConfigTest = tmplPrecompileHeader + `
+package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	tests := map[string]testutils.ConfigVerifyTest{
+		"valid config": {
+			Config: NewConfig(utils.NewUint64(3)),
+		},
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqual(t *testing.T) {
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    NewConfig(utils.NewUint64(4)),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    NewConfig(utils.NewUint64(3)),
+			Expected: true,
+		},
+	}
+	testutils.RunEqualTests(t, tests)
+}
+`
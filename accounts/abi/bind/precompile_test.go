@@ -0,0 +1,103 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bind
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const testPrecompileABI = `[
+	{"type":"function","name":"getCounter","stateMutability":"view","inputs":[],"outputs":[{"name":"counter","type":"uint256"}]},
+	{"type":"function","name":"increment","stateMutability":"nonpayable","inputs":[{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"setEntry","stateMutability":"nonpayable","inputs":[{"name":"key","type":"bytes32"},{"name":"entry","type":"tuple","internalType":"struct Entry","components":[{"name":"owner","type":"address"},{"name":"value","type":"uint64"}]}],"outputs":[{"name":"","type":"bool"},{"name":"","type":"uint64"}]},
+	{"type":"event","name":"EntrySet","anonymous":false,"inputs":[{"name":"owner","type":"address","indexed":true},{"name":"key","type":"bytes32","indexed":false}]}
+]`
+
+// Tests that precompile packages generated by the binder compile and that the
+// generated tests pass against the skeleton.
+func TestPrecompileBindings(t *testing.T) {
+	t.Parallel()
+	// Skip the test if no Go command can be found
+	gocmd := runtime.GOROOT() + "/bin/go"
+	if !common.FileExist(gocmd) {
+		t.Skip("go sdk not found for testing")
+	}
+	pkg := filepath.Join(t.TempDir(), "counter")
+	if err := os.MkdirAll(pkg, 0700); err != nil {
+		t.Fatalf("failed to create package: %v", err)
+	}
+	files, err := BindPrecompile("Counter", testPrecompileABI, "counter", "0x0300000000000000000000000000000000000010")
+	if err != nil {
+		t.Fatalf("failed to generate precompile: %v", err)
+	}
+	for _, name := range []string{"contract.abi", "contract.go", "config.go", "module.go", "contract_test.go", "config_test.go"} {
+		code, ok := files[name]
+		if !ok {
+			t.Fatalf("missing generated file %s", name)
+		}
+		if err := os.WriteFile(filepath.Join(pkg, name), code, 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	// Convert the package to go modules and use the current source for coreth
+	moder := exec.Command(gocmd, "mod", "init", "counter")
+	moder.Dir = pkg
+	if out, err := moder.CombinedOutput(); err != nil {
+		t.Fatalf("failed to convert precompile to modules: %v\n%s", err, out)
+	}
+	pwd, _ := os.Getwd()
+	replacer := exec.Command(gocmd, "mod", "edit", "-x", "-require", "github.com/ava-labs/coreth@v0.0.0", "-replace", "github.com/ava-labs/coreth="+filepath.Join(pwd, "..", "..", "..")) // Repo root
+	replacer.Dir = pkg
+	if out, err := replacer.CombinedOutput(); err != nil {
+		t.Fatalf("failed to replace precompile dependency to current source tree: %v\n%s", err, out)
+	}
+	tidier := exec.Command(gocmd, "mod", "tidy", "-compat=1.21")
+	tidier.Dir = pkg
+	if out, err := tidier.CombinedOutput(); err != nil {
+		t.Fatalf("failed to tidy Go module file: %v\n%s", err, out)
+	}
+	cmd := exec.Command(gocmd, "test", "-v", "-count", "1")
+	cmd.Dir = pkg
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to run precompile test: %v\n%s", err, out)
+	}
+}
+
+func TestPrecompileBindingsErrors(t *testing.T) {
+	tests := map[string]struct {
+		abi     string
+		address string
+		err     error
+	}{
+		"invalid address": {
+			abi:     testPrecompileABI,
+			address: "0x03",
+			err:     errPrecompileBadAddress,
+		},
+		"no methods": {
+			abi:     `[{"type":"event","name":"Ping","anonymous":false,"inputs":[]}]`,
+			address: "0x0300000000000000000000000000000000000010",
+			err:     errPrecompileNoMethods,
+		},
+		"fallback": {
+			abi:     `[{"type":"fallback","stateMutability":"nonpayable"},{"type":"function","name":"ping","stateMutability":"view","inputs":[],"outputs":[]}]`,
+			address: "0x0300000000000000000000000000000000000010",
+			err:     errPrecompileFallback,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := BindPrecompile("Test", test.abi, "test", test.address); !errors.Is(err, test.err) {
+				t.Fatalf("error mismatch: have %v, want %v", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/coreth/accounts/abi/bind"
+	"github.com/ava-labs/coreth/cmd/utils"
+	"github.com/ava-labs/coreth/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// Flags needed by precompilegen
+	abiFlag = &cli.StringFlag{
+		Name:  "abi",
+		Usage: "Path to the Solidity interface ABI json of the precompile, - for STDIN",
+	}
+	typeFlag = &cli.StringFlag{
+		Name:  "type",
+		Usage: "Name of the precompile contract (default = package name)",
+	}
+	pkgFlag = &cli.StringFlag{
+		Name:  "pkg",
+		Usage: "Package name to generate the precompile into",
+	}
+	addressFlag = &cli.StringFlag{
+		Name:  "address",
+		Usage: "Hex address of the precompile contract",
+	}
+	outFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "Output directory of the precompile package (default = precompile/contracts/<pkg>)",
+	}
+	registryFlag = &cli.StringFlag{
+		Name:  "registry",
+		Usage: "Path to the registry file importing the precompile, empty to skip the registration",
+		Value: filepath.Join("precompile", "registry", "registry.go"),
+	}
+	importFlag = &cli.StringFlag{
+		Name:  "import",
+		Usage: "Import path of the precompile package (default = derived from the output directory and go.mod)",
+	}
+)
+
+var app = flags.NewApp("Stateful precompile code generator")
+
+func init() {
+	app.Name = "precompilegen"
+	app.Flags = []cli.Flag{
+		abiFlag,
+		typeFlag,
+		pkgFlag,
+		addressFlag,
+		outFlag,
+		registryFlag,
+		importFlag,
+	}
+	app.Action = precompilegen
+}
+
+func precompilegen(c *cli.Context) error {
+	pkg := c.String(pkgFlag.Name)
+	if pkg == "" {
+		utils.Fatalf("No destination package specified (--pkg)")
+	}
+	if c.String(abiFlag.Name) == "" {
+		utils.Fatalf("No input ABI specified (--abi)")
+	}
+	if c.String(addressFlag.Name) == "" {
+		utils.Fatalf("No precompile address specified (--address)")
+	}
+	var (
+		abi []byte
+		err error
+	)
+	input := c.String(abiFlag.Name)
+	if input == "-" {
+		abi, err = io.ReadAll(os.Stdin)
+	} else {
+		abi, err = os.ReadFile(input)
+	}
+	if err != nil {
+		utils.Fatalf("Failed to read input ABI: %v", err)
+	}
+	kind := c.String(typeFlag.Name)
+	if kind == "" {
+		kind = pkg
+	}
+	files, err := bind.BindPrecompile(kind, string(abi), pkg, c.String(addressFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to generate precompile: %v", err)
+	}
+
+	out := c.String(outFlag.Name)
+	if out == "" {
+		out = filepath.Join("precompile", "contracts", pkg)
+	}
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		utils.Fatalf("Failed to create output directory: %v", err)
+	}
+	for name, code := range files {
+		path := filepath.Join(out, name)
+		if _, err := os.Stat(path); err == nil {
+			utils.Fatalf("Refusing to overwrite existing file %s", path)
+		}
+		if err := os.WriteFile(path, code, 0o600); err != nil {
+			utils.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	log.Info("Generated precompile", "type", kind, "dir", out)
+
+	registry := c.String(registryFlag.Name)
+	if registry == "" {
+		return nil
+	}
+	importPath := c.String(importFlag.Name)
+	if importPath == "" {
+		if importPath, err = packageImportPath(out); err != nil {
+			utils.Fatalf("Failed to derive the import path of the precompile: %v", err)
+		}
+	}
+	if err := registerPrecompile(registry, importPath); err != nil {
+		utils.Fatalf("Failed to register precompile: %v", err)
+	}
+	log.Info("Registered precompile", "registry", registry, "import", importPath)
+	return nil
+}
+
+func main() {
+	log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, true)))
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
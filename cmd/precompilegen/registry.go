@@ -0,0 +1,112 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	errNoModule        = errors.New("no go.mod found")
+	errNoImportBlock   = errors.New("no import block found")
+	errNoModuleDeclare = errors.New("no module declaration found")
+)
+
+// registerPrecompile adds a blank import of [importPath] to the import block
+// of the registry file at [path], so the init function of the precompile
+// registers its module. It is a no-op if the package is already imported.
+func registerPrecompile(path string, importPath string) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	for _, spec := range file.Imports {
+		if existing, _ := strconv.Unquote(spec.Path.Value); existing == importPath {
+			return nil
+		}
+	}
+	var rparen token.Pos
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && gen.Rparen.IsValid() {
+			rparen = gen.Rparen
+			break
+		}
+	}
+	if !rparen.IsValid() {
+		return fmt.Errorf("%w in %s", errNoImportBlock, path)
+	}
+	offset := fset.Position(rparen).Offset
+	var updated bytes.Buffer
+	updated.Write(src[:offset])
+	fmt.Fprintf(&updated, "\t_ %q\n", importPath)
+	updated.Write(src[offset:])
+
+	// gofmt sorts the imports of the block
+	formatted, err := format.Source(updated.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, formatted, 0o600)
+}
+
+// packageImportPath returns the import path of the package in [dir], from the
+// module declared by the closest go.mod above it.
+func packageImportPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		modFile, err := os.Open(filepath.Join(root, "go.mod"))
+		if err == nil {
+			defer modFile.Close()
+			module, err := modulePath(modFile)
+			if err != nil {
+				return "", err
+			}
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("%w above %s", errNoModule, abs)
+		}
+	}
+}
+
+// modulePath returns the module path declared by a go.mod file.
+func modulePath(modFile *os.File) (string, error) {
+	scanner := bufio.NewScanner(modFile)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", errNoModuleDeclare
+}
@@ -53,6 +53,15 @@ type ChainHeaderReader interface {
 
 	// GetHeaderByHash retrieves a block header from the database by its hash.
 	GetHeaderByHash(hash common.Hash) *types.Header
+
+	// GetFeeConfigAt retrieves the fee configuration stored by the fee manager
+	// precompile in the state of [parent], or nil if none is stored.
+	GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error)
+
+	// GetCoinbaseAt retrieves the address receiving the fees of the blocks, as
+	// stored by the reward manager precompile in the state of [parent], and
+	// true if block producers choose their fee recipient instead.
+	GetCoinbaseAt(parent *types.Header) (common.Address, bool, error)
 }
 
 // ChainReader defines a small collection of methods needed to access the local
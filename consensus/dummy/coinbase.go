@@ -0,0 +1,68 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dummy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/constants"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errNoCoinbaseReader = errors.New("reward manager is active but no coinbase reader was provided")
+	errInvalidCoinbase  = errors.New("invalid coinbase")
+)
+
+// CoinbaseReader reads the reward configuration stored by the reward manager
+// precompile, which decides where the fees of the blocks go while the
+// precompile is active.
+type CoinbaseReader interface {
+	// GetCoinbaseAt returns the address receiving the fees of the blocks, as
+	// stored in the state of [parent], and true if block producers choose
+	// their fee recipient instead.
+	GetCoinbaseAt(parent *types.Header) (common.Address, bool, error)
+}
+
+// RewardCoinbase returns the coinbase of a block at [timestamp] built on
+// [parent], and true if block producers choose their fee recipient instead.
+// Fees are burned unless the reward manager precompile is active at
+// [timestamp] and was already active at [parent], whose state holds the
+// reward configuration.
+// [chain] is only used if the precompile is active, so it can be nil for
+// chains that never activate it.
+func RewardCoinbase(config *params.ChainConfig, chain CoinbaseReader, parent *types.Header, timestamp uint64) (common.Address, bool, error) {
+	if !config.IsPrecompileEnabled(rewardmanager.ContractAddress, timestamp) || !config.IsPrecompileEnabled(rewardmanager.ContractAddress, parent.Time) {
+		return constants.BlackholeAddr, false, nil
+	}
+	if chain == nil {
+		return common.Address{}, false, errNoCoinbaseReader
+	}
+	coinbase, allowFeeRecipients, err := chain.GetCoinbaseAt(parent)
+	if err != nil {
+		return common.Address{}, false, fmt.Errorf("failed to get coinbase at block %d: %w", parent.Number, err)
+	}
+	return coinbase, allowFeeRecipients, nil
+}
+
+// verifyCoinbase verifies that [header] sends its fees to the coinbase
+// required by the reward manager precompile, if it is active. The coinbase of
+// the other blocks is left to the VM.
+func (eng *DummyEngine) verifyCoinbase(config *params.ChainConfig, chain CoinbaseReader, header *types.Header, parent *types.Header) error {
+	if eng.consensusMode.ModeSkipCoinbase || !config.IsPrecompileEnabled(rewardmanager.ContractAddress, header.Time) {
+		return nil
+	}
+	coinbase, allowFeeRecipients, err := RewardCoinbase(config, chain, parent, header.Time)
+	if err != nil {
+		return err
+	}
+	if !allowFeeRecipients && header.Coinbase != coinbase {
+		return fmt.Errorf("%w: have %s, want %s", errInvalidCoinbase, header.Coinbase, coinbase)
+	}
+	return nil
+}
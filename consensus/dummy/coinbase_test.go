@@ -0,0 +1,80 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dummy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/constants"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// testCoinbaseReader returns the same reward config for every parent.
+type testCoinbaseReader struct {
+	coinbase           common.Address
+	allowFeeRecipients bool
+}
+
+func (r *testCoinbaseReader) GetCoinbaseAt(*types.Header) (common.Address, bool, error) {
+	return r.coinbase, r.allowFeeRecipients, nil
+}
+
+func TestRewardManagerCoinbase(t *testing.T) {
+	require := require.New(t)
+	cpcfg := *params.TestChainConfig
+	config := &cpcfg
+	config.UpgradeConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: rewardmanager.NewConfig(utils.NewUint64(10), nil, nil, nil, nil)},
+	}
+	rewardAddress := common.Address{'r', 'e', 'w', 'a', 'r', 'd'}
+	feeRecipient := common.Address{'f', 'e', 'e'}
+	reader := &testCoinbaseReader{coinbase: rewardAddress}
+	eng := NewFaker()
+	parent := &types.Header{Number: big.NewInt(1), Time: 9}
+	header := &types.Header{Number: big.NewInt(2), Time: 9, Coinbase: feeRecipient}
+
+	// Prior to the activation of the reward manager, the chain is not read and
+	// the coinbase is left to the VM.
+	coinbase, allowFeeRecipients, err := RewardCoinbase(config, nil, parent, 9)
+	require.NoError(err)
+	require.False(allowFeeRecipients)
+	require.Equal(constants.BlackholeAddr, coinbase)
+	require.NoError(eng.verifyCoinbase(config, nil, header, parent))
+
+	// Fees are burned in the block activating the reward manager.
+	header.Time = 10
+	coinbase, _, err = RewardCoinbase(config, nil, parent, 10)
+	require.NoError(err)
+	require.Equal(constants.BlackholeAddr, coinbase)
+	require.ErrorIs(eng.verifyCoinbase(config, nil, header, parent), errInvalidCoinbase)
+
+	// Once active, the reward manager requires a reader.
+	parent.Time = 10
+	_, _, err = RewardCoinbase(config, nil, parent, 10)
+	require.ErrorIs(err, errNoCoinbaseReader)
+
+	// The stored reward address is required.
+	coinbase, allowFeeRecipients, err = RewardCoinbase(config, reader, parent, 10)
+	require.NoError(err)
+	require.False(allowFeeRecipients)
+	require.Equal(rewardAddress, coinbase)
+	require.ErrorIs(eng.verifyCoinbase(config, reader, header, parent), errInvalidCoinbase)
+	header.Coinbase = rewardAddress
+	require.NoError(eng.verifyCoinbase(config, reader, header, parent))
+
+	// Any coinbase is valid while fee recipients are allowed.
+	reader.allowFeeRecipients = true
+	header.Coinbase = feeRecipient
+	require.NoError(eng.verifyCoinbase(config, reader, header, parent))
+
+	// Engines skipping the coinbase accept any coinbase.
+	reader.allowFeeRecipients = false
+	require.NoError(NewCoinbaseFaker().verifyCoinbase(config, reader, header, parent))
+}
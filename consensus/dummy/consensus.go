@@ -110,7 +110,7 @@ func NewFullFaker() *DummyEngine {
 	}
 }
 
-func (eng *DummyEngine) verifyHeaderGasFields(config *params.ChainConfig, header *types.Header, parent *types.Header) error {
+func (eng *DummyEngine) verifyHeaderGasFields(config *params.ChainConfig, chain consensus.ChainHeaderReader, header *types.Header, parent *types.Header) error {
 	// Verify that the gas limit is <= 2^63-1
 	if header.GasLimit > params.MaxGasLimit {
 		return fmt.Errorf("invalid gasLimit: have %v, max %v", header.GasLimit, params.MaxGasLimit)
@@ -119,13 +119,15 @@ func (eng *DummyEngine) verifyHeaderGasFields(config *params.ChainConfig, header
 	if header.GasUsed > header.GasLimit {
 		return fmt.Errorf("invalid gasUsed: have %d, gasLimit %d", header.GasUsed, header.GasLimit)
 	}
-	if config.IsCortina(header.Time) {
-		if header.GasLimit != params.CortinaGasLimit {
-			return fmt.Errorf("expected gas limit to be %d in Cortina, but found %d", params.CortinaGasLimit, header.GasLimit)
-		}
-	} else if config.IsApricotPhase1(header.Time) {
-		if header.GasLimit != params.ApricotPhase1GasLimit {
-			return fmt.Errorf("expected gas limit to be %d in ApricotPhase1, but found %d", params.ApricotPhase1GasLimit, header.GasLimit)
+	// The gas limit is static from ApricotPhase1 on, and may be set by the fee
+	// manager precompile.
+	staticGasLimit, isStatic, err := StaticGasLimit(config, chain, parent, header.Time)
+	if err != nil {
+		return fmt.Errorf("failed to get static gas limit: %w", err)
+	}
+	if isStatic {
+		if header.GasLimit != staticGasLimit {
+			return fmt.Errorf("expected gas limit to be %d, but found %d", staticGasLimit, header.GasLimit)
 		}
 	} else {
 		// Verify that the gas limit remains within allowed bounds
@@ -148,7 +150,7 @@ func (eng *DummyEngine) verifyHeaderGasFields(config *params.ChainConfig, header
 	} else {
 		// Verify baseFee and rollupWindow encoding as part of header verification
 		// starting in AP3
-		expectedRollupWindowBytes, expectedBaseFee, err := CalcBaseFee(config, parent, header.Time)
+		expectedRollupWindowBytes, expectedBaseFee, err := CalcBaseFee(config, chain, parent, header.Time)
 		if err != nil {
 			return fmt.Errorf("failed to calculate base fee: %w", err)
 		}
@@ -178,18 +180,10 @@ func (eng *DummyEngine) verifyHeaderGasFields(config *params.ChainConfig, header
 	}
 
 	// Enforce BlockGasCost constraints
-	blockGasCostStep := ApricotPhase4BlockGasCostStep
-	if config.IsApricotPhase5(header.Time) {
-		blockGasCostStep = ApricotPhase5BlockGasCostStep
-	}
-	expectedBlockGasCost := calcBlockGasCost(
-		ApricotPhase4TargetBlockRate,
-		ApricotPhase4MinBlockGasCost,
-		ApricotPhase4MaxBlockGasCost,
-		blockGasCostStep,
-		parent.BlockGasCost,
-		parent.Time, header.Time,
-	)
+	expectedBlockGasCost, err := CalcBlockGasCost(config, chain, parent, header.Time)
+	if err != nil {
+		return fmt.Errorf("failed to calculate block gas cost: %w", err)
+	}
 	if header.BlockGasCost == nil {
 		return errBlockGasCostNil
 	}
@@ -232,7 +226,7 @@ func (eng *DummyEngine) verifyHeader(chain consensus.ChainHeaderReader, header *
 		}
 	}
 	// Ensure gas-related header fields are correct
-	if err := eng.verifyHeaderGasFields(config, header, parent); err != nil {
+	if err := eng.verifyHeaderGasFields(config, chain, header, parent); err != nil {
 		return err
 	}
 
@@ -270,8 +264,9 @@ func (eng *DummyEngine) verifyHeader(chain consensus.ChainHeaderReader, header *
 		if err := eip4844.VerifyEIP4844Header(parent, header); err != nil {
 			return err
 		}
-		if *header.BlobGasUsed > 0 { // VerifyEIP4844Header ensures BlobGasUsed is non-nil
-			return fmt.Errorf("blobs not enabled on avalanche networks: used %d blob gas, expected 0", *header.BlobGasUsed)
+		// VerifyEIP4844Header ensures BlobGasUsed is non-nil
+		if *header.BlobGasUsed > 0 && !chain.Config().IsBlobTxs(header.Time) {
+			return fmt.Errorf("blobs not enabled on this network: used %d blob gas, expected 0", *header.BlobGasUsed)
 		}
 	}
 	return nil
@@ -392,6 +387,9 @@ func (eng *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *types
 			return err
 		}
 	}
+	if err := eng.verifyCoinbase(chain.Config(), chain, block.Header(), parent); err != nil {
+		return err
+	}
 	if chain.Config().IsApricotPhase4(block.Time()) {
 		// Validate extDataGasUsed and BlockGasCost match expectations
 		//
@@ -403,20 +401,12 @@ func (eng *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *types
 		if blockExtDataGasUsed := block.ExtDataGasUsed(); blockExtDataGasUsed == nil || !blockExtDataGasUsed.IsUint64() || blockExtDataGasUsed.Cmp(extDataGasUsed) != 0 {
 			return fmt.Errorf("invalid extDataGasUsed: have %d, want %d", blockExtDataGasUsed, extDataGasUsed)
 		}
-		blockGasCostStep := ApricotPhase4BlockGasCostStep
-		if chain.Config().IsApricotPhase5(block.Time()) {
-			blockGasCostStep = ApricotPhase5BlockGasCostStep
-		}
 		// Calculate the expected blockGasCost for this block.
 		// Note: this is a deterministic transtion that defines an exact block fee for this block.
-		blockGasCost := calcBlockGasCost(
-			ApricotPhase4TargetBlockRate,
-			ApricotPhase4MinBlockGasCost,
-			ApricotPhase4MaxBlockGasCost,
-			blockGasCostStep,
-			parent.BlockGasCost,
-			parent.Time, block.Time(),
-		)
+		blockGasCost, err := CalcBlockGasCost(chain.Config(), chain, parent, block.Time())
+		if err != nil {
+			return fmt.Errorf("failed to calculate block gas cost: %w", err)
+		}
 		// Verify the BlockGasCost set in the header matches the calculated value.
 		if blockBlockGasCost := block.BlockGasCost(); blockBlockGasCost == nil || !blockBlockGasCost.IsUint64() || blockBlockGasCost.Cmp(blockGasCost) != 0 {
 			return fmt.Errorf("invalid blockGasCost: have %d, want %d", blockBlockGasCost, blockGasCost)
@@ -450,24 +440,21 @@ func (eng *DummyEngine) FinalizeAndAssemble(chain consensus.ChainHeaderReader, h
 			return nil, err
 		}
 	}
+	// The fees were already paid to the coinbase while applying [txs], so a
+	// block paying the wrong coinbase cannot be fixed here.
+	if err := eng.verifyCoinbase(chain.Config(), chain, header, parent); err != nil {
+		return nil, err
+	}
 	if chain.Config().IsApricotPhase4(header.Time) {
 		header.ExtDataGasUsed = extDataGasUsed
 		if header.ExtDataGasUsed == nil {
 			header.ExtDataGasUsed = new(big.Int).Set(common.Big0)
 		}
-		blockGasCostStep := ApricotPhase4BlockGasCostStep
-		if chain.Config().IsApricotPhase5(header.Time) {
-			blockGasCostStep = ApricotPhase5BlockGasCostStep
-		}
 		// Calculate the required block gas cost for this block.
-		header.BlockGasCost = calcBlockGasCost(
-			ApricotPhase4TargetBlockRate,
-			ApricotPhase4MinBlockGasCost,
-			ApricotPhase4MaxBlockGasCost,
-			blockGasCostStep,
-			parent.BlockGasCost,
-			parent.Time, header.Time,
-		)
+		header.BlockGasCost, err = CalcBlockGasCost(chain.Config(), chain, parent, header.Time)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate block gas cost: %w", err)
+		}
 		// Verify that this block covers the block fee.
 		if err := eng.verifyBlockFee(
 			header.BaseFee,
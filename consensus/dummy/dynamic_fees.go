@@ -5,12 +5,14 @@ package dummy
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 )
@@ -33,20 +35,162 @@ var (
 	ApricotPhase4TargetBlockRate  uint64 = 2 // in seconds
 	ApricotPhase5BlockGasCostStep        = big.NewInt(200_000)
 	rollupWindow                  uint64 = 10
+
+	errNoFeeConfigReader = errors.New("fee manager is active but no fee config reader was provided")
 )
 
+// FeeConfigReader reads the fee configuration stored by the fee manager
+// precompile, which replaces the parameters of the network upgrades while the
+// precompile is active.
+type FeeConfigReader interface {
+	// GetFeeConfigAt returns the fee configuration stored in the state of
+	// [parent], or nil if none is stored.
+	GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error)
+}
+
+// FeeParams are the parameters of the dynamic fee algorithm.
+type FeeParams struct {
+	TargetGas                uint64   // Gas targeted within the rollup window
+	BaseFeeChangeDenominator *big.Int // Bounds the change of the base fee between blocks
+	MinBaseFee               *big.Int
+	MaxBaseFee               *big.Int // nil if the base fee is unbounded
+
+	TargetBlockRate  uint64 // in seconds
+	MinBlockGasCost  *big.Int
+	MaxBlockGasCost  *big.Int
+	BlockGasCostStep *big.Int // Change of the block gas cost per second off the target block rate
+}
+
+// FeeParamsAt returns the parameters of the dynamic fee algorithm in effect at
+// [timestamp].
+func FeeParamsAt(config *params.ChainConfig, timestamp uint64) FeeParams {
+	feeParams := FeeParams{
+		TargetGas:                params.ApricotPhase3TargetGas,
+		BaseFeeChangeDenominator: ApricotPhase4BaseFeeChangeDenominator,
+		MinBaseFee:               ApricotPhase3MinBaseFee,
+		MaxBaseFee:               ApricotPhase3MaxBaseFee,
+		TargetBlockRate:          ApricotPhase4TargetBlockRate,
+		MinBlockGasCost:          ApricotPhase4MinBlockGasCost,
+		MaxBlockGasCost:          ApricotPhase4MaxBlockGasCost,
+		BlockGasCostStep:         ApricotPhase4BlockGasCostStep,
+	}
+	switch {
+	case config.IsEtna(timestamp):
+		feeParams.MinBaseFee, feeParams.MaxBaseFee = EtnaMinBaseFee, nil
+	case config.IsApricotPhase5(timestamp):
+		feeParams.MinBaseFee, feeParams.MaxBaseFee = ApricotPhase4MinBaseFee, nil
+	case config.IsApricotPhase4(timestamp):
+		feeParams.MinBaseFee, feeParams.MaxBaseFee = ApricotPhase4MinBaseFee, ApricotPhase4MaxBaseFee
+	}
+	if config.IsApricotPhase5(timestamp) {
+		feeParams.TargetGas = params.ApricotPhase5TargetGas
+		feeParams.BaseFeeChangeDenominator = ApricotPhase5BaseFeeChangeDenominator
+		feeParams.BlockGasCostStep = ApricotPhase5BlockGasCostStep
+	}
+	return feeParams
+}
+
+// storedFeeConfig returns the fee configuration stored in the state of [parent]
+// by the fee manager precompile, or nil if the precompile is not active at
+// [parent] or has no fee configuration stored.
+// [chain] is only used if the precompile is active, so it can be nil for
+// chains that never activate it.
+func storedFeeConfig(config *params.ChainConfig, chain FeeConfigReader, parent *types.Header) (*params.FeeConfig, error) {
+	if !config.IsPrecompileEnabled(feemanager.ContractAddress, parent.Time) {
+		return nil, nil
+	}
+	if chain == nil {
+		return nil, errNoFeeConfigReader
+	}
+	feeConfig, err := chain.GetFeeConfigAt(parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee config at block %d: %w", parent.Number, err)
+	}
+	return feeConfig, nil
+}
+
+// effectiveFeeParams returns the parameters of the dynamic fee algorithm in
+// effect at [timestamp], replaced by the fee configuration stored in the state
+// of [parent] if the fee manager precompile is active.
+func effectiveFeeParams(config *params.ChainConfig, chain FeeConfigReader, parent *types.Header, timestamp uint64) (FeeParams, error) {
+	feeParams := FeeParamsAt(config, timestamp)
+	feeConfig, err := storedFeeConfig(config, chain, parent)
+	if err != nil || feeConfig == nil {
+		return feeParams, err
+	}
+	feeParams.TargetGas = feeConfig.TargetGas.Uint64()
+	feeParams.BaseFeeChangeDenominator = feeConfig.BaseFeeChangeDenominator
+	feeParams.MinBaseFee, feeParams.MaxBaseFee = feeConfig.MinBaseFee, nil
+	feeParams.TargetBlockRate = feeConfig.TargetBlockRate
+	feeParams.MinBlockGasCost = feeConfig.MinBlockGasCost
+	feeParams.MaxBlockGasCost = feeConfig.MaxBlockGasCost
+	feeParams.BlockGasCostStep = feeConfig.BlockGasCostStep
+	return feeParams, nil
+}
+
+// StaticGasLimit returns the gas limit every child of [parent] built at
+// [timestamp] must have, or false if the gas limit is not static, which is
+// only the case prior to Apricot Phase 1.
+// The gas limit of the fee configuration stored by the fee manager precompile
+// takes precedence over the one of the network upgrades.
+func StaticGasLimit(config *params.ChainConfig, chain FeeConfigReader, parent *types.Header, timestamp uint64) (uint64, bool, error) {
+	feeConfig, err := storedFeeConfig(config, chain, parent)
+	switch {
+	case err != nil:
+		return 0, false, err
+	case feeConfig != nil:
+		return feeConfig.GasLimit.Uint64(), true, nil
+	case config.IsCortina(timestamp):
+		return params.CortinaGasLimit, true, nil
+	case config.IsApricotPhase1(timestamp):
+		return params.ApricotPhase1GasLimit, true, nil
+	default:
+		return 0, false, nil
+	}
+}
+
 // CalcBaseFee takes the previous header and the timestamp of its child block
 // and calculates the expected base fee as well as the encoding of the past
 // pricing information for the child block.
+// The fee configuration stored by the fee manager precompile is read from
+// [chain] if the precompile is active at [parent].
 // CalcBaseFee should only be called if [timestamp] >= [config.ApricotPhase3Timestamp]
-func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
+func CalcBaseFee(config *params.ChainConfig, chain FeeConfigReader, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
+	feeParams, err := effectiveFeeParams(config, chain, parent, parent.Time)
+	if err != nil {
+		return nil, nil, err
+	}
+	return calcBaseFee(config, feeParams, parent, timestamp)
+}
+
+// CalcBlockGasCost returns the block gas cost of the child of [parent] built at
+// [timestamp], with the fee configuration stored by the fee manager precompile
+// read from [chain] if the precompile is active at [parent].
+// CalcBlockGasCost should only be called if [timestamp] >= [config.ApricotPhase4Timestamp]
+func CalcBlockGasCost(config *params.ChainConfig, chain FeeConfigReader, parent *types.Header, timestamp uint64) (*big.Int, error) {
+	feeParams, err := effectiveFeeParams(config, chain, parent, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return calcBlockGasCost(
+		feeParams.TargetBlockRate,
+		feeParams.MinBlockGasCost,
+		feeParams.MaxBlockGasCost,
+		feeParams.BlockGasCostStep,
+		parent.BlockGasCost,
+		parent.Time, timestamp,
+	), nil
+}
+
+// calcBaseFee is CalcBaseFee with the parameters [feeParams] instead of the
+// ones in effect at the timestamp of [parent].
+func calcBaseFee(config *params.ChainConfig, feeParams FeeParams, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
 	// If the current block is the first EIP-1559 block, or it is the genesis block
 	// return the initial slice and initial base fee.
 	var (
 		isApricotPhase3 = config.IsApricotPhase3(parent.Time)
 		isApricotPhase4 = config.IsApricotPhase4(parent.Time)
 		isApricotPhase5 = config.IsApricotPhase5(parent.Time)
-		isEtna          = config.IsEtna(parent.Time)
 	)
 	if !isApricotPhase3 || parent.Number.Cmp(common.Big0) == 0 {
 		initialSlice := make([]byte, params.DynamicFeeExtraDataSize)
@@ -71,17 +215,13 @@ func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uin
 		return nil, nil, err
 	}
 
-	// If AP5, use a less responsive [BaseFeeChangeDenominator] and a higher gas
-	// block limit
+	// Since AP5, [feeParams] has a less responsive [BaseFeeChangeDenominator]
+	// and a higher gas target
 	var (
 		baseFee                  = new(big.Int).Set(parent.BaseFee)
-		baseFeeChangeDenominator = ApricotPhase4BaseFeeChangeDenominator
-		parentGasTarget          = params.ApricotPhase3TargetGas
+		baseFeeChangeDenominator = feeParams.BaseFeeChangeDenominator
+		parentGasTarget          = feeParams.TargetGas
 	)
-	if isApricotPhase5 {
-		baseFeeChangeDenominator = ApricotPhase5BaseFeeChangeDenominator
-		parentGasTarget = params.ApricotPhase5TargetGas
-	}
 	parentGasTargetBig := new(big.Int).SetUint64(parentGasTarget)
 
 	// Add in the gas used by the parent block in the correct place
@@ -102,10 +242,10 @@ func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uin
 			// The [blockGasCost] is paid by the effective tips in the block using
 			// the block's value of [baseFee].
 			blockGasCost = calcBlockGasCost(
-				ApricotPhase4TargetBlockRate,
-				ApricotPhase4MinBlockGasCost,
-				ApricotPhase4MaxBlockGasCost,
-				ApricotPhase4BlockGasCostStep,
+				feeParams.TargetBlockRate,
+				feeParams.MinBlockGasCost,
+				feeParams.MaxBlockGasCost,
+				feeParams.BlockGasCostStep,
 				parent.BlockGasCost,
 				parent.Time, timestamp,
 			).Uint64()
@@ -178,16 +318,7 @@ func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uin
 	}
 
 	// Ensure that the base fee does not increase/decrease outside of the bounds
-	switch {
-	case isEtna:
-		baseFee = selectBigWithinBounds(EtnaMinBaseFee, baseFee, nil)
-	case isApricotPhase5:
-		baseFee = selectBigWithinBounds(ApricotPhase4MinBaseFee, baseFee, nil)
-	case isApricotPhase4:
-		baseFee = selectBigWithinBounds(ApricotPhase4MinBaseFee, baseFee, ApricotPhase4MaxBaseFee)
-	default:
-		baseFee = selectBigWithinBounds(ApricotPhase3MinBaseFee, baseFee, ApricotPhase3MaxBaseFee)
-	}
+	baseFee = selectBigWithinBounds(feeParams.MinBaseFee, baseFee, feeParams.MaxBaseFee)
 
 	return newRollupWindow, baseFee, nil
 }
@@ -197,11 +328,26 @@ func CalcBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uin
 // If [timestamp] is less than the timestamp of [parent], then it uses the same timestamp as parent.
 // Warning: This function should only be used in estimation and should not be used when calculating the canonical
 // base fee for a subsequent block.
-func EstimateNextBaseFee(config *params.ChainConfig, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
+func EstimateNextBaseFee(config *params.ChainConfig, chain FeeConfigReader, parent *types.Header, timestamp uint64) ([]byte, *big.Int, error) {
 	if timestamp < parent.Time {
 		timestamp = parent.Time
 	}
-	return CalcBaseFee(config, parent, timestamp)
+	return CalcBaseFee(config, chain, parent, timestamp)
+}
+
+// EstimateBlockGasCost returns the block gas cost of a block with [parent]
+// being built at [timestamp], which is the fee that the tips of the block must
+// cover. Returns nil if the block would be built prior to Apricot Phase 4.
+// If [timestamp] is less than the timestamp of [parent], then it uses the same
+// timestamp as parent.
+func EstimateBlockGasCost(config *params.ChainConfig, chain FeeConfigReader, parent *types.Header, timestamp uint64) (*big.Int, error) {
+	if timestamp < parent.Time {
+		timestamp = parent.Time
+	}
+	if !config.IsApricotPhase4(timestamp) {
+		return nil, nil
+	}
+	return CalcBlockGasCost(config, chain, parent, timestamp)
 }
 
 // selectBigWithinBounds returns [value] if it is within the bounds:
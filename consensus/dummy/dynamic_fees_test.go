@@ -10,6 +10,8 @@ import (
 
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
+	"github.com/ava-labs/coreth/utils"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/assert"
@@ -205,7 +207,7 @@ func testDynamicFeesStaysWithinRange(t *testing.T, test test) {
 	}
 
 	for index, block := range blocks[1:] {
-		nextExtraData, nextBaseFee, err := CalcBaseFee(params.TestApricotPhase3Config, header, block.timestamp)
+		nextExtraData, nextBaseFee, err := CalcBaseFee(params.TestApricotPhase3Config, nil, header, block.timestamp)
 		if err != nil {
 			t.Fatalf("Failed to calculate base fee at index %d: %s", index, err)
 		}
@@ -410,7 +412,7 @@ func TestCalcBaseFeeAP4(t *testing.T) {
 
 	for index, event := range events {
 		block := event.block
-		nextExtraData, nextBaseFee, err := CalcBaseFee(params.TestApricotPhase4Config, header, block.timestamp)
+		nextExtraData, nextBaseFee, err := CalcBaseFee(params.TestApricotPhase4Config, nil, header, block.timestamp)
 		assert.NoError(t, err)
 		log.Info("Update", "baseFee", nextBaseFee)
 		header = &types.Header{
@@ -421,7 +423,7 @@ func TestCalcBaseFeeAP4(t *testing.T) {
 			Extra:   nextExtraData,
 		}
 
-		nextExtraData, nextBaseFee, err = CalcBaseFee(params.TestApricotPhase4Config, extDataHeader, block.timestamp)
+		nextExtraData, nextBaseFee, err = CalcBaseFee(params.TestApricotPhase4Config, nil, extDataHeader, block.timestamp)
 		assert.NoError(t, err)
 		log.Info("Update", "baseFee (w/extData)", nextBaseFee)
 		extDataHeader = &types.Header{
@@ -546,7 +548,7 @@ func TestDynamicFeesEtna(t *testing.T) {
 	}
 
 	timestamp := uint64(1)
-	extra, nextBaseFee, err := CalcBaseFee(params.TestEtnaChainConfig, header, timestamp)
+	extra, nextBaseFee, err := CalcBaseFee(params.TestEtnaChainConfig, nil, header, timestamp)
 	require.NoError(err)
 	// Genesis matches the initial base fee
 	require.Equal(params.ApricotPhase3InitialBaseFee, nextBaseFee.Int64())
@@ -558,9 +560,105 @@ func TestDynamicFeesEtna(t *testing.T) {
 		BaseFee: nextBaseFee,
 		Extra:   extra,
 	}
-	_, nextBaseFee, err = CalcBaseFee(params.TestEtnaChainConfig, header, timestamp)
+	_, nextBaseFee, err = CalcBaseFee(params.TestEtnaChainConfig, nil, header, timestamp)
 	require.NoError(err)
 	// After some time has passed in the Etna phase, the base fee should drop
 	// lower than the prior base fee minimum.
 	require.Less(nextBaseFee.Int64(), params.ApricotPhase4MinBaseFee)
 }
+
+func TestEstimateBlockGasCost(t *testing.T) {
+	require := require.New(t)
+	parent := &types.Header{
+		Time:         10,
+		BlockGasCost: big.NewInt(100_000),
+	}
+
+	estimate := func(config *params.ChainConfig, timestamp uint64) *big.Int {
+		blockGasCost, err := EstimateBlockGasCost(config, nil, parent, timestamp)
+		require.NoError(err)
+		return blockGasCost
+	}
+
+	// Prior to Apricot Phase 4 there is no block gas cost.
+	require.Nil(estimate(params.TestApricotPhase3Config, 10))
+
+	require.Equal(uint64(200_000), estimate(params.TestApricotPhase4Config, 10).Uint64())
+	require.Equal(uint64(100_000), estimate(params.TestApricotPhase4Config, 12).Uint64())
+	require.Equal(uint64(0), estimate(params.TestApricotPhase4Config, 14).Uint64())
+
+	// Apricot Phase 5 uses a larger step, and timestamps prior to the parent
+	// are treated as the parent timestamp.
+	require.Equal(uint64(500_000), estimate(params.TestApricotPhase5Config, 9).Uint64())
+	require.Equal(uint64(0), estimate(params.TestApricotPhase5Config, 13).Uint64())
+}
+
+// testFeeConfigReader returns the same fee config for every parent.
+type testFeeConfigReader struct {
+	feeConfig *params.FeeConfig
+}
+
+func (r *testFeeConfigReader) GetFeeConfigAt(*types.Header) (*params.FeeConfig, error) {
+	return r.feeConfig, nil
+}
+
+func TestFeeManagerFeeConfig(t *testing.T) {
+	require := require.New(t)
+	cpcfg := *params.TestChainConfig
+	config := &cpcfg
+	config.UpgradeConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: feemanager.NewConfig(utils.NewUint64(10), nil, nil, nil, nil)},
+	}
+	feeConfig := &params.FeeConfig{
+		GasLimit:                 big.NewInt(20_000_000),
+		TargetBlockRate:          2,
+		MinBaseFee:               big.NewInt(300_000_000_000),
+		TargetGas:                big.NewInt(15_000_000),
+		BaseFeeChangeDenominator: big.NewInt(36),
+		MinBlockGasCost:          big.NewInt(0),
+		MaxBlockGasCost:          big.NewInt(5_000_000),
+		BlockGasCostStep:         big.NewInt(1_000_000),
+	}
+	reader := &testFeeConfigReader{feeConfig: feeConfig}
+	parent := &types.Header{
+		Number:       big.NewInt(1),
+		BaseFee:      big.NewInt(params.EtnaMinBaseFee),
+		BlockGasCost: big.NewInt(0),
+		Extra:        make([]byte, params.DynamicFeeExtraDataSize),
+	}
+
+	// Prior to the activation of the fee manager, the chain is not read.
+	parent.Time = 9
+	gasLimit, isStatic, err := StaticGasLimit(config, nil, parent, 10)
+	require.NoError(err)
+	require.True(isStatic)
+	require.Equal(params.CortinaGasLimit, gasLimit)
+	_, baseFee, err := CalcBaseFee(config, nil, parent, 10)
+	require.NoError(err)
+	require.Less(baseFee.Cmp(feeConfig.MinBaseFee), 0)
+
+	// Once active, the fee manager requires a reader.
+	parent.Time = 10
+	_, _, err = CalcBaseFee(config, nil, parent, 10)
+	require.ErrorIs(err, errNoFeeConfigReader)
+
+	// Without a stored fee config, the defaults of the network upgrades apply.
+	gasLimit, _, err = StaticGasLimit(config, &testFeeConfigReader{}, parent, 10)
+	require.NoError(err)
+	require.Equal(params.CortinaGasLimit, gasLimit)
+	blockGasCost, err := CalcBlockGasCost(config, &testFeeConfigReader{}, parent, 10)
+	require.NoError(err)
+	require.Equal(uint64(400_000), blockGasCost.Uint64())
+
+	// The stored fee config replaces the defaults.
+	gasLimit, isStatic, err = StaticGasLimit(config, reader, parent, 10)
+	require.NoError(err)
+	require.True(isStatic)
+	require.Equal(feeConfig.GasLimit.Uint64(), gasLimit)
+	_, baseFee, err = CalcBaseFee(config, reader, parent, 10)
+	require.NoError(err)
+	require.Equal(feeConfig.MinBaseFee, baseFee)
+	blockGasCost, err = CalcBlockGasCost(config, reader, parent, 10)
+	require.NoError(err)
+	require.Equal(uint64(2_000_000), blockGasCost.Uint64())
+}
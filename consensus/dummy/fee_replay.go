@@ -0,0 +1,116 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dummy
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+)
+
+// ReplayedFees are the fees of a block on the chain alongside the fees it would
+// have had with alternate parameters of the dynamic fee algorithm.
+type ReplayedFees struct {
+	Number  uint64
+	Time    uint64
+	GasUsed uint64 // Including the gas used by the block's extra data
+
+	BaseFee      *big.Int // nil prior to Apricot Phase 3
+	BlockGasCost *big.Int // nil prior to Apricot Phase 4
+	BlockFee     *big.Int // BlockGasCost * BaseFee, nil prior to Apricot Phase 4
+
+	ReplayedBaseFee      *big.Int
+	ReplayedBlockGasCost *big.Int
+	ReplayedBlockFee     *big.Int
+}
+
+// ReplayFees replays the consecutive [headers], children of [parent], through
+// the dynamic fee algorithm with the parameters in effect on the chain, as
+// modified by [override]. The parameters of each block are the ones its parent
+// on the chain was built with, including the fee configuration stored by the
+// fee manager precompile, which is read from [chain]. The gas used and the
+// timestamps of the blocks are kept, while their base fees, block gas costs and
+// rollup windows are recomputed.
+//
+// Replaying with a nil [override] reproduces the fees of the chain.
+func ReplayFees(config *params.ChainConfig, chain FeeConfigReader, parent *types.Header, headers []*types.Header, override func(FeeParams) FeeParams) ([]*ReplayedFees, error) {
+	var (
+		chainParent    = parent
+		replayedParent = parent
+		replayed       = make([]*ReplayedFees, len(headers))
+	)
+	feeParams := func(timestamp uint64) (FeeParams, error) {
+		feeParams, err := effectiveFeeParams(config, chain, chainParent, timestamp)
+		if err != nil || override == nil {
+			return feeParams, err
+		}
+		return override(feeParams), nil
+	}
+	for i, header := range headers {
+		if header.Number.Uint64() != chainParent.Number.Uint64()+1 {
+			return nil, fmt.Errorf("non-consecutive header %d after %d", header.Number, chainParent.Number)
+		}
+		fees := &ReplayedFees{
+			Number:       header.Number.Uint64(),
+			Time:         header.Time,
+			GasUsed:      header.GasUsed,
+			BaseFee:      header.BaseFee,
+			BlockGasCost: header.BlockGasCost,
+			BlockFee:     blockFee(header.BlockGasCost, header.BaseFee),
+		}
+		if header.ExtDataGasUsed != nil {
+			fees.GasUsed += header.ExtDataGasUsed.Uint64()
+		}
+		replayedHeader := types.CopyHeader(header)
+		if config.IsApricotPhase3(header.Time) {
+			baseFeeParams, err := feeParams(chainParent.Time)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get fee params of block %d: %w", header.Number, err)
+			}
+			window, baseFee, err := calcBaseFee(config, baseFeeParams, replayedParent, header.Time)
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay base fee of block %d: %w", header.Number, err)
+			}
+			// Keep anything following the rollup window in the extra data.
+			if uint64(len(header.Extra)) > params.DynamicFeeExtraDataSize {
+				window = append(window, header.Extra[params.DynamicFeeExtraDataSize:]...)
+			}
+			replayedHeader.Extra = window
+			replayedHeader.BaseFee = baseFee
+		}
+		if config.IsApricotPhase4(header.Time) {
+			blockFeeParams, err := feeParams(header.Time)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get fee params of block %d: %w", header.Number, err)
+			}
+			replayedHeader.BlockGasCost = calcBlockGasCost(
+				blockFeeParams.TargetBlockRate,
+				blockFeeParams.MinBlockGasCost,
+				blockFeeParams.MaxBlockGasCost,
+				blockFeeParams.BlockGasCostStep,
+				replayedParent.BlockGasCost,
+				replayedParent.Time, header.Time,
+			)
+		}
+		fees.ReplayedBaseFee = replayedHeader.BaseFee
+		fees.ReplayedBlockGasCost = replayedHeader.BlockGasCost
+		fees.ReplayedBlockFee = blockFee(replayedHeader.BlockGasCost, replayedHeader.BaseFee)
+
+		replayed[i] = fees
+		chainParent = header
+		replayedParent = replayedHeader
+	}
+	return replayed, nil
+}
+
+// blockFee returns the fee the tips of a block must cover, or nil if the block
+// has no block gas cost.
+func blockFee(blockGasCost, baseFee *big.Int) *big.Int {
+	if blockGasCost == nil || baseFee == nil {
+		return nil
+	}
+	return new(big.Int).Mul(blockGasCost, baseFee)
+}
@@ -0,0 +1,140 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dummy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// buildFeeHeaders builds a chain of headers following [parent] with the given
+// timestamps and gas used, and the fees calculated by the chain, which reads
+// the stored fee configs from [chain].
+func buildFeeHeaders(t *testing.T, config *params.ChainConfig, chain FeeConfigReader, parent *types.Header, times []uint64, gasUsed uint64) []*types.Header {
+	headers := make([]*types.Header, len(times))
+	for i, timestamp := range times {
+		extra, baseFee, err := CalcBaseFee(config, chain, parent, timestamp)
+		require.NoError(t, err)
+		blockGasCost, err := EstimateBlockGasCost(config, chain, parent, timestamp)
+		require.NoError(t, err)
+		header := &types.Header{
+			Number:         new(big.Int).Add(parent.Number, big.NewInt(1)),
+			Time:           timestamp,
+			GasUsed:        gasUsed,
+			ExtDataGasUsed: big.NewInt(0),
+			Extra:          extra,
+			BaseFee:        baseFee,
+			BlockGasCost:   blockGasCost,
+		}
+		headers[i] = header
+		parent = header
+	}
+	return headers
+}
+
+func TestReplayFees(t *testing.T) {
+	require := require.New(t)
+	config := params.TestChainConfig
+	genesis := &types.Header{
+		Number: big.NewInt(0),
+		Time:   0,
+	}
+	headers := buildFeeHeaders(t, config, nil, genesis, []uint64{1, 2, 2, 3, 3, 3, 4, 8, 20}, 8_000_000)
+
+	// Replaying with the parameters of the chain reproduces its fees.
+	replayed, err := ReplayFees(config, nil, genesis, headers, nil)
+	require.NoError(err)
+	require.Len(replayed, len(headers))
+	for i, fees := range replayed {
+		require.Equal(headers[i].Number.Uint64(), fees.Number)
+		require.Equal(uint64(8_000_000), fees.GasUsed)
+		require.Zero(fees.BaseFee.Cmp(fees.ReplayedBaseFee))
+		require.Zero(fees.BlockGasCost.Cmp(fees.ReplayedBlockGasCost))
+		require.Zero(fees.BlockFee.Cmp(fees.ReplayedBlockFee))
+	}
+
+	// A lower gas target raises the base fee, and a larger block gas cost step
+	// raises the block gas cost of blocks built faster than the target rate.
+	replayed, err = ReplayFees(config, nil, genesis, headers, func(feeParams FeeParams) FeeParams {
+		feeParams.TargetGas /= 2
+		feeParams.BlockGasCostStep = new(big.Int).Mul(feeParams.BlockGasCostStep, big.NewInt(2))
+		return feeParams
+	})
+	require.NoError(err)
+	var raisedBaseFee, raisedBlockGasCost bool
+	for _, fees := range replayed {
+		require.GreaterOrEqual(fees.ReplayedBaseFee.Cmp(fees.BaseFee), 0)
+		raisedBaseFee = raisedBaseFee || fees.ReplayedBaseFee.Cmp(fees.BaseFee) > 0
+		raisedBlockGasCost = raisedBlockGasCost || fees.ReplayedBlockGasCost.Cmp(fees.BlockGasCost) > 0
+	}
+	require.True(raisedBaseFee)
+	require.True(raisedBlockGasCost)
+
+	// Headers must be consecutive.
+	_, err = ReplayFees(config, nil, genesis, headers[1:], nil)
+	require.ErrorContains(err, "non-consecutive header")
+}
+
+func TestReplayFeesFeeManager(t *testing.T) {
+	require := require.New(t)
+	cpcfg := *params.TestChainConfig
+	config := &cpcfg
+	config.UpgradeConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: feemanager.NewConfig(utils.NewUint64(0), nil, nil, nil, nil)},
+	}
+	feeConfig := &params.FeeConfig{
+		GasLimit:                 big.NewInt(20_000_000),
+		TargetBlockRate:          2,
+		MinBaseFee:               big.NewInt(300_000_000_000),
+		TargetGas:                big.NewInt(15_000_000),
+		BaseFeeChangeDenominator: big.NewInt(36),
+		MinBlockGasCost:          big.NewInt(0),
+		MaxBlockGasCost:          big.NewInt(5_000_000),
+		BlockGasCostStep:         big.NewInt(1_000_000),
+	}
+	reader := &testFeeConfigReader{feeConfig: feeConfig}
+	genesis := &types.Header{
+		Number: big.NewInt(0),
+		Time:   0,
+	}
+	headers := buildFeeHeaders(t, config, reader, genesis, []uint64{1, 2, 2, 3, 3, 3, 4, 8, 20}, 8_000_000)
+
+	// Replaying with the stored fee config reproduces the fees of the chain,
+	// which differ from the ones of the network upgrades.
+	replayed, err := ReplayFees(config, reader, genesis, headers, nil)
+	require.NoError(err)
+	// The child of the genesis block has the initial base fee.
+	for _, fees := range replayed {
+		require.Zero(fees.BaseFee.Cmp(fees.ReplayedBaseFee))
+		require.Zero(fees.BlockGasCost.Cmp(fees.ReplayedBlockGasCost))
+	}
+	for _, fees := range replayed[1:] {
+		require.GreaterOrEqual(fees.BaseFee.Cmp(feeConfig.MinBaseFee), 0)
+	}
+
+	// Overrides apply on top of the stored fee config.
+	replayed, err = ReplayFees(config, reader, genesis, headers, func(feeParams FeeParams) FeeParams {
+		feeParams.MinBaseFee = new(big.Int).Mul(feeConfig.MinBaseFee, big.NewInt(2))
+		return feeParams
+	})
+	require.NoError(err)
+	minBaseFee := new(big.Int).Mul(feeConfig.MinBaseFee, big.NewInt(2))
+	var raisedBaseFee bool
+	for _, fees := range replayed[1:] {
+		require.GreaterOrEqual(fees.ReplayedBaseFee.Cmp(minBaseFee), 0)
+		raisedBaseFee = raisedBaseFee || fees.ReplayedBaseFee.Cmp(fees.BaseFee) > 0
+		require.Zero(fees.BlockGasCost.Cmp(fees.ReplayedBlockGasCost))
+	}
+	require.True(raisedBaseFee)
+
+	// The stored fee config cannot be read without a reader.
+	_, err = ReplayFees(config, nil, genesis, headers, nil)
+	require.ErrorIs(err, errNoFeeConfigReader)
+}
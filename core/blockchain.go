@@ -107,11 +107,13 @@ var (
 )
 
 const (
-	bodyCacheLimit     = 256
-	blockCacheLimit    = 256
-	receiptsCacheLimit = 32
-	txLookupCacheLimit = 1024
-	badBlockLimit      = 10
+	bodyCacheLimit      = 256
+	blockCacheLimit     = 256
+	receiptsCacheLimit  = 32
+	txLookupCacheLimit  = 1024
+	badBlockLimit       = 10
+	feeConfigCacheLimit = 256
+	coinbaseCacheLimit  = 256
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
@@ -273,6 +275,9 @@ type BlockChain struct {
 	txLookupCache *lru.Cache[common.Hash, txLookup]         // Cache for the most recent transaction lookup data.
 	badBlocks     *lru.Cache[common.Hash, *badBlock]        // Cache for bad blocks
 
+	feeConfigCache *lru.Cache[common.Hash, *params.FeeConfig] // Cache for the fee configs stored by the fee manager, by block hash
+	coinbaseCache  *lru.Cache[common.Hash, storedCoinbase]    // Cache for the reward configs stored by the reward manager, by block hash
+
 	stopping atomic.Bool // false if chain is running, true when stopped
 
 	engine    consensus.Engine
@@ -369,6 +374,8 @@ func NewBlockChain(
 		blockCache:        lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
 		txLookupCache:     lru.NewCache[common.Hash, txLookup](txLookupCacheLimit),
 		badBlocks:         lru.NewCache[common.Hash, *badBlock](badBlockLimit),
+		feeConfigCache:    lru.NewCache[common.Hash, *params.FeeConfig](feeConfigCacheLimit),
+		coinbaseCache:     lru.NewCache[common.Hash, storedCoinbase](coinbaseCacheLimit),
 		engine:            engine,
 		vmConfig:          vmConfig,
 		senderCacher:      NewTxSenderCacher(runtime.NumCPU()),
@@ -2109,3 +2116,48 @@ func (bc *BlockChain) repairTxIndexTail(newTail uint64) error {
 	}
 	return nil
 }
+
+// IndexBackfilledBlocks writes the transaction lookup entries of historical
+// [blocks] fetched after state sync, so their transactions can be looked up.
+// [blocks] must be contiguous and in descending order of height. Only blocks
+// within [TransactionHistory] of the last accepted block are indexed, and the
+// tx index tail is lowered to the lowest block indexed.
+func (bc *BlockChain) IndexBackfilledBlocks(blocks []*types.Block) error {
+	bc.txIndexTailLock.Lock()
+	defer bc.txIndexTailLock.Unlock()
+
+	var (
+		batch        = bc.db.NewBatch()
+		limit        = bc.cacheConfig.TransactionHistory
+		lastAccepted = bc.LastAcceptedBlock().NumberU64()
+	)
+	if limit == 0 {
+		// the entire chain is indexed and the tail is not maintained
+		for _, block := range blocks {
+			rawdb.WriteTxLookupEntriesByBlock(batch, block)
+		}
+		return batch.Write()
+	}
+
+	tail := rawdb.ReadTxIndexTail(bc.db)
+	if tail == nil {
+		return nil
+	}
+	newTail := *tail
+	for _, block := range blocks {
+		number := block.NumberU64()
+		if number >= newTail {
+			continue // already indexed
+		}
+		if number+1 != newTail || number+limit <= lastAccepted {
+			break // not adjacent to the tail or outside of the indexed range
+		}
+		rawdb.WriteTxLookupEntriesByBlock(batch, block)
+		newTail = number
+	}
+	if newTail == *tail {
+		return nil
+	}
+	rawdb.WriteTxIndexTail(batch, newTail)
+	return batch.Write()
+}
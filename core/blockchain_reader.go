@@ -34,6 +34,8 @@ import (
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
+	"github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/coreth/triedb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
@@ -257,6 +259,45 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 	return state.New(root, bc.stateCache, bc.snaps)
 }
 
+// GetFeeConfigAt returns the fee configuration stored by the fee manager
+// precompile in the state of [parent], or nil if none is stored, caching it.
+func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error) {
+	hash := parent.Hash()
+	if feeConfig, ok := bc.feeConfigCache.Get(hash); ok {
+		return feeConfig, nil
+	}
+	statedb, err := bc.StateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	feeConfig := feemanager.GetStoredFeeConfig(statedb)
+	bc.feeConfigCache.Add(hash, feeConfig)
+	return feeConfig, nil
+}
+
+// storedCoinbase is the reward configuration stored by the reward manager.
+type storedCoinbase struct {
+	address            common.Address
+	allowFeeRecipients bool
+}
+
+// GetCoinbaseAt returns the address receiving the fees of the blocks, as
+// stored by the reward manager precompile in the state of [parent], and true
+// if block producers choose their fee recipient instead, caching them.
+func (bc *BlockChain) GetCoinbaseAt(parent *types.Header) (common.Address, bool, error) {
+	hash := parent.Hash()
+	if coinbase, ok := bc.coinbaseCache.Get(hash); ok {
+		return coinbase.address, coinbase.allowFeeRecipients, nil
+	}
+	statedb, err := bc.StateAt(parent.Root)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	address, allowFeeRecipients := rewardmanager.GetStoredRewardAddress(statedb)
+	bc.coinbaseCache.Add(hash, storedCoinbase{address: address, allowFeeRecipients: allowFeeRecipients})
+	return address, allowFeeRecipients, nil
+}
+
 // Config retrieves the chain's fork configuration.
 func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
 
@@ -27,6 +27,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -38,6 +39,8 @@ import (
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
+	"github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/coreth/triedb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -314,9 +317,10 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 	// Forcibly use hash-based state scheme for retaining all nodes in disk.
 	triedb := triedb.NewDatabase(db, triedb.HashDefaults)
 	defer triedb.Close()
+	cm.stateCache = state.NewDatabaseWithNodeDB(db, triedb)
 
 	for i := 0; i < n; i++ {
-		statedb, err := state.New(parent.Root(), state.NewDatabaseWithNodeDB(db, triedb), nil)
+		statedb, err := state.New(parent.Root(), cm.stateCache, nil)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -373,12 +377,11 @@ func GenerateChainWithGenesis(genesis *Genesis, engine consensus.Engine, n int,
 func (cm *chainMaker) makeHeader(parent *types.Block, gap uint64, state *state.StateDB, engine consensus.Engine) *types.Header {
 	time := parent.Time() + gap // block time is fixed at [gap] seconds
 
-	var gasLimit uint64
-	if cm.config.IsCortina(time) {
-		gasLimit = params.CortinaGasLimit
-	} else if cm.config.IsApricotPhase1(time) {
-		gasLimit = params.ApricotPhase1GasLimit
-	} else {
+	gasLimit, isStatic, err := dummy.StaticGasLimit(cm.config, cm, parent.Header(), time)
+	if err != nil {
+		panic(err)
+	}
+	if !isStatic {
 		gasLimit = CalcGasLimit(parent.GasUsed(), parent.GasLimit(), parent.GasLimit(), parent.GasLimit())
 	}
 
@@ -392,8 +395,7 @@ func (cm *chainMaker) makeHeader(parent *types.Block, gap uint64, state *state.S
 		Time:       time,
 	}
 	if cm.config.IsApricotPhase3(time) {
-		var err error
-		header.Extra, header.BaseFee, err = dummy.CalcBaseFee(cm.config, parent.Header(), time)
+		header.Extra, header.BaseFee, err = dummy.CalcBaseFee(cm.config, cm, parent.Header(), time)
 		if err != nil {
 			panic(err)
 		}
@@ -423,6 +425,7 @@ type chainMaker struct {
 	chain       []*types.Block
 	chainByHash map[common.Hash]*types.Block
 	receipts    []types.Receipts
+	stateCache  state.Database // Database of the committed states of the generated blocks
 }
 
 func newChainMaker(bottom *types.Block, config *params.ChainConfig, engine consensus.Engine) *chainMaker {
@@ -494,3 +497,26 @@ func (cm *chainMaker) GetHeader(hash common.Hash, number uint64) *types.Header {
 func (cm *chainMaker) GetBlock(hash common.Hash, number uint64) *types.Block {
 	return cm.blockByNumber(number)
 }
+
+func (cm *chainMaker) GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error) {
+	if cm.stateCache == nil {
+		return nil, errors.New("no state to read the fee config from")
+	}
+	statedb, err := state.New(parent.Root, cm.stateCache, nil)
+	if err != nil {
+		return nil, err
+	}
+	return feemanager.GetStoredFeeConfig(statedb), nil
+}
+
+func (cm *chainMaker) GetCoinbaseAt(parent *types.Header) (common.Address, bool, error) {
+	if cm.stateCache == nil {
+		return common.Address{}, false, errors.New("no state to read the reward config from")
+	}
+	statedb, err := state.New(parent.Root, cm.stateCache, nil)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	address, allowFeeRecipients := rewardmanager.GetStoredRewardAddress(statedb)
+	return address, allowFeeRecipients, nil
+}
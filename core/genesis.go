@@ -169,9 +169,20 @@ func SetupGenesisBlock(
 	if storedcfg == nil {
 		log.Warn("Found genesis block without chain config")
 		rawdb.WriteChainConfig(db, stored, newcfg)
+		rawdb.WriteUpgradeConfig(db, stored, &newcfg.UpgradeConfig)
 		return newcfg, stored, nil
 	}
 	storedData, _ := json.Marshal(storedcfg)
+	// The upgrade config is not encoded in the chain config, so it is stored
+	// separately. Chains which never stored it are assumed to be compatible.
+	storedUpgrades := rawdb.ReadUpgradeConfig(db, stored)
+	if storedUpgrades != nil {
+		storedcfg.UpgradeConfig = *storedUpgrades
+	} else {
+		log.Info("Found chain config without upgrade config")
+		storedcfg.UpgradeConfig = newcfg.UpgradeConfig
+	}
+	storedUpgradesData, _ := json.Marshal(storedcfg.UpgradeConfig)
 	// Check config compatibility and write the config. Compatibility errors
 	// are returned to the caller unless we're already at block zero.
 	// we use last accepted block for cfg compatibility check. Note this allows
@@ -198,6 +209,9 @@ func SetupGenesisBlock(
 	if newData, _ := json.Marshal(newcfg); !bytes.Equal(storedData, newData) {
 		rawdb.WriteChainConfig(db, stored, newcfg)
 	}
+	if newUpgradesData, _ := json.Marshal(newcfg.UpgradeConfig); storedUpgrades == nil || !bytes.Equal(storedUpgradesData, newUpgradesData) {
+		rawdb.WriteUpgradeConfig(db, stored, &newcfg.UpgradeConfig)
+	}
 	return newcfg, stored, nil
 }
 
@@ -328,6 +342,7 @@ func (g *Genesis) Commit(db ethdb.Database, triedb *triedb.Database) (*types.Blo
 	rawdb.WriteHeadBlockHash(db, block.Hash())
 	rawdb.WriteHeadHeaderHash(db, block.Hash())
 	rawdb.WriteChainConfig(db, block.Hash(), config)
+	rawdb.WriteUpgradeConfig(db, block.Hash(), &config.UpgradeConfig)
 	return block, nil
 }
 
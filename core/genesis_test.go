@@ -279,6 +279,53 @@ func TestGenesisWriteUpgradesRegression(t *testing.T) {
 	// This tests a regression where the UpgradeConfig would not be written to disk correctly.
 	_, _, err = SetupGenesisBlock(db, trieDB, genesis, lastAcceptedBlock.Hash(), false)
 	require.NoError(err)
+
+	// The activated precompile upgrade cannot be removed.
+	genesis.Config.UpgradeConfig.PrecompileUpgrades = nil
+	_, _, err = SetupGenesisBlock(db, trieDB, genesis, lastAcceptedBlock.Hash(), false)
+	var compatErr *params.ConfigCompatError
+	require.ErrorAs(err, &compatErr)
+	require.Equal(uint64(50), compatErr.RewindToTime)
+}
+
+func TestGenesisUpgradeConfigCompatible(t *testing.T) {
+	require := require.New(t)
+	config := *params.TestChainConfig
+	config.UpgradeConfig.BlobTxsTimestamp = utils.NewUint64(50)
+	genesis := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			{1}: {Balance: big.NewInt(1)},
+		},
+	}
+
+	db := rawdb.NewMemoryDatabase()
+	trieDB := triedb.NewDatabase(db, triedb.HashDefaults)
+	genesisBlock := genesis.MustCommit(db, trieDB)
+	require.Equal(&config.UpgradeConfig, rawdb.ReadUpgradeConfig(db, genesisBlock.Hash()))
+
+	lastAcceptedBlock := types.NewBlock(&types.Header{
+		ParentHash: common.Hash{1, 2, 3},
+		Number:     big.NewInt(100),
+		GasLimit:   8_000_000,
+		Time:       100,
+	}, nil, nil, nil, trie.NewStackTrie(nil))
+	rawdb.WriteBlock(db, lastAcceptedBlock)
+
+	// Blob txs cannot be disabled after the last accepted block opted in.
+	disabled := *genesis
+	disabledConfig := config
+	disabledConfig.UpgradeConfig = params.UpgradeConfig{}
+	disabled.Config = &disabledConfig
+	_, _, err := SetupGenesisBlock(db, trieDB, &disabled, lastAcceptedBlock.Hash(), false)
+	var compatErr *params.ConfigCompatError
+	require.ErrorAs(err, &compatErr)
+	require.Equal(uint64(49), compatErr.RewindToTime)
+
+	// Unless the upgrade check is skipped, which records the new config.
+	_, _, err = SetupGenesisBlock(db, trieDB, &disabled, lastAcceptedBlock.Hash(), true)
+	require.NoError(err)
+	require.Equal(&params.UpgradeConfig{}, rawdb.ReadUpgradeConfig(db, genesisBlock.Hash()))
 }
 
 func newDbConfig(scheme string) *triedb.Config {
@@ -0,0 +1,65 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReadBlobSidecar retrieves the sidecar of the blob transaction [txHash]
+// included in the accepted block [number], or nil if it was not stored or
+// was pruned.
+func ReadBlobSidecar(db ethdb.KeyValueReader, number uint64, txHash common.Hash) *types.BlobTxSidecar {
+	data, _ := db.Get(blobSidecarKey(number, txHash))
+	if len(data) == 0 {
+		return nil
+	}
+	sidecar := new(types.BlobTxSidecar)
+	if err := rlp.DecodeBytes(data, sidecar); err != nil {
+		log.Error("Invalid blob sidecar RLP", "number", number, "tx", txHash, "err", err)
+		return nil
+	}
+	return sidecar
+}
+
+// WriteBlobSidecar stores the sidecar of the blob transaction [txHash]
+// included in the accepted block [number].
+func WriteBlobSidecar(db ethdb.KeyValueWriter, number uint64, txHash common.Hash, sidecar *types.BlobTxSidecar) {
+	data, err := rlp.EncodeToBytes(sidecar)
+	if err != nil {
+		log.Crit("Failed to RLP encode blob sidecar", "err", err)
+	}
+	if err := db.Put(blobSidecarKey(number, txHash), data); err != nil {
+		log.Crit("Failed to store blob sidecar", "err", err)
+	}
+}
+
+// DeleteBlobSidecarsBelow removes the sidecars of the blob transactions
+// included in blocks below [number], returning how many were removed.
+func DeleteBlobSidecarsBelow(db ethdb.KeyValueStore, number uint64) int {
+	it := NewKeyLengthIterator(db.NewIterator(blobSidecarPrefix, nil), blobSidecarKeyLength)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	deleted := 0
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(blobSidecarPrefix):]) >= number {
+			break
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			log.Crit("Failed to delete blob sidecar", "err", err)
+		}
+		deleted++
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete blob sidecars", "err", err)
+	}
+	return deleted
+}
@@ -0,0 +1,37 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobSidecarStorage(t *testing.T) {
+	require := require.New(t)
+	db := NewMemoryDatabase()
+
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{{1}},
+		Commitments: []kzg4844.Commitment{{2}},
+		Proofs:      []kzg4844.Proof{{3}},
+	}
+	require.Nil(ReadBlobSidecar(db, 1, common.Hash{1}))
+
+	for number := uint64(1); number <= 3; number++ {
+		WriteBlobSidecar(db, number, common.Hash{byte(number)}, sidecar)
+	}
+	require.Equal(sidecar, ReadBlobSidecar(db, 1, common.Hash{1}))
+	require.Nil(ReadBlobSidecar(db, 2, common.Hash{1}))
+
+	require.Equal(2, DeleteBlobSidecarsBelow(db, 3))
+	require.Nil(ReadBlobSidecar(db, 1, common.Hash{1}))
+	require.Nil(ReadBlobSidecar(db, 2, common.Hash{2}))
+	require.Equal(sidecar, ReadBlobSidecar(db, 3, common.Hash{3}))
+	require.Zero(DeleteBlobSidecarsBelow(db, 3))
+}
@@ -5,6 +5,7 @@ package rawdb
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ethereum/go-ethereum/common"
@@ -31,6 +32,82 @@ func WriteSyncRoot(db ethdb.KeyValueWriter, root common.Hash) error {
 	return db.Put(syncRootKey, root[:])
 }
 
+// syncBlocksProgressLength is the length of an encoded SyncBlocksProgress.
+const syncBlocksProgressLength = 2*common.HashLength + 2*wrappers.LongLen
+
+// SyncBlocksProgress is a checkpoint of fetching the parents of the block
+// an in-progress sync targets. Blocks are fetched from [Target] towards
+// genesis, and all blocks up to (excluding) [NextHash] are on disk.
+type SyncBlocksProgress struct {
+	Target     common.Hash // hash of the block being synced to
+	NextHash   common.Hash // hash of the next block to fetch
+	NextHeight uint64      // height of the next block to fetch
+	Remaining  uint64      // number of parents still to fetch
+}
+
+// ReadSyncBlocksProgress reads the checkpoint of fetching parent blocks of an
+// in-progress sync. Returns nil if no checkpoint was found.
+func ReadSyncBlocksProgress(db ethdb.KeyValueReader) (*SyncBlocksProgress, error) {
+	return readSyncBlocksProgress(db, syncBlocksKey)
+}
+
+// WriteSyncBlocksProgress writes the checkpoint of fetching parent blocks of
+// an in-progress sync.
+func WriteSyncBlocksProgress(db ethdb.KeyValueWriter, progress *SyncBlocksProgress) error {
+	return writeSyncBlocksProgress(db, syncBlocksKey, progress)
+}
+
+// DeleteSyncBlocksProgress removes the checkpoint of fetching parent blocks.
+func DeleteSyncBlocksProgress(db ethdb.KeyValueWriter) error {
+	return db.Delete(syncBlocksKey)
+}
+
+// ReadBlockBackfillProgress reads the checkpoint of backfilling historical
+// blocks below the block synced to. Returns nil if no checkpoint was found.
+func ReadBlockBackfillProgress(db ethdb.KeyValueReader) (*SyncBlocksProgress, error) {
+	return readSyncBlocksProgress(db, blockBackfillKey)
+}
+
+// WriteBlockBackfillProgress writes the checkpoint of backfilling historical
+// blocks below the block synced to.
+func WriteBlockBackfillProgress(db ethdb.KeyValueWriter, progress *SyncBlocksProgress) error {
+	return writeSyncBlocksProgress(db, blockBackfillKey, progress)
+}
+
+// DeleteBlockBackfillProgress removes the checkpoint of backfilling historical blocks.
+func DeleteBlockBackfillProgress(db ethdb.KeyValueWriter) error {
+	return db.Delete(blockBackfillKey)
+}
+
+func readSyncBlocksProgress(db ethdb.KeyValueReader, key []byte) (*SyncBlocksProgress, error) {
+	has, err := db.Has(key)
+	if err != nil || !has {
+		return nil, err
+	}
+	data, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != syncBlocksProgressLength {
+		return nil, fmt.Errorf("unexpected sync blocks progress length %d (expected %d)", len(data), syncBlocksProgressLength)
+	}
+	return &SyncBlocksProgress{
+		Target:     common.BytesToHash(data[:common.HashLength]),
+		NextHash:   common.BytesToHash(data[common.HashLength : 2*common.HashLength]),
+		NextHeight: binary.BigEndian.Uint64(data[2*common.HashLength:]),
+		Remaining:  binary.BigEndian.Uint64(data[2*common.HashLength+wrappers.LongLen:]),
+	}, nil
+}
+
+func writeSyncBlocksProgress(db ethdb.KeyValueWriter, key []byte, progress *SyncBlocksProgress) error {
+	data := make([]byte, syncBlocksProgressLength)
+	copy(data, progress.Target[:])
+	copy(data[common.HashLength:], progress.NextHash[:])
+	binary.BigEndian.PutUint64(data[2*common.HashLength:], progress.NextHeight)
+	binary.BigEndian.PutUint64(data[2*common.HashLength+wrappers.LongLen:], progress.Remaining)
+	return db.Put(key, data)
+}
+
 // AddCodeToFetch adds a marker that we need to fetch the code for [hash].
 func AddCodeToFetch(db ethdb.KeyValueWriter, hash common.Hash) {
 	if err := db.Put(codeToFetchKey(hash), nil); err != nil {
@@ -31,3 +31,28 @@ func TestClearPrefix(t *testing.T) {
 	require.NoError(it.Error())
 	require.Equal(1, count)
 }
+
+func TestSyncBlocksProgress(t *testing.T) {
+	require := require.New(t)
+	db := NewMemoryDatabase()
+
+	progress, err := ReadSyncBlocksProgress(db)
+	require.NoError(err)
+	require.Nil(progress)
+
+	expected := &SyncBlocksProgress{
+		Target:     common.Hash{1},
+		NextHash:   common.Hash{2},
+		NextHeight: 100,
+		Remaining:  20,
+	}
+	require.NoError(WriteSyncBlocksProgress(db, expected))
+	progress, err = ReadSyncBlocksProgress(db)
+	require.NoError(err)
+	require.Equal(expected, progress)
+
+	require.NoError(DeleteSyncBlocksProgress(db))
+	progress, err = ReadSyncBlocksProgress(db)
+	require.NoError(err)
+	require.Nil(progress)
+}
@@ -104,6 +104,8 @@ var (
 	PreimagePrefix = []byte("secure-key-")      // PreimagePrefix + hash -> preimage
 	configPrefix   = []byte("ethereum-config-") // config prefix for the db
 
+	upgradeConfigPrefix = []byte("ethereum-upgrade-config-") // upgradeConfigPrefix + genesis hash -> upgrade config
+
 	// BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 	BloomBitsIndexPrefix = []byte("iB")
 
@@ -111,10 +113,12 @@ var (
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 
 	// State sync progress keys and prefixes
-	syncRootKey            = []byte("sync_root")     // indicates the root of the main account trie currently being synced
-	syncStorageTriesPrefix = []byte("sync_storage")  // syncStorageTriesPrefix + trie root + account hash: indicates a storage trie must be fetched for the account
-	syncSegmentsPrefix     = []byte("sync_segments") // syncSegmentsPrefix + trie root + 32-byte start key: indicates the trie at root has a segment starting at the specified key
-	CodeToFetchPrefix      = []byte("CP")            // CodeToFetchPrefix + code hash -> empty value tracks the outstanding code hashes we need to fetch.
+	syncRootKey            = []byte("sync_root")      // indicates the root of the main account trie currently being synced
+	syncStorageTriesPrefix = []byte("sync_storage")   // syncStorageTriesPrefix + trie root + account hash: indicates a storage trie must be fetched for the account
+	syncSegmentsPrefix     = []byte("sync_segments")  // syncSegmentsPrefix + trie root + 32-byte start key: indicates the trie at root has a segment starting at the specified key
+	CodeToFetchPrefix      = []byte("CP")             // CodeToFetchPrefix + code hash -> empty value tracks the outstanding code hashes we need to fetch.
+	syncBlocksKey          = []byte("sync_blocks")    // indicates the progress of fetching the parents of the block currently being synced to
+	blockBackfillKey       = []byte("block_backfill") // indicates the progress of backfilling historical blocks below the block synced to
 
 	// State sync progress key lengths
 	syncStorageTriesKeyLength = len(syncStorageTriesPrefix) + 2*common.HashLength
@@ -124,6 +128,10 @@ var (
 	// State sync metadata
 	syncPerformedPrefix    = []byte("sync_performed")
 	syncPerformedKeyLength = len(syncPerformedPrefix) + wrappers.LongLen // prefix + block number as uint64
+
+	// Sidecars of the blob transactions in accepted blocks, which are not part of the blocks
+	blobSidecarPrefix    = []byte("blob_sidecar") // blobSidecarPrefix + num (uint64 big endian) + tx hash -> blob sidecar
+	blobSidecarKeyLength = len(blobSidecarPrefix) + 8 + common.HashLength
 )
 
 // LegacyTxLookupEntry is the legacy TxLookupEntry definition with some unnecessary
@@ -171,6 +179,11 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// blobSidecarKey = blobSidecarPrefix + num (uint64 big endian) + tx hash
+func blobSidecarKey(number uint64, txHash common.Hash) []byte {
+	return append(append(blobSidecarPrefix, encodeBlockNumber(number)...), txHash.Bytes()...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -229,6 +242,11 @@ func configKey(hash common.Hash) []byte {
 	return append(configPrefix, hash.Bytes()...)
 }
 
+// upgradeConfigKey = upgradeConfigPrefix + hash
+func upgradeConfigKey(hash common.Hash) []byte {
+	return append(upgradeConfigPrefix, hash.Bytes()...)
+}
+
 // stateIDKey = stateIDPrefix + root (32 bytes)
 func stateIDKey(root common.Hash) []byte {
 	return append(stateIDPrefix, root.Bytes()...)
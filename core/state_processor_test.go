@@ -28,6 +28,7 @@ package core
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -39,8 +40,13 @@ import (
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
+	"github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/coreth/precompile/contracts/txallowlist"
 	"github.com/ava-labs/coreth/trie"
 	"github.com/ava-labs/coreth/utils"
+	"github.com/ava-labs/coreth/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
@@ -346,6 +352,232 @@ func TestStateProcessorErrors(t *testing.T) {
 	}
 }
 
+// TestStateProcessorAllowLists tests that the transaction and contract
+// deployer allow lists are enforced when processing blocks.
+func TestStateProcessorAllowLists(t *testing.T) {
+	var (
+		adminKey, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		enabledKey, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		noRoleKey, _  = crypto.HexToECDSA("49a7b37aa6f6645917e7b807e9d1c00d4fa71f18343b0d4122a4d2df64dd6fee")
+		adminAddr     = crypto.PubkeyToAddress(adminKey.PublicKey)
+		enabledAddr   = crypto.PubkeyToAddress(enabledKey.PublicKey)
+		noRoleAddr    = crypto.PubkeyToAddress(noRoleKey.PublicKey)
+		funds         = big.NewInt(4000000000000000000) // 4 ether
+		gasPrice      = big.NewInt(225000000000)
+	)
+	cpcfg := *params.TestChainConfig
+	config := &cpcfg
+	config.UpgradeConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: deployerallowlist.NewConfig(utils.NewUint64(0), []common.Address{adminAddr}, nil, nil)},
+		{Config: txallowlist.NewConfig(utils.NewUint64(0), []common.Address{adminAddr}, []common.Address{enabledAddr}, nil)},
+	}
+	signer := types.LatestSigner(config)
+	gspec := &Genesis{
+		Config: config,
+		Alloc: types.GenesisAlloc{
+			adminAddr:   {Balance: funds},
+			enabledAddr: {Balance: funds},
+			noRoleAddr:  {Balance: funds},
+		},
+		GasLimit: params.CortinaGasLimit,
+	}
+	// Contract creation is only allowed for the deployer allow list admin, but
+	// creations by other transaction allow list members are still included.
+	_, _, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(i int, b *BlockGen) {
+		for _, key := range []*ecdsa.PrivateKey{adminKey, enabledKey} {
+			tx, err := types.SignTx(types.NewContractCreation(0, common.Big0, 100_000, gasPrice, []byte{byte(vm.STOP)}), signer, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.AddTx(tx)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := receipts[0][0].Status; have != types.ReceiptStatusSuccessful {
+		t.Fatalf("admin contract creation status mismatch: have %d, want %d", have, types.ReceiptStatusSuccessful)
+	}
+	if have := receipts[0][1].Status; have != types.ReceiptStatusFailed {
+		t.Fatalf("enabled contract creation status mismatch: have %d, want %d", have, types.ReceiptStatusFailed)
+	}
+
+	// Transactions from senders outside of the transaction allow list are invalid.
+	db := rawdb.NewMemoryDatabase()
+	blockchain, _ := NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	defer blockchain.Stop()
+
+	tx, err := types.SignTx(types.NewTransaction(0, adminAddr, common.Big0, params.TxGas, gasPrice, nil), signer, noRoleKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := GenerateBadBlock(gspec.ToBlock(), dummy.NewCoinbaseFaker(), types.Transactions{tx}, gspec.Config)
+	if _, err := blockchain.InsertChain(types.Blocks{block}); !errors.Is(err, vmerrs.ErrSenderNotAllowListed) {
+		t.Fatalf("block import error mismatch: have %v, want %v", err, vmerrs.ErrSenderNotAllowListed)
+	}
+}
+
+// TestStateProcessorFeeManager tests that the fee configuration stored by the
+// fee manager precompile sets the gas limit and bounds the base fee of the
+// following blocks, both when generating and when importing them.
+func TestStateProcessorFeeManager(t *testing.T) {
+	var (
+		adminKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		adminAddr   = crypto.PubkeyToAddress(adminKey.PublicKey)
+		funds       = big.NewInt(4000000000000000000) // 4 ether
+		gasPrice    = big.NewInt(225000000000)
+		feeConfig   = &params.FeeConfig{
+			GasLimit:                 big.NewInt(20_000_000),
+			TargetBlockRate:          2,
+			MinBaseFee:               big.NewInt(300_000_000_000),
+			TargetGas:                big.NewInt(15_000_000),
+			BaseFeeChangeDenominator: big.NewInt(36),
+			MinBlockGasCost:          big.NewInt(0),
+			MaxBlockGasCost:          big.NewInt(1_000_000),
+			BlockGasCostStep:         big.NewInt(200_000),
+		}
+	)
+	cpcfg := *params.TestChainConfig
+	config := &cpcfg
+	config.UpgradeConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: feemanager.NewConfig(utils.NewUint64(0), []common.Address{adminAddr}, nil, nil, nil)},
+	}
+	signer := types.LatestSigner(config)
+	gspec := &Genesis{
+		Config:   config,
+		Alloc:    types.GenesisAlloc{adminAddr: {Balance: funds}},
+		GasLimit: params.CortinaGasLimit,
+	}
+	input, err := feemanager.PackSetFeeConfig(feeConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, b *BlockGen) {
+		if i != 0 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(0, feemanager.ContractAddress, common.Big0, 500_000, gasPrice, input), signer, adminKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.AddTx(tx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The stored fee configuration applies from the child of the block storing it.
+	if have, want := blocks[0].GasLimit(), params.CortinaGasLimit; have != want {
+		t.Fatalf("gas limit mismatch before the fee config is stored: have %d, want %d", have, want)
+	}
+	if have, want := blocks[1].GasLimit(), feeConfig.GasLimit.Uint64(); have != want {
+		t.Fatalf("gas limit mismatch after the fee config is stored: have %d, want %d", have, want)
+	}
+	if blocks[1].BaseFee().Cmp(feeConfig.MinBaseFee) < 0 {
+		t.Fatalf("base fee %d below the stored minimum %d", blocks[1].BaseFee(), feeConfig.MinBaseFee)
+	}
+
+	// The blocks are valid for a chain reading the stored fee configuration.
+	db := rawdb.NewMemoryDatabase()
+	blockchain, _ := NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	defer blockchain.Stop()
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+
+	// A block ignoring the stored gas limit is rejected.
+	invalid := types.CopyHeader(blocks[1].Header())
+	invalid.GasLimit = params.CortinaGasLimit
+	if err := blockchain.engine.VerifyHeader(blockchain, invalid); err == nil {
+		t.Fatal("expected header with the default gas limit to be rejected")
+	}
+}
+
+// TestStateProcessorRewardManager tests that the reward configuration stored by
+// the reward manager precompile decides the coinbase receiving the fees of the
+// following blocks, both when generating and when importing them.
+func TestStateProcessorRewardManager(t *testing.T) {
+	var (
+		adminKey, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		adminAddr     = crypto.PubkeyToAddress(adminKey.PublicKey)
+		funds         = big.NewInt(4000000000000000000) // 4 ether
+		gasPrice      = big.NewInt(225000000000)
+		rewardAddress = common.Address{'r', 'e', 'w', 'a', 'r', 'd'}
+		feeRecipient  = common.Address{'f', 'e', 'e'}
+	)
+	cpcfg := *params.TestChainConfig
+	config := &cpcfg
+	config.UpgradeConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: rewardmanager.NewConfig(utils.NewUint64(0), []common.Address{adminAddr}, nil, nil, &rewardmanager.InitialRewardConfig{
+			RewardAddress: rewardAddress,
+		})},
+	}
+	signer := types.LatestSigner(config)
+	gspec := &Genesis{
+		Config:   config,
+		Alloc:    types.GenesisAlloc{adminAddr: {Balance: funds}},
+		GasLimit: params.CortinaGasLimit,
+	}
+	input, err := rewardmanager.PackAllowFeeRecipients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewFaker(), 2, 10, func(i int, b *BlockGen) {
+		switch i {
+		case 0:
+			// The initial reward address receives the fees of the first block,
+			// which allows fee recipients from the second block on.
+			b.SetCoinbase(rewardAddress)
+			tx, err := types.SignTx(types.NewTransaction(0, rewardmanager.ContractAddress, common.Big0, 500_000, gasPrice, input), signer, adminKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.AddTx(tx)
+		case 1:
+			b.SetCoinbase(feeRecipient)
+			tx, err := types.SignTx(types.NewTransaction(1, feeRecipient, common.Big0, params.TxGas, gasPrice, nil), signer, adminKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.AddTx(tx)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The blocks are valid for a chain reading the stored reward configuration.
+	db := rawdb.NewMemoryDatabase()
+	blockchain, _ := NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewFaker(), vm.Config{}, common.Hash{}, false)
+	defer blockchain.Stop()
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	statedb, err := blockchain.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statedb.GetBalance(rewardAddress).IsZero() {
+		t.Fatal("reward address did not receive the fees of the first block")
+	}
+	if statedb.GetBalance(feeRecipient).IsZero() {
+		t.Fatal("fee recipient did not receive the fees of the second block")
+	}
+
+	// A block paying another coinbase than the reward address is rejected.
+	_, invalid, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(i int, b *BlockGen) {
+		b.SetCoinbase(feeRecipient)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db = rawdb.NewMemoryDatabase()
+	blockchain, _ = NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewFaker(), vm.Config{}, common.Hash{}, false)
+	defer blockchain.Stop()
+	if _, err := blockchain.InsertChain(invalid); err == nil {
+		t.Fatal("expected block paying another coinbase than the reward address to be rejected")
+	}
+}
+
 // GenerateBadBlock constructs a "block" which contains the transactions. The transactions are not expected to be
 // valid, and no proper post-state can be made. But from the perspective of the blockchain, the block is sufficiently
 // valid to be considered for import:
@@ -367,7 +599,7 @@ func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Tr
 		UncleHash: types.EmptyUncleHash,
 	}
 	if config.IsApricotPhase3(header.Time) {
-		header.Extra, header.BaseFee, _ = dummy.CalcBaseFee(config, parent.Header(), header.Time)
+		header.Extra, header.BaseFee, _ = dummy.CalcBaseFee(config, nil, parent.Header(), header.Time)
 	}
 	if config.IsApricotPhase4(header.Time) {
 		header.BlockGasCost = big.NewInt(0)
@@ -34,6 +34,7 @@ import (
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/txallowlist"
 	"github.com/ava-labs/coreth/utils"
 	"github.com/ava-labs/coreth/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
@@ -355,6 +356,13 @@ func (st *StateTransition) preCheck() error {
 		if vm.IsProhibited(msg.From) {
 			return fmt.Errorf("%w: address %v", vmerrs.ErrAddrProhibited, msg.From)
 		}
+		// Make sure the sender is allowed to issue transactions if the
+		// transaction allow list is enabled
+		if st.evm.ChainConfig().IsPrecompileEnabled(txallowlist.ContractAddress, st.evm.Context.Time) {
+			if role := txallowlist.GetTxAllowListStatus(st.state, msg.From); !role.IsEnabled() {
+				return fmt.Errorf("%w: address %v", vmerrs.ErrSenderNotAllowListed, msg.From)
+			}
+		}
 	}
 	// Make sure that transaction gasFeeCap is greater than the baseFee (post london)
 	if st.evm.ChainConfig().IsApricotPhase3(st.evm.Context.Time) {
@@ -412,6 +412,7 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserve txpool.Addres
 	}
 	_, baseFee, err := dummy.EstimateNextBaseFee(
 		p.chain.Config(),
+		p.chain,
 		p.head,
 		uint64(time.Now().Unix()),
 	)
@@ -842,6 +843,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 	}
 	_, baseFeeBig, err := dummy.EstimateNextBaseFee(
 		p.chain.Config(),
+		p.chain,
 		p.head,
 		uint64(time.Now().Unix()),
 	)
@@ -1123,6 +1125,7 @@ func (p *BlobPool) validateTx(tx *types.Transaction) error {
 	// Ensure the transaction adheres to the stateful pool filters (nonce, balance)
 	stateOpts := &txpool.ValidationOptionsWithState{
 		State: p.state,
+		Rules: p.chain.Config().Rules(p.head.Number, p.head.Time),
 
 		FirstNonceGap: func(addr common.Address) uint64 {
 			// Nonce gaps are not permitted in the blob pool, the first gap will
@@ -1237,6 +1240,23 @@ func (p *BlobPool) Get(hash common.Hash) *types.Transaction {
 	return item
 }
 
+// GetSidecar returns the sidecar of a blob transaction if it is contained in
+// the pool, or was recently included and is still kept in limbo in case of a
+// reorg, or nil otherwise.
+func (p *BlobPool) GetSidecar(hash common.Hash) *types.BlobTxSidecar {
+	if tx := p.Get(hash); tx != nil {
+		return tx.BlobTxSidecar()
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	tx, err := p.limbo.get(hash)
+	if err != nil {
+		return nil
+	}
+	return tx.BlobTxSidecar()
+}
+
 // Add inserts a set of blob transactions into the pool if they pass validation (both
 // consensus validity and pool restrictions).
 func (p *BlobPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
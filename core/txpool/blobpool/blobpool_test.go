@@ -47,6 +47,7 @@ import (
 	"github.com/ava-labs/coreth/core/txpool"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -75,6 +76,7 @@ func init() {
 
 	testChainConfig.CancunTime = new(uint64)
 	*testChainConfig.CancunTime = uint64(time.Now().Unix())
+	testChainConfig.BlobTxsTimestamp = testChainConfig.CancunTime
 }
 
 // overrideMinFee sets the minimum base fee to 1 wei for the duration of the test.
@@ -122,7 +124,7 @@ func (bc *testBlockChain) CurrentBlock() *types.Header {
 			Extra:    make([]byte, params.DynamicFeeExtraDataSize),
 		}
 		_, baseFee, err := dummy.CalcBaseFee(
-			bc.config, parent, blockTime,
+			bc.config, nil, parent, blockTime,
 		)
 		if err != nil {
 			panic(err)
@@ -176,6 +178,10 @@ func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
 	return bc.statedb, nil
 }
 
+func (bc *testBlockChain) GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error) {
+	return feemanager.GetStoredFeeConfig(bc.statedb), nil
+}
+
 // makeAddressReserver is a utility method to sanity check that accounts are
 // properly reserved by the blobpool (no duplicate reserves or unreserves).
 func makeAddressReserver() txpool.AddressReserver {
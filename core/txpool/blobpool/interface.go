@@ -51,4 +51,8 @@ type BlockChain interface {
 
 	// StateAt returns a state database for a given root hash (generally the head).
 	StateAt(root common.Hash) (*state.StateDB, error)
+
+	// GetFeeConfigAt returns the fee configuration stored by the fee manager
+	// precompile in the state of [parent], used to estimate the base fee.
+	GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error)
 }
@@ -179,6 +179,24 @@ func (l *limbo) pull(tx common.Hash) (*types.Transaction, error) {
 	return item.Tx, nil
 }
 
+// get retrieves a previously pushed blob transaction from the limbo, without
+// removing it.
+func (l *limbo) get(tx common.Hash) (*types.Transaction, error) {
+	id, ok := l.index[tx]
+	if !ok {
+		return nil, errors.New("unseen blob transaction")
+	}
+	data, err := l.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	item := new(limboBlob)
+	if err = rlp.DecodeBytes(data, item); err != nil {
+		return nil, err
+	}
+	return item.Tx, nil
+}
+
 // update changes the block number under which a blob transaction is tracked. This
 // method should be used when a reorg changes a transaction's inclusion block.
 //
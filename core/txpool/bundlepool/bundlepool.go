@@ -0,0 +1,368 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package bundlepool implements a transaction pool for bundles of
+// transactions that are included in a block together or not at all.
+package bundlepool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/metrics"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/holiman/uint256"
+)
+
+// txMaxSize is the maximum size a single bundled transaction can have, same
+// as in the legacy pool.
+const txMaxSize = 128 * 1024
+
+var (
+	// ErrEmptyBundle is returned if a bundle contains no transactions.
+	ErrEmptyBundle = errors.New("empty bundle")
+
+	// ErrBundleTooLarge is returned if a bundle contains more transactions
+	// than the pool allows.
+	ErrBundleTooLarge = errors.New("bundle too large")
+
+	// ErrBundleTargetPassed is returned if the target block of a bundle is
+	// not above the current head.
+	ErrBundleTargetPassed = errors.New("bundle target block already passed")
+
+	// ErrBundleExpired is returned if the maximum timestamp of a bundle is
+	// below the timestamp of the current head.
+	ErrBundleExpired = errors.New("bundle expired")
+
+	// ErrInvalidBundleTimestamps is returned if the maximum timestamp of a
+	// bundle is below its minimum timestamp.
+	ErrInvalidBundleTimestamps = errors.New("bundle max timestamp below min timestamp")
+
+	// ErrBundlePoolFull is returned if the pool already holds the maximum
+	// number of bundles.
+	ErrBundlePoolFull = errors.New("bundle pool is full")
+
+	// ErrBundleOnly is returned if transactions are added to the pool
+	// individually instead of as part of a bundle.
+	ErrBundleOnly = errors.New("transactions must be submitted as part of a bundle")
+)
+
+var bundlesGauge = metrics.NewRegisteredGauge("bundlepool/bundles", nil)
+
+var _ txpool.SubPool = (*BundlePool)(nil)
+
+// BlockChain defines the minimal set of methods needed to back a bundle pool
+// with a chain. Exists to allow mocking the live chain out of tests.
+type BlockChain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+}
+
+// Bundle is an ordered list of transactions which must all be included in
+// the target block, in order and without any failing, or not at all.
+type Bundle struct {
+	Txs          types.Transactions
+	BlockNumber  uint64 // Number of the only block the bundle may be included in
+	MinTimestamp uint64 // Minimum timestamp of the block, 0 if unbounded
+	MaxTimestamp uint64 // Maximum timestamp of the block, 0 if unbounded
+}
+
+// Hash returns the hash identifying the bundle, which commits to its
+// transactions, target block and timestamp bounds.
+func (b *Bundle) Hash() common.Hash {
+	data := make([]byte, 0, len(b.Txs)*common.HashLength+3*8)
+	for _, tx := range b.Txs {
+		data = append(data, tx.Hash().Bytes()...)
+	}
+	data = binary.BigEndian.AppendUint64(data, b.BlockNumber)
+	data = binary.BigEndian.AppendUint64(data, b.MinTimestamp)
+	data = binary.BigEndian.AppendUint64(data, b.MaxTimestamp)
+	return crypto.Keccak256Hash(data)
+}
+
+// Includable returns whether the bundle may be included in a block with the
+// given number and timestamp.
+func (b *Bundle) Includable(number uint64, time uint64) bool {
+	if b.BlockNumber != number || time < b.MinTimestamp {
+		return false
+	}
+	return b.MaxTimestamp == 0 || time <= b.MaxTimestamp
+}
+
+// expired returns whether the bundle may no longer be included in any block
+// built on top of [head].
+func (b *Bundle) expired(head *types.Header) bool {
+	if b.BlockNumber <= head.Number.Uint64() {
+		return true
+	}
+	// Blocks may have the same timestamp as their parent, but never a lower one.
+	return b.MaxTimestamp != 0 && b.MaxTimestamp < head.Time
+}
+
+// BundlePool is a subpool holding bundles of transactions until their target
+// block is built. Transactions are only accepted as part of a bundle, so the
+// pool never reports them as pending to be included individually.
+type BundlePool struct {
+	config Config
+	chain  BlockChain
+	signer types.Signer
+
+	mu      sync.RWMutex
+	head    *types.Header
+	gasTip  *uint256.Int
+	bundles []*Bundle                          // Bundles in order of arrival
+	known   map[common.Hash]struct{}           // Hashes of the held bundles
+	txs     map[common.Hash]*types.Transaction // Transactions of the held bundles
+	txRefs  map[common.Hash]int                // Number of held bundles containing each transaction
+}
+
+// New creates a new bundle pool to hold bundles of transactions until their
+// target block is built.
+func New(config Config, chain BlockChain) *BundlePool {
+	return &BundlePool{
+		config: config.sanitize(),
+		chain:  chain,
+		signer: types.LatestSigner(chain.Config()),
+		known:  make(map[common.Hash]struct{}),
+		txs:    make(map[common.Hash]*types.Transaction),
+		txRefs: make(map[common.Hash]int),
+	}
+}
+
+// Filter returns false for every transaction, since transactions can only be
+// added to the pool as part of a bundle through AddBundle.
+func (p *BundlePool) Filter(tx *types.Transaction) bool {
+	return false
+}
+
+// Init sets the gas tip and head of the pool. Bundles are not persisted, so
+// there is nothing to load.
+func (p *BundlePool) Init(gasTip uint64, head *types.Header, reserve txpool.AddressReserver) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.head = head
+	p.gasTip = uint256.NewInt(gasTip)
+	return nil
+}
+
+// Close drops all held bundles.
+func (p *BundlePool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.bundles = nil
+	p.known = make(map[common.Hash]struct{})
+	p.txs = make(map[common.Hash]*types.Transaction)
+	p.txRefs = make(map[common.Hash]int)
+	bundlesGauge.Update(0)
+	return nil
+}
+
+// Reset drops the bundles which may no longer be included in a block built on
+// top of [newHead].
+func (p *BundlePool) Reset(oldHead, newHead *types.Header) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.head = newHead
+
+	bundles := p.bundles[:0]
+	for _, bundle := range p.bundles {
+		if !bundle.expired(newHead) {
+			bundles = append(bundles, bundle)
+			continue
+		}
+		p.drop(bundle)
+	}
+	for i := len(bundles); i < len(p.bundles); i++ {
+		p.bundles[i] = nil
+	}
+	p.bundles = bundles
+	bundlesGauge.Update(int64(len(p.bundles)))
+}
+
+// drop removes the transactions of [bundle] from the lookup maps. The caller
+// is responsible for removing [bundle] from p.bundles.
+func (p *BundlePool) drop(bundle *Bundle) {
+	delete(p.known, bundle.Hash())
+	for _, tx := range bundle.Txs {
+		hash := tx.Hash()
+		p.txRefs[hash]--
+		if p.txRefs[hash] <= 0 {
+			delete(p.txRefs, hash)
+			delete(p.txs, hash)
+		}
+	}
+}
+
+// SetGasTip updates the minimum gas tip required for the transactions of new
+// bundles. Held bundles are not affected.
+func (p *BundlePool) SetGasTip(tip *big.Int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.gasTip = uint256.MustFromBig(tip)
+}
+
+// SetMinFee is a no-op, since bundles are only checked against the base fee
+// of the block they are included in.
+func (p *BundlePool) SetMinFee(fee *big.Int) {}
+
+// Has returns whether a held bundle contains the transaction with [hash].
+func (p *BundlePool) Has(hash common.Hash) bool {
+	return p.Get(hash) != nil
+}
+
+// HasLocal returns false, since bundles are not tracked as local.
+func (p *BundlePool) HasLocal(hash common.Hash) bool {
+	return false
+}
+
+// Get returns the transaction with [hash] if a held bundle contains it, or
+// nil otherwise.
+func (p *BundlePool) Get(hash common.Hash) *types.Transaction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.txs[hash]
+}
+
+// Add rejects all transactions, since transactions can only be added to the
+// pool as part of a bundle through AddBundle.
+func (p *BundlePool) Add(txs []*types.Transaction, local bool, sync bool) []error {
+	errs := make([]error, len(txs))
+	for i := range txs {
+		errs[i] = ErrBundleOnly
+	}
+	return errs
+}
+
+// AddBundle validates [bundle] and adds it to the pool.
+func (p *BundlePool) AddBundle(bundle *Bundle) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case len(bundle.Txs) == 0:
+		return ErrEmptyBundle
+	case len(bundle.Txs) > p.config.MaxBundleTxs:
+		return fmt.Errorf("%w: %d transactions, limit %d", ErrBundleTooLarge, len(bundle.Txs), p.config.MaxBundleTxs)
+	case bundle.MaxTimestamp != 0 && bundle.MaxTimestamp < bundle.MinTimestamp:
+		return fmt.Errorf("%w: min %d, max %d", ErrInvalidBundleTimestamps, bundle.MinTimestamp, bundle.MaxTimestamp)
+	case bundle.BlockNumber <= p.head.Number.Uint64():
+		return fmt.Errorf("%w: target %d, head %d", ErrBundleTargetPassed, bundle.BlockNumber, p.head.Number)
+	case bundle.expired(p.head):
+		return fmt.Errorf("%w: max timestamp %d, head timestamp %d", ErrBundleExpired, bundle.MaxTimestamp, p.head.Time)
+	}
+	hash := bundle.Hash()
+	if _, ok := p.known[hash]; ok {
+		return txpool.ErrAlreadyKnown
+	}
+	if len(p.bundles) >= p.config.MaxBundles {
+		return ErrBundlePoolFull
+	}
+	opts := &txpool.ValidationOptions{
+		Config: p.chain.Config(),
+		Accept: 0 |
+			1<<types.LegacyTxType |
+			1<<types.AccessListTxType |
+			1<<types.DynamicFeeTxType,
+		MaxSize: txMaxSize,
+		MinTip:  p.gasTip.ToBig(),
+	}
+	for i, tx := range bundle.Txs {
+		if err := txpool.ValidateTransaction(tx, p.head, p.signer, opts); err != nil {
+			return fmt.Errorf("invalid bundle transaction %d (%s): %w", i, tx.Hash(), err)
+		}
+	}
+
+	p.bundles = append(p.bundles, bundle)
+	p.known[hash] = struct{}{}
+	for _, tx := range bundle.Txs {
+		p.txs[tx.Hash()] = tx
+		p.txRefs[tx.Hash()]++
+	}
+	bundlesGauge.Update(int64(len(p.bundles)))
+	return nil
+}
+
+// Bundles returns the held bundles which may be included in a block with the
+// given number and timestamp, in order of arrival.
+func (p *BundlePool) Bundles(number uint64, time uint64) []*Bundle {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var bundles []*Bundle
+	for _, bundle := range p.bundles {
+		if bundle.Includable(number, time) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// Pending returns no transactions, since bundled transactions can only be
+// included as part of their bundle.
+func (p *BundlePool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	return nil
+}
+
+// IteratePending does not iterate any transactions, since bundled
+// transactions can only be included as part of their bundle.
+func (p *BundlePool) IteratePending(f func(tx *types.Transaction) bool) bool {
+	return true
+}
+
+// SubscribeTransactions returns nil, since bundled transactions are not
+// announced to peers.
+func (p *BundlePool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription {
+	return nil
+}
+
+// Nonce returns 0, since bundled transactions do not advance the pending
+// nonce of their sender.
+func (p *BundlePool) Nonce(addr common.Address) uint64 {
+	return 0
+}
+
+// Stats returns no pending or queued transactions, since bundled
+// transactions can only be included as part of their bundle.
+func (p *BundlePool) Stats() (int, int) {
+	return 0, 0
+}
+
+// Content returns no transactions, since bundled transactions can only be
+// included as part of their bundle.
+func (p *BundlePool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	return make(map[common.Address][]*types.Transaction), make(map[common.Address][]*types.Transaction)
+}
+
+// ContentFrom returns no transactions, since bundled transactions can only be
+// included as part of their bundle.
+func (p *BundlePool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	return []*types.Transaction{}, []*types.Transaction{}
+}
+
+// Locals returns no accounts, since bundles are not tracked as local.
+func (p *BundlePool) Locals() []common.Address {
+	return nil
+}
+
+// Status returns pending for transactions of held bundles, as they are
+// waiting for the target block of their bundle.
+func (p *BundlePool) Status(hash common.Hash) txpool.TxStatus {
+	if p.Has(hash) {
+		return txpool.TxStatusPending
+	}
+	return txpool.TxStatusUnknown
+}
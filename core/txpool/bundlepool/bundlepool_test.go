@@ -0,0 +1,214 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bundlepool
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type testBlockChain struct {
+	config *params.ChainConfig
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig { return bc.config }
+
+func newTestPool(t *testing.T, config Config, head *types.Header) *BundlePool {
+	pool := New(config, &testBlockChain{config: params.TestChainConfig})
+	if err := pool.Init(0, head, nil); err != nil {
+		t.Fatalf("failed to init pool: %v", err)
+	}
+	return pool
+}
+
+func newTestHeader(number uint64, time uint64) *types.Header {
+	return &types.Header{
+		Number:   new(big.Int).SetUint64(number),
+		Time:     time,
+		GasLimit: params.CortinaGasLimit,
+		BaseFee:  big.NewInt(params.ApricotPhase3InitialBaseFee),
+	}
+}
+
+func newTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	tx, err := types.SignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(params.ApricotPhase3InitialBaseFee),
+		Gas:       params.TxGas,
+		To:        &common.Address{},
+		Value:     big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatalf("failed to sign tx: %v", err)
+	}
+	return tx
+}
+
+func TestAddBundle(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx0, tx1 := newTestTx(t, key, 0), newTestTx(t, key, 1)
+	unsigned := types.NewTx(&types.DynamicFeeTx{ChainID: params.TestChainConfig.ChainID, Gas: params.TxGas, To: &common.Address{}})
+
+	tests := map[string]struct {
+		bundle *Bundle
+		err    error
+	}{
+		"valid": {
+			bundle: &Bundle{Txs: types.Transactions{tx0, tx1}, BlockNumber: 11},
+		},
+		"empty": {
+			bundle: &Bundle{BlockNumber: 11},
+			err:    ErrEmptyBundle,
+		},
+		"too many txs": {
+			bundle: &Bundle{Txs: types.Transactions{tx0, tx1, tx0}, BlockNumber: 11},
+			err:    ErrBundleTooLarge,
+		},
+		"target passed": {
+			bundle: &Bundle{Txs: types.Transactions{tx0}, BlockNumber: 10},
+			err:    ErrBundleTargetPassed,
+		},
+		"expired": {
+			bundle: &Bundle{Txs: types.Transactions{tx0}, BlockNumber: 11, MaxTimestamp: 99},
+			err:    ErrBundleExpired,
+		},
+		"invalid timestamps": {
+			bundle: &Bundle{Txs: types.Transactions{tx0}, BlockNumber: 11, MinTimestamp: 110, MaxTimestamp: 105},
+			err:    ErrInvalidBundleTimestamps,
+		},
+		"invalid tx": {
+			bundle: &Bundle{Txs: types.Transactions{tx0, unsigned}, BlockNumber: 11},
+			err:    txpool.ErrInvalidSender,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pool := newTestPool(t, Config{MaxBundles: 2, MaxBundleTxs: 2}, newTestHeader(10, 100))
+			if err := pool.AddBundle(test.bundle); !errors.Is(err, test.err) {
+				t.Fatalf("unexpected error: have %v, want %v", err, test.err)
+			}
+			for _, tx := range test.bundle.Txs {
+				if have, want := pool.Has(tx.Hash()), test.err == nil; have != want {
+					t.Errorf("unexpected presence of tx %s: have %t, want %t", tx.Hash(), have, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBundleHash(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	txs := types.Transactions{newTestTx(t, key, 0)}
+	bundle := &Bundle{Txs: txs, BlockNumber: 11, MinTimestamp: 100, MaxTimestamp: 110}
+
+	// Bundles differing only in their timestamp bounds are distinct.
+	for _, other := range []*Bundle{
+		{Txs: txs, BlockNumber: 12, MinTimestamp: 100, MaxTimestamp: 110},
+		{Txs: txs, BlockNumber: 11, MaxTimestamp: 110},
+		{Txs: txs, BlockNumber: 11, MinTimestamp: 100},
+	} {
+		if bundle.Hash() == other.Hash() {
+			t.Errorf("bundle %+v has the same hash as %+v", other, bundle)
+		}
+	}
+}
+
+func TestAddBundleLimits(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	pool := newTestPool(t, Config{MaxBundles: 2, MaxBundleTxs: 2}, newTestHeader(10, 100))
+
+	if err := pool.AddBundle(&Bundle{Txs: types.Transactions{newTestTx(t, key, 0)}, BlockNumber: 11}); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+	if err := pool.AddBundle(&Bundle{Txs: types.Transactions{newTestTx(t, key, 0)}, BlockNumber: 11}); !errors.Is(err, txpool.ErrAlreadyKnown) {
+		t.Fatalf("unexpected error adding known bundle: have %v, want %v", err, txpool.ErrAlreadyKnown)
+	}
+	if err := pool.AddBundle(&Bundle{Txs: types.Transactions{newTestTx(t, key, 0)}, BlockNumber: 12}); err != nil {
+		t.Fatalf("failed to add bundle for another block: %v", err)
+	}
+	if err := pool.AddBundle(&Bundle{Txs: types.Transactions{newTestTx(t, key, 1)}, BlockNumber: 12}); !errors.Is(err, ErrBundlePoolFull) {
+		t.Fatalf("unexpected error adding bundle to full pool: have %v, want %v", err, ErrBundlePoolFull)
+	}
+	// Bundled transactions are never included individually.
+	if pending := pool.Pending(txpool.PendingFilter{}); len(pending) != 0 {
+		t.Fatalf("unexpected pending transactions: %v", pending)
+	}
+	if errs := pool.Add(types.Transactions{newTestTx(t, key, 1)}, true, true); !errors.Is(errs[0], ErrBundleOnly) {
+		t.Fatalf("unexpected error adding individual tx: have %v, want %v", errs[0], ErrBundleOnly)
+	}
+}
+
+func TestBundlesTargetAndExpiry(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	pool := newTestPool(t, DefaultConfig, newTestHeader(10, 100))
+
+	var (
+		shared = newTestTx(t, key, 0)
+		next   = &Bundle{Txs: types.Transactions{shared}, BlockNumber: 11}
+		window = &Bundle{Txs: types.Transactions{shared, newTestTx(t, key, 1)}, BlockNumber: 12, MinTimestamp: 105, MaxTimestamp: 110}
+		later  = &Bundle{Txs: types.Transactions{newTestTx(t, key, 2)}, BlockNumber: 13}
+	)
+	for _, bundle := range []*Bundle{next, window, later} {
+		if err := pool.AddBundle(bundle); err != nil {
+			t.Fatalf("failed to add bundle: %v", err)
+		}
+	}
+
+	tests := []struct {
+		number uint64
+		time   uint64
+		want   []*Bundle
+	}{
+		{number: 11, time: 100, want: []*Bundle{next}},
+		{number: 12, time: 104},
+		{number: 12, time: 105, want: []*Bundle{window}},
+		{number: 12, time: 110, want: []*Bundle{window}},
+		{number: 12, time: 111},
+		{number: 13, time: 200, want: []*Bundle{later}},
+	}
+	for _, test := range tests {
+		have := pool.Bundles(test.number, test.time)
+		if len(have) != len(test.want) {
+			t.Fatalf("block %d at %d: have %d bundles, want %d", test.number, test.time, len(have), len(test.want))
+		}
+		for i := range have {
+			if have[i] != test.want[i] {
+				t.Errorf("block %d at %d: unexpected bundle %d: have %s, want %s", test.number, test.time, i, have[i].Hash(), test.want[i].Hash())
+			}
+		}
+	}
+
+	// Accepting block 11 drops the bundle targeting it, but keeps the shared
+	// transaction of the bundle targeting block 12.
+	pool.Reset(nil, newTestHeader(11, 102))
+	if bundles := pool.Bundles(11, 102); len(bundles) != 0 {
+		t.Fatalf("unexpected bundles for passed block: %d", len(bundles))
+	}
+	if !pool.Has(shared.Hash()) {
+		t.Fatalf("shared transaction dropped with one of its bundles")
+	}
+
+	// A head past the max timestamp of the bundle targeting block 12 expires
+	// it before its target block is built.
+	pool.Reset(nil, newTestHeader(11, 111))
+	if bundles := pool.Bundles(12, 111); len(bundles) != 0 {
+		t.Fatalf("unexpected bundles after expiry: %d", len(bundles))
+	}
+	if pool.Has(shared.Hash()) {
+		t.Fatalf("transaction of expired bundles not dropped")
+	}
+	if bundles := pool.Bundles(13, 200); len(bundles) != 1 || bundles[0] != later {
+		t.Fatalf("unexpected bundles for block 13: %v", bundles)
+	}
+}
@@ -0,0 +1,35 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bundlepool
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Config are the configuration parameters of the bundle pool.
+type Config struct {
+	MaxBundles   int // Maximum number of bundles held by the pool
+	MaxBundleTxs int // Maximum number of transactions in a single bundle
+}
+
+// DefaultConfig contains the default configurations for the bundle pool.
+var DefaultConfig = Config{
+	MaxBundles:   1024,
+	MaxBundleTxs: 16,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.MaxBundles < 1 {
+		log.Warn("Sanitizing invalid bundlepool max bundles", "provided", conf.MaxBundles, "updated", DefaultConfig.MaxBundles)
+		conf.MaxBundles = DefaultConfig.MaxBundles
+	}
+	if conf.MaxBundleTxs < 1 {
+		log.Warn("Sanitizing invalid bundlepool max bundle txs", "provided", conf.MaxBundleTxs, "updated", DefaultConfig.MaxBundleTxs)
+		conf.MaxBundleTxs = DefaultConfig.MaxBundleTxs
+	}
+	return conf
+}
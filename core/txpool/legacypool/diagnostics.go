@@ -0,0 +1,106 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package legacypool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// droppedTxsCacheSize is the number of recently dropped transactions whose
+// drop reason is remembered.
+const droppedTxsCacheSize = 8192
+
+// Reasons reported for transactions dropped or demoted by the pool.
+const (
+	reasonReplaced           = "replaced by a transaction with the same nonce and a higher price"
+	reasonReplaceUnderpriced = "replacement transaction underpriced"
+	reasonEvictedUnderpriced = "underpriced: evicted to make room for higher priced transactions"
+	reasonBelowGasTip        = "underpriced: gas tip below the pool's minimum"
+	reasonLifetime           = "queued for longer than the pool's lifetime"
+	reasonUnpayable          = "insufficient funds or gas above the block gas limit"
+	reasonAccountQueue       = "account queue slots exceeded"
+	reasonPendingLimit       = "pool pending slots exceeded"
+	reasonQueueLimit         = "pool queue slots exceeded"
+	reasonNonceGap           = "demoted: nonce gap"
+)
+
+// diagnostics remembers why transactions recently left the pool and posts
+// their status changes to subscribers.
+//
+// Status changes are buffered while the pool lock is held and only sent by
+// flush, which must be called without holding the pool lock.
+type diagnostics struct {
+	dropped *lru.Cache[common.Hash, txpool.DroppedTx]
+
+	lock   sync.Mutex
+	events []txpool.TxStatusEvent
+	feed   event.Feed
+	scope  event.SubscriptionScope
+}
+
+func newDiagnostics() *diagnostics {
+	return &diagnostics{
+		dropped: lru.NewCache[common.Hash, txpool.DroppedTx](droppedTxsCacheSize),
+	}
+}
+
+// drop records that the transaction with [hash] left the pool for [reason].
+func (d *diagnostics) drop(hash common.Hash, reason string) {
+	d.dropped.Add(hash, txpool.DroppedTx{Reason: reason, Time: time.Now()})
+	d.post(txpool.TxStatusEvent{Hash: hash, Status: txpool.TxStatusUnknown, Reason: reason})
+}
+
+// queued records that the transaction with [hash] entered the queue, for
+// [reason] if it was demoted from pending.
+func (d *diagnostics) queued(hash common.Hash, reason string) {
+	d.post(txpool.TxStatusEvent{Hash: hash, Status: txpool.TxStatusQueued, Reason: reason})
+}
+
+// pending records that the transaction with [hash] became pending.
+func (d *diagnostics) pending(hash common.Hash) {
+	// A transaction dropped before may be added again.
+	d.dropped.Remove(hash)
+	d.post(txpool.TxStatusEvent{Hash: hash, Status: txpool.TxStatusPending})
+}
+
+func (d *diagnostics) post(ev txpool.TxStatusEvent) {
+	// Avoid buffering events nobody listens to.
+	if d.scope.Count() == 0 {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.events = append(d.events, ev)
+}
+
+// flush sends the buffered status changes to the subscribers.
+func (d *diagnostics) flush() {
+	d.lock.Lock()
+	events := d.events
+	d.events = nil
+	d.lock.Unlock()
+
+	for _, ev := range events {
+		d.feed.Send(ev)
+	}
+}
+
+// Dropped returns why the transaction with [hash] was recently dropped or
+// rejected by the pool, if it was.
+func (pool *LegacyPool) Dropped(hash common.Hash) (txpool.DroppedTx, bool) {
+	return pool.diagnostics.dropped.Get(hash)
+}
+
+// SubscribeTxStatusEvents subscribes to the status changes of transactions in
+// the pool.
+func (pool *LegacyPool) SubscribeTxStatusEvents(ch chan<- txpool.TxStatusEvent) event.Subscription {
+	return pool.diagnostics.scope.Track(pool.diagnostics.feed.Subscribe(ch))
+}
@@ -0,0 +1,100 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package legacypool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests that the pool reports the status changes of transactions, and why they
+// were dropped.
+func TestTxStatusDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	events := make(chan txpool.TxStatusEvent, 32)
+	sub := pool.SubscribeTxStatusEvents(events)
+	defer sub.Unsubscribe()
+
+	expect := func(hash common.Hash, status txpool.TxStatus, reason string) {
+		t.Helper()
+		select {
+		case ev := <-events:
+			if ev.Hash != hash || ev.Status != status || ev.Reason != reason {
+				t.Fatalf("status event mismatch: have %x %v %q, want %x %v %q", ev.Hash, ev.Status, ev.Reason, hash, status, reason)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("status event of %x not fired", hash)
+		}
+	}
+
+	// A gapped transaction is queued, and promoted once the gap is filled
+	tx0, tx1 := pricedTransaction(0, 100000, big.NewInt(1), key), pricedTransaction(1, 100000, big.NewInt(1), key)
+	if err := pool.addRemoteSync(tx1); err != nil {
+		t.Fatalf("failed to add gapped transaction: %v", err)
+	}
+	expect(tx1.Hash(), txpool.TxStatusQueued, "")
+
+	if err := pool.addRemoteSync(tx0); err != nil {
+		t.Fatalf("failed to add gap filling transaction: %v", err)
+	}
+	expect(tx0.Hash(), txpool.TxStatusQueued, "")
+	expect(tx0.Hash(), txpool.TxStatusPending, "")
+	expect(tx1.Hash(), txpool.TxStatusPending, "")
+
+	// Rejected and replaced transactions are remembered as dropped
+	underpriced := pricedTransaction(0, 100001, big.NewInt(1), key)
+	if err := pool.addRemoteSync(underpriced); err != txpool.ErrReplaceUnderpriced {
+		t.Fatalf("underpriced replacement error mismatch: have %v, want %v", err, txpool.ErrReplaceUnderpriced)
+	}
+	expect(underpriced.Hash(), txpool.TxStatusUnknown, txpool.ErrReplaceUnderpriced.Error())
+
+	replacement := pricedTransaction(0, 100000, big.NewInt(2), key)
+	if err := pool.addRemoteSync(replacement); err != nil {
+		t.Fatalf("failed to replace pending transaction: %v", err)
+	}
+	expect(tx0.Hash(), txpool.TxStatusUnknown, reasonReplaced)
+	expect(replacement.Hash(), txpool.TxStatusPending, "")
+
+	if dropped, ok := pool.Dropped(tx0.Hash()); !ok || dropped.Reason != reasonReplaced {
+		t.Fatalf("replaced transaction drop mismatch: have %v %v, want %q", dropped, ok, reasonReplaced)
+	}
+	if _, ok := pool.Dropped(replacement.Hash()); ok {
+		t.Fatalf("pending transaction reported as dropped")
+	}
+
+	// Raising the minimum tip drops the cheap remote transactions
+	pool.SetGasTip(big.NewInt(2))
+	if dropped, ok := pool.Dropped(tx1.Hash()); !ok || dropped.Reason != reasonBelowGasTip {
+		t.Fatalf("underpriced transaction drop mismatch: have %v %v, want %q", dropped, ok, reasonBelowGasTip)
+	}
+	if pool.Status(replacement.Hash()) != txpool.TxStatusPending {
+		t.Fatalf("well priced transaction dropped")
+	}
+	expect(tx1.Hash(), txpool.TxStatusUnknown, reasonBelowGasTip)
+
+	// Transactions leaving the pool once included in a block are not dropped
+	testSetNonce(pool, crypto.PubkeyToAddress(key.PublicKey), 1)
+	<-pool.requestReset(nil, nil)
+	if pool.Status(replacement.Hash()) != txpool.TxStatusUnknown {
+		t.Fatalf("included transaction still in the pool")
+	}
+	if dropped, ok := pool.Dropped(replacement.Hash()); ok {
+		t.Fatalf("included transaction reported as dropped: %v", dropped)
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected status event: %x %v %q", ev.Hash, ev.Status, ev.Reason)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -132,6 +132,10 @@ type BlockChain interface {
 	// StateAt returns a state database for a given root hash (generally the head).
 	StateAt(root common.Hash) (*state.StateDB, error)
 
+	// GetFeeConfigAt returns the fee configuration stored by the fee manager
+	// precompile in the state of [parent], used to estimate the base fee.
+	GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error)
+
 	SenderCacher() *core.TxSenderCacher
 }
 
@@ -257,6 +261,8 @@ type LegacyPool struct {
 	initDoneCh      chan struct{}  // is closed once the pool is initialized (for tests)
 
 	changesSinceReorg int // A counter for how many drops we've performed in-between reorg.
+
+	diagnostics *diagnostics // Why transactions left the pool, and their status changes
 }
 
 type txpoolResetRequest struct {
@@ -286,6 +292,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		reorgShutdownCh:     make(chan struct{}),
 		initDoneCh:          make(chan struct{}),
 		generalShutdownChan: make(chan struct{}),
+		diagnostics:         newDiagnostics(),
 	}
 	pool.locals = newAccountSet(pool.signer)
 	for _, addr := range config.Locals {
@@ -409,11 +416,13 @@ func (pool *LegacyPool) loop() {
 					list := pool.queue[addr].Flatten()
 					for _, tx := range list {
 						pool.removeTx(tx.Hash(), true, true)
+						pool.diagnostics.drop(tx.Hash(), reasonLifetime)
 					}
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
 			pool.mu.Unlock()
+			pool.diagnostics.flush()
 
 		// Handle local transaction journal rotation
 		case <-journal.C:
@@ -463,6 +472,7 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
+	defer pool.diagnostics.flush()
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
@@ -477,6 +487,7 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 		drop := pool.all.RemotesBelowTip(tip)
 		for _, tx := range drop {
 			pool.removeTx(tx.Hash(), false, true)
+			pool.diagnostics.drop(tx.Hash(), reasonBelowGasTip)
 		}
 		pool.priced.Removed(len(drop))
 	}
@@ -835,6 +846,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 
 			sender, _ := types.Sender(pool.signer, tx)
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
+			pool.diagnostics.drop(tx.Hash(), reasonEvictedUnderpriced)
 
 			pool.changesSinceReorg += dropped
 		}
@@ -853,11 +865,13 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.diagnostics.drop(old.Hash(), reasonReplaced)
 		}
 		pool.all.Add(tx, isLocal)
 		pool.priced.Put(tx, isLocal)
 		pool.journalTx(from, tx)
 		pool.queueTxEvent(tx)
+		pool.diagnostics.pending(hash)
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
 		// Successful promotion, bump the heartbeat
@@ -869,6 +883,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 	if err != nil {
 		return false, err
 	}
+	pool.diagnostics.queued(hash, "")
 	// Mark local addresses and journal local transactions
 	if local && !pool.locals.contains(from) {
 		log.Info("Setting new local account", "address", from)
@@ -928,6 +943,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		queuedReplaceMeter.Mark(1)
+		pool.diagnostics.drop(old.Hash(), reasonReplaced)
 	} else {
 		// Nothing was replaced, bump the queued counter
 		queuedGauge.Inc(1)
@@ -977,6 +993,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.diagnostics.drop(hash, reasonReplaceUnderpriced)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -984,12 +1001,14 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.diagnostics.drop(old.Hash(), reasonReplaced)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
 	}
 	// Set the potentially new pending nonce and notify any subsystems of the new tx
 	pool.pendingNonces.set(addr, tx.Nonce()+1)
+	pool.diagnostics.pending(hash)
 
 	// Successful promotion, bump the heartbeat
 	pool.beats[addr] = time.Now()
@@ -1064,12 +1083,14 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 			errs[i] = err
 			log.Trace("Discarding invalid transaction", "hash", tx.Hash(), "err", err)
 			invalidTxMeter.Mark(1)
+			pool.diagnostics.drop(tx.Hash(), err.Error())
 			continue
 		}
 		// Accumulate all unknown transactions for deeper processing
 		news = append(news, tx)
 	}
 	if len(news) == 0 {
+		pool.diagnostics.flush()
 		return errs
 	}
 
@@ -1077,6 +1098,7 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 	pool.mu.Lock()
 	newErrs, dirtyAddrs := pool.addTxsLocked(news, local)
 	pool.mu.Unlock()
+	pool.diagnostics.flush()
 
 	var nilSlot = 0
 	for _, err := range newErrs {
@@ -1102,6 +1124,9 @@ func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction, local bool) ([]er
 	for i, tx := range txs {
 		replaced, err := pool.add(tx, local)
 		errs[i] = err
+		if err != nil && !errors.Is(err, txpool.ErrAlreadyKnown) {
+			pool.diagnostics.drop(tx.Hash(), err.Error())
+		}
 		if err == nil && !replaced {
 			dirty.addTx(tx)
 		}
@@ -1204,6 +1229,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 			for _, tx := range invalids {
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(tx.Hash(), tx, false, false)
+				pool.diagnostics.queued(tx.Hash(), reasonNonceGap)
 			}
 			// Update the account nonce if needed
 			pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1392,6 +1418,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
 	pool.mu.Unlock()
+	pool.diagnostics.flush()
 
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
@@ -1528,7 +1555,9 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		if list == nil {
 			continue // Just in case someone calls with a non existing account
 		}
-		// Drop all transactions that are deemed too old (low nonce)
+		// Drop all transactions that are deemed too old (low nonce). They
+		// were included in a block, or replaced by a transaction that was,
+		// so they are not reported as dropped.
 		forwards := list.Forward(pool.currentState.GetNonce(addr))
 		for _, tx := range forwards {
 			hash := tx.Hash()
@@ -1540,6 +1569,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.diagnostics.drop(hash, reasonUnpayable)
 		}
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
@@ -1562,6 +1592,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 			for _, tx := range caps {
 				hash := tx.Hash()
 				pool.all.Remove(hash)
+				pool.diagnostics.drop(hash, reasonAccountQueue)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
 			queuedRateLimitMeter.Mark(int64(len(caps)))
@@ -1627,6 +1658,7 @@ func (pool *LegacyPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.diagnostics.drop(hash, reasonPendingLimit)
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1654,6 +1686,7 @@ func (pool *LegacyPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pool.diagnostics.drop(hash, reasonPendingLimit)
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1701,6 +1734,7 @@ func (pool *LegacyPool) truncateQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true, true)
+				pool.diagnostics.drop(tx.Hash(), reasonQueueLimit)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1710,6 +1744,7 @@ func (pool *LegacyPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true, true)
+			pool.diagnostics.drop(txs[i].Hash(), reasonQueueLimit)
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1732,7 +1767,8 @@ func (pool *LegacyPool) demoteUnexecutables() {
 	for addr, list := range pool.pending {
 		nonce := pool.currentState.GetNonce(addr)
 
-		// Drop all transactions that are deemed too old (low nonce)
+		// Drop all transactions that are deemed too old (low nonce). They
+		// are not reported as dropped, see promoteExecutables.
 		olds := list.Forward(nonce)
 		for _, tx := range olds {
 			hash := tx.Hash()
@@ -1745,6 +1781,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
+			pool.diagnostics.drop(hash, reasonUnpayable)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
@@ -1754,6 +1791,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 
 			// Internal shuffle shouldn't touch the lookup set.
 			pool.enqueueTx(hash, tx, false, false)
+			pool.diagnostics.queued(hash, reasonNonceGap)
 		}
 		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
 		if pool.locals.contains(addr) {
@@ -1768,6 +1806,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(hash, tx, false, false)
+				pool.diagnostics.queued(hash, reasonNonceGap)
 			}
 			pendingGauge.Dec(int64(len(gapped)))
 		}
@@ -1830,7 +1869,7 @@ func (pool *LegacyPool) updateBaseFee() {
 
 // assumes lock is already held
 func (pool *LegacyPool) updateBaseFeeAt(head *types.Header) error {
-	_, baseFeeEstimate, err := dummy.EstimateNextBaseFee(pool.chainconfig, head, uint64(time.Now().Unix()))
+	_, baseFeeEstimate, err := dummy.EstimateNextBaseFee(pool.chainconfig, pool.chain, head, uint64(time.Now().Unix()))
 	if err != nil {
 		return err
 	}
@@ -45,8 +45,13 @@ import (
 	"github.com/ava-labs/coreth/core/txpool"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
+	"github.com/ava-labs/coreth/precompile/contracts/txallowlist"
 	"github.com/ava-labs/coreth/trie"
 	"github.com/ava-labs/coreth/utils"
+	"github.com/ava-labs/coreth/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
@@ -108,6 +113,13 @@ func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
 	return bc.statedb, nil
 }
 
+func (bc *testBlockChain) GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	return feemanager.GetStoredFeeConfig(bc.statedb), nil
+}
+
 func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
 	bc.lock.Lock()
 	defer bc.lock.Unlock()
@@ -378,6 +390,40 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that transactions from senders outside of the transaction allow list,
+// and contract creations from senders outside of the contract deployer allow
+// list, are rejected once the allow lists are enabled.
+func TestAllowListedTransactions(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.UpgradeConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: deployerallowlist.NewConfig(utils.NewUint64(0), nil, nil, nil)},
+		{Config: txallowlist.NewConfig(utils.NewUint64(0), nil, nil, nil)},
+	}
+	pool, key := setupPoolWithConfig(&config)
+	defer pool.Close()
+
+	tx := transaction(0, 100000, key)
+	from, _ := deriveSender(tx)
+	testAddBalance(pool, from, big.NewInt(0xffffffffffffff))
+
+	if err, want := pool.addRemote(tx), vmerrs.ErrSenderNotAllowListed; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	pool.mu.Lock()
+	txallowlist.SetTxAllowListStatus(pool.currentState, from, allowlist.EnabledRole)
+	pool.mu.Unlock()
+
+	create, _ := types.SignTx(types.NewContractCreation(0, common.Big0, 100000, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+	if err, want := pool.addRemote(create), vmerrs.ErrDeployerNotAllowListed; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	if err := pool.addRemote(tx); err != nil {
+		t.Error("expected", nil, "got", err)
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,45 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package privatepool
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Config are the configuration parameters of the private pool.
+type Config struct {
+	MaxTxs      int    // Maximum number of private transactions held by the pool
+	Lifetime    uint64 // Number of blocks a private transaction is held for if no expiry is given
+	MaxLifetime uint64 // Maximum number of blocks a private transaction may be held for
+}
+
+// DefaultConfig contains the default configurations for the private pool.
+var DefaultConfig = Config{
+	MaxTxs:      1024,
+	Lifetime:    64,
+	MaxLifetime: 4096,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.MaxTxs < 1 {
+		log.Warn("Sanitizing invalid privatepool max txs", "provided", conf.MaxTxs, "updated", DefaultConfig.MaxTxs)
+		conf.MaxTxs = DefaultConfig.MaxTxs
+	}
+	if conf.MaxLifetime < 1 {
+		log.Warn("Sanitizing invalid privatepool max lifetime", "provided", conf.MaxLifetime, "updated", DefaultConfig.MaxLifetime)
+		conf.MaxLifetime = DefaultConfig.MaxLifetime
+	}
+	if conf.Lifetime < 1 {
+		log.Warn("Sanitizing invalid privatepool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
+		conf.Lifetime = DefaultConfig.Lifetime
+	}
+	if conf.Lifetime > conf.MaxLifetime {
+		log.Warn("Sanitizing privatepool lifetime above max lifetime", "provided", conf.Lifetime, "updated", conf.MaxLifetime)
+		conf.Lifetime = conf.MaxLifetime
+	}
+	return conf
+}
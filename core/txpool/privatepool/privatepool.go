@@ -0,0 +1,502 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package privatepool implements a transaction pool for transactions that are
+// held only by this node, without being gossiped, for inclusion in the blocks
+// it builds.
+package privatepool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/metrics"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+)
+
+// txMaxSize is the maximum size a single private transaction can have, same
+// as in the legacy pool.
+const txMaxSize = 128 * 1024
+
+var (
+	// ErrExpiryPassed is returned if the maximum block number of a private
+	// transaction is not above the current head.
+	ErrExpiryPassed = errors.New("private transaction expiry already passed")
+
+	// ErrExpiryTooFar is returned if the maximum block number of a private
+	// transaction is further ahead of the current head than the pool allows.
+	ErrExpiryTooFar = errors.New("private transaction expiry too far ahead")
+
+	// ErrFallbackPassed is returned if the fallback block number of a private
+	// transaction is not above the current head.
+	ErrFallbackPassed = errors.New("private transaction fallback already passed")
+
+	// ErrPrivatePoolFull is returned if the pool already holds the maximum
+	// number of private transactions.
+	ErrPrivatePoolFull = errors.New("private pool is full")
+
+	// ErrPrivateOnly is returned if transactions are added to the pool without
+	// being submitted privately.
+	ErrPrivateOnly = errors.New("transactions must be submitted privately")
+)
+
+var (
+	privateTxsGauge = metrics.NewRegisteredGauge("privatepool/txs", nil)
+
+	includedMeter = metrics.NewRegisteredMeter("privatepool/included", nil)
+	expiredMeter  = metrics.NewRegisteredMeter("privatepool/expired", nil)
+	fallbackMeter = metrics.NewRegisteredMeter("privatepool/fallback", nil)
+)
+
+var _ txpool.SubPool = (*PrivatePool)(nil)
+
+// BlockChain defines the minimal set of methods needed to back a private pool
+// with a chain. Exists to allow mocking the live chain out of tests.
+type BlockChain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// StateAt returns a state database for a given root hash (generally the head).
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// PrivateTx is a transaction held only by this node for inclusion in the
+// blocks it builds, until it expires or falls back to public gossip.
+type PrivateTx struct {
+	Tx                  *types.Transaction
+	MaxBlockNumber      uint64 // Last block the transaction may be included in, 0 for the pool's default lifetime
+	FallbackBlockNumber uint64 // Block after which the transaction is gossiped publicly, 0 if never
+
+	from common.Address
+	time time.Time
+}
+
+// PrivatePool is a subpool holding transactions submitted privately to this
+// node. Its transactions are only included in the blocks built by this node,
+// so the pool never reports them as pending to the gossip protocols.
+type PrivatePool struct {
+	config Config
+	chain  BlockChain
+	signer types.Signer
+
+	mu       sync.RWMutex
+	head     *types.Header
+	state    *state.StateDB
+	gasTip   *uint256.Int
+	reserve  txpool.AddressReserver
+	all      map[common.Hash]*PrivateTx
+	bySender map[common.Address]map[uint64]*PrivateTx
+
+	txFeed       event.Feed
+	fallbackFeed event.Feed
+}
+
+// New creates a new private pool to hold privately submitted transactions
+// until they are included, expire or fall back to public gossip.
+func New(config Config, chain BlockChain) *PrivatePool {
+	return &PrivatePool{
+		config:   config.sanitize(),
+		chain:    chain,
+		signer:   types.LatestSigner(chain.Config()),
+		all:      make(map[common.Hash]*PrivateTx),
+		bySender: make(map[common.Address]map[uint64]*PrivateTx),
+	}
+}
+
+// Filter returns false for every transaction, since transactions can only be
+// added to the pool privately through AddPrivate.
+func (p *PrivatePool) Filter(tx *types.Transaction) bool {
+	return false
+}
+
+// Init sets the gas tip and head of the pool. Private transactions are not
+// persisted, so there is nothing to load.
+func (p *PrivatePool) Init(gasTip uint64, head *types.Header, reserve txpool.AddressReserver) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.gasTip = uint256.NewInt(gasTip)
+	p.reserve = reserve
+	return p.setHead(head)
+}
+
+// setHead sets the head and the state transactions are validated against.
+func (p *PrivatePool) setHead(head *types.Header) error {
+	statedb, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		statedb, err = p.chain.StateAt(types.EmptyRootHash)
+	}
+	if err != nil {
+		return err
+	}
+	p.head = head
+	p.state = statedb
+	return nil
+}
+
+// Close drops all held transactions and releases their senders.
+func (p *PrivatePool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for addr := range p.bySender {
+		p.reserve(addr, false)
+	}
+	p.all = make(map[common.Hash]*PrivateTx)
+	p.bySender = make(map[common.Address]map[uint64]*PrivateTx)
+	privateTxsGauge.Update(0)
+	return nil
+}
+
+// Reset drops the transactions which were included or expired on top of
+// [newHead], and releases the ones whose fallback block was reached to the
+// subscribers of SubscribeFallbacks.
+func (p *PrivatePool) Reset(oldHead, newHead *types.Header) {
+	var fallbacks types.Transactions
+	defer func() {
+		// Release the transactions outside the lock, since they are added to
+		// the other subpools.
+		if len(fallbacks) > 0 {
+			p.fallbackFeed.Send(core.NewTxsEvent{Txs: fallbacks})
+		}
+	}()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.setHead(newHead); err != nil {
+		log.Error("Failed to reset privatepool state", "err", err)
+		return
+	}
+	number := newHead.Number.Uint64()
+	for hash, ptx := range p.all {
+		switch {
+		case p.state.GetNonce(ptx.from) > ptx.Tx.Nonce():
+			includedMeter.Mark(1)
+		case ptx.FallbackBlockNumber != 0 && ptx.FallbackBlockNumber <= number:
+			fallbacks = append(fallbacks, ptx.Tx)
+			fallbackMeter.Mark(1)
+		case ptx.MaxBlockNumber <= number:
+			expiredMeter.Mark(1)
+		default:
+			continue
+		}
+		p.remove(hash)
+	}
+	privateTxsGauge.Update(int64(len(p.all)))
+}
+
+// remove removes the transaction with [hash] from the pool, releasing its
+// sender to the other subpools if it was the last one held for it.
+func (p *PrivatePool) remove(hash common.Hash) {
+	ptx := p.all[hash]
+	if ptx == nil {
+		return
+	}
+	delete(p.all, hash)
+	delete(p.bySender[ptx.from], ptx.Tx.Nonce())
+	if len(p.bySender[ptx.from]) == 0 {
+		delete(p.bySender, ptx.from)
+		p.reserve(ptx.from, false)
+	}
+}
+
+// SetGasTip updates the minimum gas tip required for new private
+// transactions. Held transactions are not affected.
+func (p *PrivatePool) SetGasTip(tip *big.Int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.gasTip = uint256.MustFromBig(tip)
+}
+
+// SetMinFee is a no-op, since private transactions are only checked against
+// the base fee of the block they are included in.
+func (p *PrivatePool) SetMinFee(fee *big.Int) {}
+
+// Has returns whether the pool holds the transaction with [hash].
+func (p *PrivatePool) Has(hash common.Hash) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.all[hash] != nil
+}
+
+// HasLocal returns whether the pool holds the transaction with [hash], since
+// private transactions are always submitted locally.
+func (p *PrivatePool) HasLocal(hash common.Hash) bool {
+	return p.Has(hash)
+}
+
+// Get returns nil, since private transactions must not be exposed through
+// the pool before they are included.
+func (p *PrivatePool) Get(hash common.Hash) *types.Transaction {
+	return nil
+}
+
+// Add rejects all transactions, since transactions can only be added to the
+// pool privately through AddPrivate.
+func (p *PrivatePool) Add(txs []*types.Transaction, local bool, sync bool) []error {
+	errs := make([]error, len(txs))
+	for i := range txs {
+		errs[i] = ErrPrivateOnly
+	}
+	return errs
+}
+
+// AddPrivate validates [ptx] against the current head and adds it to the pool,
+// replacing any private transaction of the same sender with the same nonce.
+func (p *PrivatePool) AddPrivate(ptx *PrivateTx) error {
+	if err := p.addPrivate(ptx); err != nil {
+		return err
+	}
+	p.txFeed.Send(core.NewTxsEvent{Txs: types.Transactions{ptx.Tx}})
+	return nil
+}
+
+func (p *PrivatePool) addPrivate(ptx *PrivateTx) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	head := p.head.Number.Uint64()
+	if ptx.MaxBlockNumber == 0 {
+		ptx.MaxBlockNumber = head + p.config.Lifetime
+	}
+	switch {
+	case ptx.MaxBlockNumber <= head:
+		return fmt.Errorf("%w: max block %d, head %d", ErrExpiryPassed, ptx.MaxBlockNumber, head)
+	case ptx.MaxBlockNumber-head > p.config.MaxLifetime:
+		return fmt.Errorf("%w: max block %d, head %d, limit %d blocks", ErrExpiryTooFar, ptx.MaxBlockNumber, head, p.config.MaxLifetime)
+	case ptx.FallbackBlockNumber != 0 && ptx.FallbackBlockNumber <= head:
+		return fmt.Errorf("%w: fallback block %d, head %d", ErrFallbackPassed, ptx.FallbackBlockNumber, head)
+	}
+	hash := ptx.Tx.Hash()
+	if _, ok := p.all[hash]; ok {
+		return txpool.ErrAlreadyKnown
+	}
+	opts := &txpool.ValidationOptions{
+		Config: p.chain.Config(),
+		Accept: 0 |
+			1<<types.LegacyTxType |
+			1<<types.AccessListTxType |
+			1<<types.DynamicFeeTxType,
+		MaxSize: txMaxSize,
+		MinTip:  p.gasTip.ToBig(),
+	}
+	if err := txpool.ValidateTransaction(ptx.Tx, p.head, p.signer, opts); err != nil {
+		return err
+	}
+	from, _ := types.Sender(p.signer, ptx.Tx) // already validated
+	replaced := p.bySender[from][ptx.Tx.Nonce()]
+	if replaced == nil && len(p.all) >= p.config.MaxTxs {
+		return ErrPrivatePoolFull
+	}
+	stateOpts := &txpool.ValidationOptionsWithState{
+		State: p.state,
+		UsedAndLeftSlots: func(addr common.Address) (int, int) {
+			return len(p.bySender[addr]), p.config.MaxTxs - len(p.all)
+		},
+		ExistingExpenditure: func(addr common.Address) *big.Int {
+			spent := new(big.Int)
+			for _, held := range p.bySender[addr] {
+				spent.Add(spent, held.Tx.Cost())
+			}
+			return spent
+		},
+		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
+			if held := p.bySender[addr][nonce]; held != nil {
+				return held.Tx.Cost()
+			}
+			return nil
+		},
+		Rules: p.chain.Config().Rules(p.head.Number, p.head.Time),
+	}
+	if err := txpool.ValidateTransactionWithState(ptx.Tx, p.signer, stateOpts); err != nil {
+		return err
+	}
+
+	if replaced != nil {
+		delete(p.all, replaced.Tx.Hash())
+	} else if len(p.bySender[from]) == 0 {
+		// Claim the sender from the other subpools, so its nonces are not used
+		// by public transactions while private ones are held.
+		if err := p.reserve(from, true); err != nil {
+			return err
+		}
+	}
+	ptx.from = from
+	ptx.time = time.Now()
+	p.all[hash] = ptx
+	if p.bySender[from] == nil {
+		p.bySender[from] = make(map[uint64]*PrivateTx)
+	}
+	p.bySender[from][ptx.Tx.Nonce()] = ptx
+	privateTxsGauge.Update(int64(len(p.all)))
+	return nil
+}
+
+// PrivatePending returns the held transactions which are executable on top of
+// the current head, grouped by sender and sorted by nonce. Like the legacy
+// pool, the list of each sender is capped at the first transaction paying less
+// than the minimum tip of [filter] on top of its base fee, or the base fee of
+// the head if not set.
+func (p *PrivatePool) PrivatePending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	pending := make(map[common.Address][]*txpool.LazyTransaction, len(p.bySender))
+	for addr, txs := range p.pending(filter) {
+		lazies := make([]*txpool.LazyTransaction, len(txs))
+		for i, ptx := range txs {
+			lazies[i] = &txpool.LazyTransaction{
+				Pool:      p,
+				Hash:      ptx.Tx.Hash(),
+				Tx:        ptx.Tx,
+				Time:      ptx.time,
+				GasFeeCap: uint256.MustFromBig(ptx.Tx.GasFeeCap()),
+				GasTipCap: uint256.MustFromBig(ptx.Tx.GasTipCap()),
+				Gas:       ptx.Tx.Gas(),
+			}
+		}
+		pending[addr] = lazies
+	}
+	return pending
+}
+
+// PendingSize returns the number of held transactions PrivatePending would
+// return for [filter].
+func (p *PrivatePool) PendingSize(filter txpool.PendingFilter) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	size := 0
+	for _, txs := range p.pending(filter) {
+		size += len(txs)
+	}
+	return size
+}
+
+// pending returns the executable transactions of each sender passing
+// [filter]. It assumes the lock is held.
+func (p *PrivatePool) pending(filter txpool.PendingFilter) map[common.Address][]*PrivateTx {
+	var minTip, baseFee *big.Int
+	if filter.MinTip != nil {
+		minTip = filter.MinTip.ToBig()
+	}
+	if filter.BaseFee != nil {
+		baseFee = filter.BaseFee.ToBig()
+	} else {
+		baseFee = p.head.BaseFee
+	}
+
+	pending := make(map[common.Address][]*PrivateTx, len(p.bySender))
+	for addr, held := range p.bySender {
+		var txs []*PrivateTx
+		for nonce := p.state.GetNonce(addr); ; nonce++ {
+			ptx := held[nonce]
+			if ptx == nil {
+				break
+			}
+			if baseFee != nil && ptx.Tx.GasFeeCapIntCmp(baseFee) < 0 {
+				break
+			}
+			if minTip != nil && ptx.Tx.EffectiveGasTipIntCmp(minTip, baseFee) < 0 {
+				break
+			}
+			txs = append(txs, ptx)
+		}
+		if len(txs) > 0 {
+			pending[addr] = txs
+		}
+	}
+	return pending
+}
+
+// Len returns the number of held transactions.
+func (p *PrivatePool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return len(p.all)
+}
+
+// SubscribePrivateTxs subscribes to the transactions added to the pool, so
+// that block building can be triggered without announcing them to peers.
+func (p *PrivatePool) SubscribePrivateTxs(ch chan<- core.NewTxsEvent) event.Subscription {
+	return p.txFeed.Subscribe(ch)
+}
+
+// SubscribeFallbacks subscribes to the private transactions released to
+// public gossip once their fallback block is reached. They are removed from
+// the pool before being sent.
+func (p *PrivatePool) SubscribeFallbacks(ch chan<- core.NewTxsEvent) event.Subscription {
+	return p.fallbackFeed.Subscribe(ch)
+}
+
+// Pending returns no transactions, since private transactions are only
+// retrieved through PrivatePending by the miner and must not be gossiped.
+func (p *PrivatePool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	return nil
+}
+
+// IteratePending does not iterate any transactions, since private
+// transactions must not be gossiped.
+func (p *PrivatePool) IteratePending(f func(tx *types.Transaction) bool) bool {
+	return true
+}
+
+// SubscribeTransactions returns nil, since private transactions are not
+// announced to peers.
+func (p *PrivatePool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription {
+	return nil
+}
+
+// Nonce returns 0, since private transactions do not advance the pending
+// nonce of their sender.
+func (p *PrivatePool) Nonce(addr common.Address) uint64 {
+	return 0
+}
+
+// Stats returns no pending or queued transactions, since private transactions
+// are not exposed through the pool content.
+func (p *PrivatePool) Stats() (int, int) {
+	return 0, 0
+}
+
+// Content returns no transactions, since private transactions are not
+// exposed through the pool content.
+func (p *PrivatePool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	return make(map[common.Address][]*types.Transaction), make(map[common.Address][]*types.Transaction)
+}
+
+// ContentFrom returns no transactions, since private transactions are not
+// exposed through the pool content.
+func (p *PrivatePool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	return []*types.Transaction{}, []*types.Transaction{}
+}
+
+// Locals returns no accounts, since the senders of private transactions are
+// not prioritized in the public pools.
+func (p *PrivatePool) Locals() []common.Address {
+	return nil
+}
+
+// Status returns pending for held transactions, as they are waiting for a
+// block built by this node.
+func (p *PrivatePool) Status(hash common.Hash) txpool.TxStatus {
+	if p.Has(hash) {
+		return txpool.TxStatusPending
+	}
+	return txpool.TxStatusUnknown
+}
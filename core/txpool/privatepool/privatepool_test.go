@@ -0,0 +1,338 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package privatepool
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+type testBlockChain struct {
+	config  *params.ChainConfig
+	statedb *state.StateDB
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig { return bc.config }
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+// testReserver tracks the addresses reserved by the pool, failing to reserve
+// the ones held by another subpool.
+type testReserver struct {
+	reserved map[common.Address]bool
+	others   map[common.Address]bool
+}
+
+func (r *testReserver) reserve(addr common.Address, reserve bool) error {
+	switch {
+	case reserve && (r.reserved[addr] || r.others[addr]):
+		return txpool.ErrAlreadyReserved
+	case !reserve && !r.reserved[addr]:
+		return errors.New("address not reserved")
+	}
+	r.reserved[addr] = reserve
+	return nil
+}
+
+func newTestPool(t *testing.T, config Config, head *types.Header) (*PrivatePool, *state.StateDB) {
+	pool, statedb, _ := newTestPoolWithReserver(t, config, head)
+	return pool, statedb
+}
+
+func newTestPoolWithReserver(t *testing.T, config Config, head *types.Header) (*PrivatePool, *state.StateDB, *testReserver) {
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	pool := New(config, &testBlockChain{config: params.TestChainConfig, statedb: statedb})
+	reserver := &testReserver{
+		reserved: make(map[common.Address]bool),
+		others:   make(map[common.Address]bool),
+	}
+	if err := pool.Init(0, head, reserver.reserve); err != nil {
+		t.Fatalf("failed to init pool: %v", err)
+	}
+	return pool, statedb, reserver
+}
+
+func newTestHeader(number uint64) *types.Header {
+	return &types.Header{
+		Number:   new(big.Int).SetUint64(number),
+		GasLimit: params.CortinaGasLimit,
+		BaseFee:  big.NewInt(params.ApricotPhase3InitialBaseFee),
+	}
+}
+
+func newTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, feeCap int64) *types.Transaction {
+	tx, err := types.SignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(feeCap),
+		Gas:       params.TxGas,
+		To:        &common.Address{},
+		Value:     big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatalf("failed to sign tx: %v", err)
+	}
+	return tx
+}
+
+func TestAddPrivate(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	poor, _ := crypto.GenerateKey()
+
+	tests := map[string]struct {
+		ptx func() *PrivateTx
+		err error
+	}{
+		"valid": {
+			ptx: func() *PrivateTx {
+				return &PrivateTx{Tx: newTestTx(t, key, 0, params.ApricotPhase3InitialBaseFee)}
+			},
+		},
+		"expiry passed": {
+			ptx: func() *PrivateTx {
+				return &PrivateTx{Tx: newTestTx(t, key, 0, params.ApricotPhase3InitialBaseFee), MaxBlockNumber: 10}
+			},
+			err: ErrExpiryPassed,
+		},
+		"expiry too far": {
+			ptx: func() *PrivateTx {
+				return &PrivateTx{Tx: newTestTx(t, key, 0, params.ApricotPhase3InitialBaseFee), MaxBlockNumber: 11 + DefaultConfig.MaxLifetime}
+			},
+			err: ErrExpiryTooFar,
+		},
+		"fallback passed": {
+			ptx: func() *PrivateTx {
+				return &PrivateTx{Tx: newTestTx(t, key, 0, params.ApricotPhase3InitialBaseFee), FallbackBlockNumber: 10}
+			},
+			err: ErrFallbackPassed,
+		},
+		"nonce too low": {
+			ptx: func() *PrivateTx {
+				return &PrivateTx{Tx: newTestTx(t, key, 1, params.ApricotPhase3InitialBaseFee)}
+			},
+			err: core.ErrNonceTooLow,
+		},
+		"insufficient funds": {
+			ptx: func() *PrivateTx {
+				return &PrivateTx{Tx: newTestTx(t, poor, 0, params.ApricotPhase3InitialBaseFee)}
+			},
+			err: core.ErrInsufficientFunds,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pool, statedb := newTestPool(t, DefaultConfig, newTestHeader(10))
+			statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(params.Ether))
+			if name == "nonce too low" {
+				statedb.SetNonce(crypto.PubkeyToAddress(key.PublicKey), 2)
+			}
+			ptx := test.ptx()
+			if err := pool.AddPrivate(ptx); !errors.Is(err, test.err) {
+				t.Fatalf("unexpected error: have %v, want %v", err, test.err)
+			}
+			if test.err != nil {
+				return
+			}
+			if !pool.Has(ptx.Tx.Hash()) {
+				t.Fatal("private tx not held")
+			}
+			if ptx.MaxBlockNumber != 10+DefaultConfig.Lifetime {
+				t.Fatalf("unexpected default max block: have %d, want %d", ptx.MaxBlockNumber, 10+DefaultConfig.Lifetime)
+			}
+			if err := pool.AddPrivate(ptx); !errors.Is(err, txpool.ErrAlreadyKnown) {
+				t.Fatalf("unexpected error adding known tx: %v", err)
+			}
+		})
+	}
+}
+
+func TestPrivatePoolNotGossiped(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	pool, statedb := newTestPool(t, DefaultConfig, newTestHeader(10))
+	statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(params.Ether))
+
+	tx := newTestTx(t, key, 0, params.ApricotPhase3InitialBaseFee)
+	if errs := pool.Add([]*types.Transaction{tx}, true, false); !errors.Is(errs[0], ErrPrivateOnly) {
+		t.Fatalf("unexpected error adding tx publicly: %v", errs[0])
+	}
+	if err := pool.AddPrivate(&PrivateTx{Tx: tx}); err != nil {
+		t.Fatalf("failed to add private tx: %v", err)
+	}
+	if pending := pool.Pending(txpool.PendingFilter{}); len(pending) != 0 {
+		t.Fatalf("private tx reported pending: %v", pending)
+	}
+	pool.IteratePending(func(*types.Transaction) bool {
+		t.Fatal("private tx iterated")
+		return false
+	})
+	if pending, queued := pool.Content(); len(pending) != 0 || len(queued) != 0 {
+		t.Fatal("private tx reported in content")
+	}
+	if status := pool.Status(tx.Hash()); status != txpool.TxStatusPending {
+		t.Fatalf("unexpected status: %v", status)
+	}
+	if pool.Get(tx.Hash()) != nil {
+		t.Fatal("private tx exposed through Get")
+	}
+}
+
+func TestPrivatePoolReservesSenders(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	pool, statedb, reserver := newTestPoolWithReserver(t, DefaultConfig, newTestHeader(10))
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	otherAddr := crypto.PubkeyToAddress(other.PublicKey)
+	statedb.AddBalance(addr, uint256.NewInt(params.Ether))
+	statedb.AddBalance(otherAddr, uint256.NewInt(params.Ether))
+
+	// A sender held by another subpool cannot submit private txs.
+	reserver.others[otherAddr] = true
+	if err := pool.AddPrivate(&PrivateTx{Tx: newTestTx(t, other, 0, params.ApricotPhase3InitialBaseFee)}); !errors.Is(err, txpool.ErrAlreadyReserved) {
+		t.Fatalf("unexpected error adding tx of reserved sender: %v", err)
+	}
+
+	// The sender stays reserved until its last private tx is removed.
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if err := pool.AddPrivate(&PrivateTx{Tx: newTestTx(t, key, nonce, params.ApricotPhase3InitialBaseFee)}); err != nil {
+			t.Fatalf("failed to add private tx: %v", err)
+		}
+	}
+	if err := pool.AddPrivate(&PrivateTx{Tx: newTestTx(t, key, 1, 2*params.ApricotPhase3InitialBaseFee)}); err != nil {
+		t.Fatalf("failed to replace private tx: %v", err)
+	}
+	if !reserver.reserved[addr] {
+		t.Fatal("sender of private txs not reserved")
+	}
+	statedb.SetNonce(addr, 1)
+	pool.Reset(newTestHeader(10), newTestHeader(11))
+	if !reserver.reserved[addr] {
+		t.Fatal("sender released while private txs are held")
+	}
+	statedb.SetNonce(addr, 2)
+	pool.Reset(newTestHeader(11), newTestHeader(12))
+	if reserver.reserved[addr] {
+		t.Fatal("sender not released after its private txs were included")
+	}
+}
+
+func TestPrivatePending(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	pool, statedb := newTestPool(t, DefaultConfig, newTestHeader(10))
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	statedb.AddBalance(addr, uint256.NewInt(params.Ether))
+
+	txs := []*types.Transaction{
+		newTestTx(t, key, 1, params.ApricotPhase3InitialBaseFee),
+		newTestTx(t, key, 0, params.ApricotPhase3InitialBaseFee),
+		newTestTx(t, key, 2, params.ApricotPhase3InitialBaseFee/2),
+	}
+	for _, tx := range txs {
+		if err := pool.AddPrivate(&PrivateTx{Tx: tx}); err != nil {
+			t.Fatalf("failed to add private tx: %v", err)
+		}
+	}
+	// Transactions are sorted by nonce and filtered by the base fee.
+	filter := txpool.PendingFilter{BaseFee: uint256.NewInt(uint64(params.ApricotPhase3InitialBaseFee))}
+	pending := pool.PrivatePending(filter)
+	if len(pending[addr]) != 2 {
+		t.Fatalf("unexpected pending txs: have %d, want 2", len(pending[addr]))
+	}
+	for i, lazy := range pending[addr] {
+		if lazy.Tx.Nonce() != uint64(i) {
+			t.Fatalf("pending tx %d has nonce %d", i, lazy.Tx.Nonce())
+		}
+	}
+	if size := pool.PendingSize(filter); size != 2 {
+		t.Fatalf("unexpected pending size: have %d, want 2", size)
+	}
+
+	// Transactions paying less than the minimum tip are not pending.
+	filter.MinTip = uint256.NewInt(2)
+	if size := pool.PendingSize(filter); size != 0 {
+		t.Fatalf("unexpected pending size below min tip: have %d, want 0", size)
+	}
+
+	// Transactions are not pending until the nonce gap before them is filled.
+	statedb.SetNonce(addr, 1)
+	pool.Reset(newTestHeader(10), newTestHeader(11))
+	statedb.SetNonce(addr, 0)
+	pool.Reset(newTestHeader(11), newTestHeader(12))
+	if size := pool.PendingSize(txpool.PendingFilter{}); size != 0 {
+		t.Fatalf("unexpected pending size with nonce gap: have %d, want 0", size)
+	}
+
+	// A private tx with the same nonce replaces the held one.
+	replacement := newTestTx(t, key, 1, 2*params.ApricotPhase3InitialBaseFee)
+	if err := pool.AddPrivate(&PrivateTx{Tx: replacement}); err != nil {
+		t.Fatalf("failed to replace private tx: %v", err)
+	}
+	if pool.Has(txs[0].Hash()) || !pool.Has(replacement.Hash()) {
+		t.Fatal("private tx not replaced")
+	}
+}
+
+func TestPrivatePoolReset(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	pool, statedb := newTestPool(t, DefaultConfig, newTestHeader(10))
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	statedb.AddBalance(addr, uint256.NewInt(params.Ether))
+
+	var (
+		included = &PrivateTx{Tx: newTestTx(t, key, 0, params.ApricotPhase3InitialBaseFee)}
+		expiring = &PrivateTx{Tx: newTestTx(t, key, 1, params.ApricotPhase3InitialBaseFee), MaxBlockNumber: 12}
+		fallback = &PrivateTx{Tx: newTestTx(t, key, 2, params.ApricotPhase3InitialBaseFee), FallbackBlockNumber: 12, MaxBlockNumber: 20}
+		held     = &PrivateTx{Tx: newTestTx(t, key, 3, params.ApricotPhase3InitialBaseFee), FallbackBlockNumber: 13}
+	)
+	for _, ptx := range []*PrivateTx{included, expiring, fallback, held} {
+		if err := pool.AddPrivate(ptx); err != nil {
+			t.Fatalf("failed to add private tx: %v", err)
+		}
+	}
+	fallbacks := make(chan core.NewTxsEvent, 1)
+	sub := pool.SubscribeFallbacks(fallbacks)
+	defer sub.Unsubscribe()
+
+	statedb.SetNonce(addr, 1)
+	pool.Reset(newTestHeader(10), newTestHeader(11))
+	if pool.Has(included.Tx.Hash()) {
+		t.Fatal("included private tx still held")
+	}
+	for _, ptx := range []*PrivateTx{expiring, fallback, held} {
+		if !pool.Has(ptx.Tx.Hash()) {
+			t.Fatalf("private tx %d dropped early", ptx.Tx.Nonce())
+		}
+	}
+
+	pool.Reset(newTestHeader(11), newTestHeader(12))
+	if pool.Has(expiring.Tx.Hash()) || pool.Has(fallback.Tx.Hash()) {
+		t.Fatal("expired or fallen back private tx still held")
+	}
+	if !pool.Has(held.Tx.Hash()) {
+		t.Fatal("private tx dropped before its fallback block")
+	}
+	select {
+	case ev := <-fallbacks:
+		if len(ev.Txs) != 1 || ev.Txs[0].Hash() != fallback.Tx.Hash() {
+			t.Fatalf("unexpected fallback txs: %v", ev.Txs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("private tx did not fall back")
+	}
+}
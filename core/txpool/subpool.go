@@ -176,3 +176,29 @@ type SubPool interface {
 	// identified by their hashes.
 	Status(hash common.Hash) TxStatus
 }
+
+// DroppedTx describes why a transaction was dropped from, or rejected by, a
+// subpool.
+type DroppedTx struct {
+	Reason string
+	Time   time.Time
+}
+
+// TxStatusEvent is posted when a transaction enters a subpool, moves between
+// its pending and queued sets, or is dropped from it.
+type TxStatusEvent struct {
+	Hash   common.Hash
+	Status TxStatus // TxStatusUnknown if the transaction was dropped
+	Reason string   // Why the transaction was dropped or demoted, if it was
+}
+
+// StatusReporter is implemented by subpools that report why transactions
+// leave them, so users can tell why their transactions vanished.
+type StatusReporter interface {
+	// Dropped returns why the transaction with [hash] was recently dropped or
+	// rejected, if it was.
+	Dropped(hash common.Hash) (DroppedTx, bool)
+
+	// SubscribeTxStatusEvents subscribes to status changes of transactions.
+	SubscribeTxStatusEvents(ch chan<- TxStatusEvent) event.Subscription
+}
@@ -563,6 +563,31 @@ func (p *TxPool) Status(hash common.Hash) TxStatus {
 	return TxStatusUnknown
 }
 
+// Dropped returns why the transaction with [hash] was recently dropped or
+// rejected by a subpool, if it was and the subpool reports it.
+func (p *TxPool) Dropped(hash common.Hash) (DroppedTx, bool) {
+	for _, subpool := range p.subpools {
+		if reporter, ok := subpool.(StatusReporter); ok {
+			if dropped, ok := reporter.Dropped(hash); ok {
+				return dropped, true
+			}
+		}
+	}
+	return DroppedTx{}, false
+}
+
+// SubscribeTxStatusEvents subscribes to status changes of transactions in the
+// subpools that report them.
+func (p *TxPool) SubscribeTxStatusEvents(ch chan<- TxStatusEvent) event.Subscription {
+	var subs []event.Subscription
+	for _, subpool := range p.subpools {
+		if reporter, ok := subpool.(StatusReporter); ok {
+			subs = append(subs, reporter.SubscribeTxStatusEvents(ch))
+		}
+	}
+	return p.subs.Track(event.JoinSubscriptions(subs...))
+}
+
 // Sync is a helper method for unit tests or simulator runs where the chain events
 // are arriving in quick succession, without any time in between them to run the
 // internal background reset operations. This method will run an explicit reset
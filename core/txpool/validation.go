@@ -35,6 +35,8 @@ import (
 	"github.com/ava-labs/coreth/core/state"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/coreth/precompile/contracts/txallowlist"
 	"github.com/ava-labs/coreth/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -83,6 +85,9 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if !opts.Config.IsCancun(head.Number, head.Time) && tx.Type() == types.BlobTxType {
 		return fmt.Errorf("%w: type %d rejected, pool not yet in Cancun", core.ErrTxTypeNotSupported, tx.Type())
 	}
+	if !opts.Config.IsBlobTxs(head.Time) && tx.Type() == types.BlobTxType {
+		return fmt.Errorf("%w: type %d rejected, blob txs not enabled", core.ErrTxTypeNotSupported, tx.Type())
+	}
 	// Check whether the init code size has been exceeded
 	if opts.Config.IsDurango(head.Time) && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
 		return fmt.Errorf("%w: code size %v, limit %v", vmerrs.ErrMaxInitCodeSizeExceeded, len(tx.Data()), params.MaxInitCodeSize)
@@ -226,6 +231,19 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 		return err
 	}
 
+	// Drop the transaction if the sender is not allowed to issue transactions
+	// or, for contract creations, to deploy contracts
+	if opts.Rules.IsPrecompileEnabled(txallowlist.ContractAddress) {
+		if role := txallowlist.GetTxAllowListStatus(opts.State, from); !role.IsEnabled() {
+			return fmt.Errorf("%w: address %s", vmerrs.ErrSenderNotAllowListed, from.Hex())
+		}
+	}
+	if tx.To() == nil && opts.Rules.IsPrecompileEnabled(deployerallowlist.ContractAddress) {
+		if role := deployerallowlist.GetContractDeployerAllowListStatus(opts.State, from); !role.IsEnabled() {
+			return fmt.Errorf("%w: address %s", vmerrs.ErrDeployerNotAllowListed, from.Hex())
+		}
+	}
+
 	// Drop the transaction if the gas fee cap is below the pool's minimum fee
 	if opts.MinimumFee != nil && tx.GasFeeCapIntCmp(opts.MinimumFee) < 0 {
 		return fmt.Errorf("%w: address %s have gas fee cap (%d) < pool minimum fee cap (%d)", ErrUnderpriced, from.Hex(), tx.GasFeeCap(), opts.MinimumFee)
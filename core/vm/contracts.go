@@ -174,6 +174,19 @@ var PrecompiledContractsCancun = map[common.Address]contract.StatefulPrecompiled
 	common.BytesToAddress([]byte{0x0a}): newWrappedPrecompiledContract(&kzgPointEvaluation{}),
 }
 
+// PrecompiledContractsSignatures contains the set of pre-compiled contracts
+// used once a network opted in to the signature verification precompiles,
+// which extend the Cancun set with P-256 and BLS signature verification.
+var PrecompiledContractsSignatures = func() map[common.Address]contract.StatefulPrecompiledContract {
+	precompiles := make(map[common.Address]contract.StatefulPrecompiledContract, len(PrecompiledContractsCancun)+2)
+	for addr, precompile := range PrecompiledContractsCancun {
+		precompiles[addr] = precompile
+	}
+	precompiles[P256VerifyAddr] = newWrappedPrecompiledContract(&p256Verify{})
+	precompiles[BLSSignatureVerifyAddr] = newWrappedPrecompiledContract(&blsSignatureVerify{})
+	return precompiles
+}()
+
 // PrecompiledContractsBLS contains the set of pre-compiled Ethereum
 // contracts specified in EIP-2537. These are exported for testing purposes.
 var PrecompiledContractsBLS = map[common.Address]contract.StatefulPrecompiledContract{
@@ -189,6 +202,7 @@ var PrecompiledContractsBLS = map[common.Address]contract.StatefulPrecompiledCon
 }
 
 var (
+	PrecompiledAddressesSignatures       []common.Address
 	PrecompiledAddressesCancun           []common.Address
 	PrecompiledAddressesBanff            []common.Address
 	PrecompiledAddressesApricotPhase6    []common.Address
@@ -226,6 +240,9 @@ func init() {
 	for k := range PrecompiledContractsCancun {
 		PrecompiledAddressesCancun = append(PrecompiledAddressesCancun, k)
 	}
+	for k := range PrecompiledContractsSignatures {
+		PrecompiledAddressesSignatures = append(PrecompiledAddressesSignatures, k)
+	}
 	for k := range PrecompiledContractsBLS {
 		PrecompiledAddressesBLS = append(PrecompiledAddressesBLS, k)
 	}
@@ -240,6 +257,7 @@ func init() {
 	addrsList = append(addrsList, PrecompiledAddressesApricotPhase6...)
 	addrsList = append(addrsList, PrecompiledAddressesBanff...)
 	addrsList = append(addrsList, PrecompiledAddressesCancun...)
+	addrsList = append(addrsList, PrecompiledAddressesSignatures...)
 	addrsList = append(addrsList, PrecompiledAddressesBLS...)
 	for _, k := range addrsList {
 		PrecompileAllNativeAddresses[k] = struct{}{}
@@ -258,6 +276,8 @@ func init() {
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
 	switch {
+	case rules.IsCancun && rules.IsSignaturePrecompiles:
+		return PrecompiledAddressesSignatures
 	case rules.IsCancun:
 		return PrecompiledAddressesCancun
 	case rules.IsBanff:
@@ -27,8 +27,14 @@
 package vm
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/rand"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -52,3 +58,63 @@ func FuzzPrecompiledContracts(f *testing.F) {
 		}
 	})
 }
+
+// FuzzP256Verify checks that the P-256 precompile accepts exactly the
+// signatures of the signed message.
+func FuzzP256Verify(f *testing.F) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.New(rand.NewSource(1)))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add([]byte("hello"), []byte("hello"))
+	f.Add([]byte("hello"), []byte("world"))
+	f.Fuzz(func(t *testing.T, signed []byte, verified []byte) {
+		signedHash := sha256.Sum256(signed)
+		r, s, err := ecdsa.Sign(rand.New(rand.NewSource(2)), key, signedHash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifiedHash := sha256.Sum256(verified)
+		input := make([]byte, 0, p256VerifyInputLength)
+		input = append(input, verifiedHash[:]...)
+		input = append(input, common.BigToHash(r).Bytes()...)
+		input = append(input, common.BigToHash(s).Bytes()...)
+		input = append(input, common.BigToHash(key.X).Bytes()...)
+		input = append(input, common.BigToHash(key.Y).Bytes()...)
+
+		res, err := (&p256Verify{}).Run(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid := signedHash == verifiedHash; valid != bytes.Equal(res, true32Byte) {
+			t.Errorf("expected valid %t, got output %x", valid, res)
+		}
+	})
+}
+
+// FuzzBLSSignatureVerify checks that the BLS precompile accepts exactly the
+// signatures of the signed message.
+func FuzzBLSSignatureVerify(f *testing.F) {
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		f.Fatal(err)
+	}
+	pk := bls.PublicKeyToCompressedBytes(bls.PublicFromSecretKey(sk))
+	f.Add([]byte("hello"), []byte("hello"))
+	f.Add([]byte("hello"), []byte("world"))
+	f.Fuzz(func(t *testing.T, signed []byte, verified []byte) {
+		sig := bls.SignatureToBytes(bls.Sign(sk, signed))
+		input := make([]byte, 0, blsSignatureVerifyPrefixLength+len(verified))
+		input = append(input, pk...)
+		input = append(input, sig...)
+		input = append(input, verified...)
+
+		res, err := (&blsSignatureVerify{}).Run(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid := bytes.Equal(signed, verified); valid != bytes.Equal(res, true32Byte) {
+			t.Errorf("expected valid %t, got output %x", valid, res)
+		}
+	})
+}
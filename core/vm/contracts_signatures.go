@@ -0,0 +1,94 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// P256VerifyAddr is the address of the secp256r1 signature verification
+	// precompile, as specified in RIP-7212.
+	P256VerifyAddr = common.BytesToAddress([]byte{0x01, 0x00})
+	// BLSSignatureVerifyAddr is the address of the BLS signature verification
+	// precompile, verifying signatures of Avalanche BLS keys. It follows the
+	// P-256 precompile, outside of the ranges reserved for stateful precompiles.
+	BLSSignatureVerifyAddr = common.BytesToAddress([]byte{0x01, 0x01})
+)
+
+const (
+	p256VerifyInputLength          = 160 // hash, r, s, x and y of 32 bytes each
+	blsSignatureVerifyPrefixLength = bls.PublicKeyLen + bls.SignatureLen
+)
+
+// p256Verify implements the RIP-7212 secp256r1 signature verification
+// precompile.
+type p256Verify struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *p256Verify) RequiredGas(input []byte) uint64 {
+	return params.P256VerifyGas
+}
+
+// Run verifies the signature (r, s) of the hash against the public key (x, y),
+// given as the 160 bytes (hash, r, s, x, y). It returns 1 as a 32 byte word if
+// the signature is valid, and no output if it is not or the input is invalid.
+func (c *p256Verify) Run(input []byte) ([]byte, error) {
+	if len(input) != p256VerifyInputLength {
+		return nil, nil
+	}
+	hash := input[0:32]
+	r := new(big.Int).SetBytes(input[32:64])
+	s := new(big.Int).SetBytes(input[64:96])
+	x := new(big.Int).SetBytes(input[96:128])
+	y := new(big.Int).SetBytes(input[128:160])
+
+	// The point at infinity is not on the curve, so it is rejected as well.
+	curve := elliptic.P256()
+	if !curve.IsOnCurve(x, y) {
+		return nil, nil
+	}
+	// ecdsa.Verify rejects r and s out of [1, n-1].
+	if !ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, hash, r, s) {
+		return nil, nil
+	}
+	return true32Byte, nil
+}
+
+// blsSignatureVerify implements the verification of BLS signatures of
+// Avalanche BLS keys, as used by validators to sign warp messages.
+type blsSignatureVerify struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *blsSignatureVerify) RequiredGas(input []byte) uint64 {
+	return params.BlsSignatureVerifyBaseGas + uint64(len(input)+31)/32*params.BlsSignatureVerifyPerWordGas
+}
+
+// Run verifies the signature of the message against the public key, given as
+// the 48 byte compressed public key, the 96 byte compressed signature and the
+// message. It returns 1 as a 32 byte word if the signature is valid, and no
+// output if it is not or the input is invalid.
+func (c *blsSignatureVerify) Run(input []byte) ([]byte, error) {
+	if len(input) < blsSignatureVerifyPrefixLength {
+		return nil, nil
+	}
+	pk, err := bls.PublicKeyFromCompressedBytes(input[:bls.PublicKeyLen])
+	if err != nil {
+		return nil, nil
+	}
+	sig, err := bls.SignatureFromBytes(input[bls.PublicKeyLen:blsSignatureVerifyPrefixLength])
+	if err != nil {
+		return nil, nil
+	}
+	if !bls.Verify(pk, sig, input[blsSignatureVerifyPrefixLength:]) {
+		return nil, nil
+	}
+	return true32Byte, nil
+}
@@ -34,7 +34,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/modules"
+	"github.com/ava-labs/coreth/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -77,6 +81,9 @@ var allPrecompiles = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{0x0f, 0x10}): &bls12381Pairing{},
 	common.BytesToAddress([]byte{0x0f, 0x11}): &bls12381MapG1{},
 	common.BytesToAddress([]byte{0x0f, 0x12}): &bls12381MapG2{},
+
+	P256VerifyAddr:         &p256Verify{},
+	BLSSignatureVerifyAddr: &blsSignatureVerify{},
 }
 
 // EIP-152 test vectors
@@ -326,6 +333,33 @@ func TestPrecompiledBLS12381MapG2(t *testing.T)      { testJson("blsMapG2", "f12
 
 func TestPrecompiledPointEvaluation(t *testing.T) { testJson("pointEvaluation", "0a", t) }
 
+func TestPrecompiledP256Verify(t *testing.T)      { testJson("p256Verify", "100", t) }
+func BenchmarkPrecompiledP256Verify(b *testing.B) { benchJson("p256Verify", "100", b) }
+
+func TestSignaturePrecompilesActivation(t *testing.T) {
+	config := *params.TestChainConfig
+	config.CancunTime = utils.NewUint64(0)
+	config.SignaturePrecompilesTimestamp = utils.NewUint64(10)
+	for _, addr := range []common.Address{P256VerifyAddr, BLSSignatureVerifyAddr} {
+		require.NotContains(t, ActivePrecompiles(config.Rules(common.Big0, 9)), addr)
+		require.Contains(t, ActivePrecompiles(config.Rules(common.Big0, 10)), addr)
+		require.False(t, modules.ReservedAddress(addr))
+	}
+	// The signature precompiles extend the Cancun set.
+	require.Len(t, PrecompiledContractsSignatures, len(PrecompiledContractsCancun)+2)
+	for addr, precompile := range PrecompiledContractsCancun {
+		require.Equal(t, precompile, PrecompiledContractsSignatures[addr])
+	}
+}
+
+func TestPrecompiledBLSSignatureVerify(t *testing.T) {
+	testJson("blsSignatureVerify", BLSSignatureVerifyAddr.Hex(), t)
+}
+
+func BenchmarkPrecompiledBLSSignatureVerify(b *testing.B) {
+	benchJson("blsSignatureVerify", BLSSignatureVerifyAddr.Hex(), b)
+}
+
 func BenchmarkPrecompiledBLS12381G1Add(b *testing.B)      { benchJson("blsG1Add", "f0a", b) }
 func BenchmarkPrecompiledBLS12381G1Mul(b *testing.B)      { benchJson("blsG1Mul", "f0b", b) }
 func BenchmarkPrecompiledBLS12381G1MultiExp(b *testing.B) { benchJson("blsG1MultiExp", "f0c", b) }
@@ -0,0 +1,254 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/modules"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+)
+
+// PrecompileTracer is an EVMLogger that also inspects the execution of the
+// stateful precompiles registered in precompile/modules, which otherwise only
+// appear as opaque calls.
+type PrecompileTracer interface {
+	EVMLogger
+	// CapturePrecompile is called once a precompile of a module has run, before
+	// the end of its call frame is captured. [depth] is the depth of the call
+	// frame of the precompile, as passed to CaptureState.
+	CapturePrecompile(call *PrecompileCall, depth int)
+}
+
+// PrecompileCall describes the execution of a precompile of a module.
+type PrecompileCall struct {
+	Address common.Address
+	// Method and Args are decoded from the input with the ABI of the module.
+	// Method is empty if the module has no ABI or the selector is unknown.
+	Method string
+	Args   []PrecompileArg
+	// Predicates are the predicates of the transaction read by the precompile.
+	Predicates []PrecompilePredicate
+	// Storage and Accounts are the storage slots and accounts accessed by the
+	// precompile, in the order they were first accessed.
+	Storage  []PrecompileStorageAccess
+	Accounts []PrecompileAccountAccess
+	Logs     []PrecompileLog
+}
+
+// PrecompileArg is a decoded argument of a call to a precompile.
+type PrecompileArg struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// PrecompilePredicate is a predicate read by a precompile. Valid is true if
+// the predicate exists and passed verification.
+type PrecompilePredicate struct {
+	Address common.Address
+	Index   int
+	Valid   bool
+}
+
+// PrecompileStorageAccess is a storage slot accessed by a precompile, with
+// its value before the first access and after the precompile ran.
+type PrecompileStorageAccess struct {
+	Address common.Address
+	Slot    common.Hash
+	Before  common.Hash
+	After   common.Hash
+}
+
+// PrecompileAccountAccess is an account accessed by a precompile, with its
+// balance and nonce before the first access.
+type PrecompileAccountAccess struct {
+	Address common.Address
+	Balance *uint256.Int
+	Nonce   uint64
+}
+
+// PrecompileLog is a log emitted by a precompile.
+type PrecompileLog struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+// runPrecompile runs [p] at [addr]. If the tracer is a PrecompileTracer and
+// [addr] is the address of a module, the accesses of the precompile to the
+// state are recorded and passed to the tracer.
+func (evm *EVM) runPrecompile(p contract.StatefulPrecompiledContract, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	tracer, ok := evm.Config.Tracer.(PrecompileTracer)
+	if !ok {
+		return RunStatefulPrecompiledContract(p, evm, caller, addr, input, suppliedGas, readOnly)
+	}
+	module, ok := modules.GetPrecompileModuleByAddress(addr)
+	if !ok {
+		return RunStatefulPrecompiledContract(p, evm, caller, addr, input, suppliedGas, readOnly)
+	}
+
+	call := &PrecompileCall{Address: addr}
+	decodePrecompileInput(call, module, input)
+	state := &tracingAccessibleState{
+		EVM: evm,
+		stateDB: &precompileTracingStateDB{
+			StateDB:  evm.StateDB,
+			call:     call,
+			slots:    make(map[precompileSlot]struct{}),
+			accounts: make(map[common.Address]struct{}),
+		},
+	}
+	ret, remainingGas, err = RunStatefulPrecompiledContract(p, state, caller, addr, input, suppliedGas, readOnly)
+
+	for i := range call.Predicates {
+		predicate := &call.Predicates[i]
+		results := evm.Context.GetPredicateResults(evm.StateDB.GetTxHash(), predicate.Address)
+		predicate.Valid = predicate.Valid && !set.BitsFromBytes(results).Contains(predicate.Index)
+	}
+	for i := range call.Storage {
+		access := &call.Storage[i]
+		access.After = evm.StateDB.GetState(access.Address, access.Slot)
+	}
+	tracer.CapturePrecompile(call, evm.depth+1)
+	return ret, remainingGas, err
+}
+
+// decodePrecompileInput sets the method and the arguments of [call] from
+// [input], if it is a valid call to a method of the ABI of [module].
+func decodePrecompileInput(call *PrecompileCall, module modules.Module, input []byte) {
+	if len(input) < 4 {
+		return
+	}
+	method, err := module.ABI.MethodById(input[:4])
+	if err != nil {
+		return
+	}
+	call.Method = method.Name
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return
+	}
+	call.Args = make([]PrecompileArg, len(values))
+	for i, value := range values {
+		call.Args[i] = PrecompileArg{
+			Name:  method.Inputs[i].Name,
+			Type:  method.Inputs[i].Type.String(),
+			Value: value,
+		}
+	}
+}
+
+// tracingAccessibleState is the state accessible to a traced precompile,
+// which accesses the state through a precompileTracingStateDB.
+type tracingAccessibleState struct {
+	*EVM
+	stateDB *precompileTracingStateDB
+}
+
+func (s *tracingAccessibleState) GetStateDB() contract.StateDB {
+	return s.stateDB
+}
+
+type precompileSlot struct {
+	address common.Address
+	slot    common.Hash
+}
+
+// precompileTracingStateDB records the accesses of a precompile to the state
+// into [call].
+type precompileTracingStateDB struct {
+	contract.StateDB
+	call     *PrecompileCall
+	slots    map[precompileSlot]struct{}
+	accounts map[common.Address]struct{}
+}
+
+func (s *precompileTracingStateDB) touchSlot(address common.Address, slot common.Hash) {
+	key := precompileSlot{address: address, slot: slot}
+	if _, ok := s.slots[key]; ok {
+		return
+	}
+	s.slots[key] = struct{}{}
+	value := s.StateDB.GetState(address, slot)
+	s.call.Storage = append(s.call.Storage, PrecompileStorageAccess{
+		Address: address,
+		Slot:    slot,
+		Before:  value,
+		After:   value,
+	})
+}
+
+func (s *precompileTracingStateDB) touchAccount(address common.Address) {
+	if _, ok := s.accounts[address]; ok {
+		return
+	}
+	s.accounts[address] = struct{}{}
+	s.call.Accounts = append(s.call.Accounts, PrecompileAccountAccess{
+		Address: address,
+		Balance: new(uint256.Int).Set(s.StateDB.GetBalance(address)),
+		Nonce:   s.StateDB.GetNonce(address),
+	})
+}
+
+func (s *precompileTracingStateDB) GetState(address common.Address, slot common.Hash) common.Hash {
+	s.touchSlot(address, slot)
+	return s.StateDB.GetState(address, slot)
+}
+
+func (s *precompileTracingStateDB) SetState(address common.Address, slot common.Hash, value common.Hash) {
+	s.touchSlot(address, slot)
+	s.StateDB.SetState(address, slot, value)
+}
+
+func (s *precompileTracingStateDB) SetNonce(address common.Address, nonce uint64) {
+	s.touchAccount(address)
+	s.StateDB.SetNonce(address, nonce)
+}
+
+func (s *precompileTracingStateDB) GetNonce(address common.Address) uint64 {
+	s.touchAccount(address)
+	return s.StateDB.GetNonce(address)
+}
+
+func (s *precompileTracingStateDB) GetBalance(address common.Address) *uint256.Int {
+	s.touchAccount(address)
+	return s.StateDB.GetBalance(address)
+}
+
+func (s *precompileTracingStateDB) AddBalance(address common.Address, amount *uint256.Int) {
+	s.touchAccount(address)
+	s.StateDB.AddBalance(address, amount)
+}
+
+func (s *precompileTracingStateDB) CreateAccount(address common.Address) {
+	s.touchAccount(address)
+	s.StateDB.CreateAccount(address)
+}
+
+func (s *precompileTracingStateDB) Exist(address common.Address) bool {
+	s.touchAccount(address)
+	return s.StateDB.Exist(address)
+}
+
+func (s *precompileTracingStateDB) AddLog(address common.Address, topics []common.Hash, data []byte, blockNumber uint64) {
+	s.call.Logs = append(s.call.Logs, PrecompileLog{
+		Address: address,
+		Topics:  append([]common.Hash(nil), topics...),
+		Data:    common.CopyBytes(data),
+	})
+	s.StateDB.AddLog(address, topics, data, blockNumber)
+}
+
+func (s *precompileTracingStateDB) GetPredicateStorageSlots(address common.Address, index int) ([]byte, bool) {
+	predicate, exists := s.StateDB.GetPredicateStorageSlots(address, index)
+	// Valid is completed with the predicate results once the precompile ran.
+	s.call.Predicates = append(s.call.Predicates, PrecompilePredicate{
+		Address: address,
+		Index:   index,
+		Valid:   exists,
+	})
+	return predicate, exists
+}
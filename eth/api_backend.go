@@ -37,8 +37,11 @@ import (
 	"github.com/ava-labs/coreth/consensus/dummy"
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/bloombits"
+	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/state"
 	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/eth/gasprice"
@@ -276,6 +279,13 @@ func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (type
 	return b.eth.blockchain.GetReceiptsByHash(hash), nil
 }
 
+func (b *EthAPIBackend) GetBlobSidecar(ctx context.Context, number uint64, txHash common.Hash) (*types.BlobTxSidecar, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return rawdb.ReadBlobSidecar(b.eth.ChainDb(), number, txHash), nil
+}
+
 func (b *EthAPIBackend) GetLogs(ctx context.Context, hash common.Hash, number uint64) ([][]*types.Log, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -347,6 +357,22 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return nil
 }
 
+func (b *EthAPIBackend) SendBundle(ctx context.Context, bundle *bundlepool.Bundle) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return b.eth.bundlePool.AddBundle(bundle)
+}
+
+func (b *EthAPIBackend) SendPrivateTx(ctx context.Context, ptx *privatepool.PrivateTx) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// Private transactions are not enqueued for push gossip until they fall
+	// back to public gossip.
+	return b.eth.privatePool.AddPrivate(ptx)
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(txpool.PendingFilter{})
 	var txs types.Transactions
@@ -407,6 +433,22 @@ func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.S
 	return b.eth.txPool.SubscribeTransactions(ch, true)
 }
 
+func (b *EthAPIBackend) TxPoolStatus(txHash common.Hash) txpool.TxStatus {
+	return b.eth.txPool.Status(txHash)
+}
+
+func (b *EthAPIBackend) TxPoolDropped(txHash common.Hash) (txpool.DroppedTx, bool) {
+	return b.eth.txPool.Dropped(txHash)
+}
+
+func (b *EthAPIBackend) SubscribeTxStatusEvent(ch chan<- txpool.TxStatusEvent) event.Subscription {
+	return b.eth.txPool.SubscribeTxStatusEvents(ch)
+}
+
+func (b *EthAPIBackend) TxLastGossiped(txHash common.Hash) (time.Time, bool) {
+	return b.eth.gossiper.LastGossiped(txHash)
+}
+
 func (b *EthAPIBackend) EstimateBaseFee(ctx context.Context) (*big.Int, error) {
 	return b.gpo.EstimateBaseFee(ctx)
 }
@@ -419,6 +461,20 @@ func (b *EthAPIBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	return b.gpo.SuggestTipCap(ctx)
 }
 
+func (b *EthAPIBackend) SuggestGasTipCapsByLatency(ctx context.Context, latencies []uint64) (baseFees, blockGasCosts, tips []*big.Int, err error) {
+	latencyTips, err := b.gpo.SuggestTipCapsByLatency(ctx, latencies)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	baseFees = make([]*big.Int, len(latencyTips))
+	blockGasCosts = make([]*big.Int, len(latencyTips))
+	tips = make([]*big.Int, len(latencyTips))
+	for i, tip := range latencyTips {
+		baseFees[i], blockGasCosts[i], tips[i] = tip.BaseFee, tip.BlockGasCost, tip.Tip
+	}
+	return baseFees, blockGasCosts, tips, nil
+}
+
 func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, err error) {
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
@@ -510,6 +566,10 @@ func (b *EthAPIBackend) MinRequiredTip(ctx context.Context, header *types.Header
 	return dummy.MinRequiredTip(b.ChainConfig(), header)
 }
 
+func (b *EthAPIBackend) GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error) {
+	return b.eth.blockchain.GetFeeConfigAt(parent)
+}
+
 func (b *EthAPIBackend) isLatestAndAllowed(number rpc.BlockNumber) bool {
 	return number.IsLatest() && b.IsAllowUnfinalizedQueries()
 }
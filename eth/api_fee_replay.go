@@ -0,0 +1,172 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxFeeReplayBlocks is the maximum number of blocks replayed by a single call
+// to debug_replayFees.
+const maxFeeReplayBlocks = 10_000
+
+// FeeParamsOverride overrides parameters of the dynamic fee algorithm. Unset
+// parameters keep the values in effect on the chain.
+type FeeParamsOverride struct {
+	TargetGas                *hexutil.Uint64 `json:"targetGas"`
+	BaseFeeChangeDenominator *hexutil.Big    `json:"baseFeeChangeDenominator"`
+	MinBaseFee               *hexutil.Big    `json:"minBaseFee"`
+	MaxBaseFee               *hexutil.Big    `json:"maxBaseFee"`
+	TargetBlockRate          *hexutil.Uint64 `json:"targetBlockRate"`
+	MinBlockGasCost          *hexutil.Big    `json:"minBlockGasCost"`
+	MaxBlockGasCost          *hexutil.Big    `json:"maxBlockGasCost"`
+	BlockGasCostStep         *hexutil.Big    `json:"blockGasCostStep"`
+}
+
+// apply returns [feeParams] with the set parameters of [o] overridden.
+func (o *FeeParamsOverride) apply(feeParams dummy.FeeParams) dummy.FeeParams {
+	if o == nil {
+		return feeParams
+	}
+	if o.TargetGas != nil {
+		feeParams.TargetGas = uint64(*o.TargetGas)
+	}
+	if o.BaseFeeChangeDenominator != nil {
+		feeParams.BaseFeeChangeDenominator = o.BaseFeeChangeDenominator.ToInt()
+	}
+	if o.MinBaseFee != nil {
+		feeParams.MinBaseFee = o.MinBaseFee.ToInt()
+	}
+	if o.MaxBaseFee != nil {
+		feeParams.MaxBaseFee = o.MaxBaseFee.ToInt()
+	}
+	if o.TargetBlockRate != nil {
+		feeParams.TargetBlockRate = uint64(*o.TargetBlockRate)
+	}
+	if o.MinBlockGasCost != nil {
+		feeParams.MinBlockGasCost = o.MinBlockGasCost.ToInt()
+	}
+	if o.MaxBlockGasCost != nil {
+		feeParams.MaxBlockGasCost = o.MaxBlockGasCost.ToInt()
+	}
+	if o.BlockGasCostStep != nil {
+		feeParams.BlockGasCostStep = o.BlockGasCostStep.ToInt()
+	}
+	return feeParams
+}
+
+// validate checks that the overridden parameters can be used by the dynamic
+// fee algorithm.
+func (o *FeeParamsOverride) validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.TargetGas != nil && *o.TargetGas == 0 {
+		return errors.New("target gas must be positive")
+	}
+	if o.BaseFeeChangeDenominator != nil && o.BaseFeeChangeDenominator.ToInt().Sign() <= 0 {
+		return errors.New("base fee change denominator must be positive")
+	}
+	for name, value := range map[string]*hexutil.Big{
+		"min base fee":        o.MinBaseFee,
+		"max base fee":        o.MaxBaseFee,
+		"min block gas cost":  o.MinBlockGasCost,
+		"max block gas cost":  o.MaxBlockGasCost,
+		"block gas cost step": o.BlockGasCostStep,
+	} {
+		if value != nil && value.ToInt().Sign() < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// ReplayedFeesResult are the fees of a block on the chain alongside the fees
+// it would have had with the overridden fee parameters.
+type ReplayedFeesResult struct {
+	Number  hexutil.Uint64 `json:"number"`
+	Time    hexutil.Uint64 `json:"timestamp"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+
+	BaseFee      *hexutil.Big `json:"baseFeePerGas,omitempty"`
+	BlockGasCost *hexutil.Big `json:"blockGasCost,omitempty"`
+	BlockFee     *hexutil.Big `json:"blockFee,omitempty"`
+
+	ReplayedBaseFee      *hexutil.Big `json:"replayedBaseFeePerGas,omitempty"`
+	ReplayedBlockGasCost *hexutil.Big `json:"replayedBlockGasCost,omitempty"`
+	ReplayedBlockFee     *hexutil.Big `json:"replayedBlockFee,omitempty"`
+}
+
+// ReplayFees replays the blocks from [start] to [end] through the dynamic fee
+// algorithm with the parameters in [override] instead of the ones in effect on
+// the chain, and returns the base fee, block gas cost and block fee series of
+// both for comparison.
+func (api *DebugAPI) ReplayFees(ctx context.Context, start, end rpc.BlockNumber, override *FeeParamsOverride) ([]*ReplayedFeesResult, error) {
+	if err := override.validate(); err != nil {
+		return nil, err
+	}
+	startHeader, err := api.eth.APIBackend.HeaderByNumber(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	endHeader, err := api.eth.APIBackend.HeaderByNumber(ctx, end)
+	if err != nil {
+		return nil, err
+	}
+	if startHeader == nil || endHeader == nil {
+		return nil, fmt.Errorf("block range [%d, %d] not found", start, end)
+	}
+	startNum, endNum := startHeader.Number.Uint64(), endHeader.Number.Uint64()
+	switch {
+	case startNum == 0:
+		return nil, errors.New("cannot replay the genesis block")
+	case startNum > endNum:
+		return nil, fmt.Errorf("start block %d after end block %d", startNum, endNum)
+	case endNum-startNum+1 > maxFeeReplayBlocks:
+		return nil, fmt.Errorf("cannot replay more than %d blocks", maxFeeReplayBlocks)
+	}
+
+	parent := api.eth.blockchain.GetHeaderByNumber(startNum - 1)
+	if parent == nil {
+		return nil, fmt.Errorf("block %d not found", startNum-1)
+	}
+	headers := make([]*types.Header, 0, endNum-startNum+1)
+	for number := startNum; number <= endNum; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		header := api.eth.blockchain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("block %d not found", number)
+		}
+		headers = append(headers, header)
+	}
+
+	replayed, err := dummy.ReplayFees(api.eth.blockchain.Config(), api.eth.blockchain, parent, headers, override.apply)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*ReplayedFeesResult, len(replayed))
+	for i, fees := range replayed {
+		results[i] = &ReplayedFeesResult{
+			Number:               hexutil.Uint64(fees.Number),
+			Time:                 hexutil.Uint64(fees.Time),
+			GasUsed:              hexutil.Uint64(fees.GasUsed),
+			BaseFee:              (*hexutil.Big)(fees.BaseFee),
+			BlockGasCost:         (*hexutil.Big)(fees.BlockGasCost),
+			BlockFee:             (*hexutil.Big)(fees.BlockFee),
+			ReplayedBaseFee:      (*hexutil.Big)(fees.ReplayedBaseFee),
+			ReplayedBlockGasCost: (*hexutil.Big)(fees.ReplayedBlockGasCost),
+			ReplayedBlockFee:     (*hexutil.Big)(fees.ReplayedBlockFee),
+		}
+	}
+	return results, nil
+}
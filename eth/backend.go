@@ -42,7 +42,10 @@ import (
 	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/state/pruner"
 	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/blobpool"
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
 	"github.com/ava-labs/coreth/core/txpool/legacypool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/eth/ethconfig"
@@ -75,6 +78,10 @@ type Settings struct {
 // removed from the mempool.
 type PushGossiper interface {
 	Add(*types.Transaction)
+
+	// LastGossiped returns when the transaction with [hash] was last sent to
+	// peers, if it was recently.
+	LastGossiped(hash common.Hash) (time.Time, bool)
 }
 
 // Ethereum implements the Ethereum full node service.
@@ -82,7 +89,10 @@ type Ethereum struct {
 	config *Config
 
 	// Handlers
-	txPool *txpool.TxPool
+	txPool      *txpool.TxPool
+	blobPool    *blobpool.BlobPool // nil unless the network opted in to blob txs
+	bundlePool  *bundlepool.BundlePool
+	privatePool *privatepool.PrivatePool
 
 	blockchain *core.BlockChain
 	gossiper   PushGossiper
@@ -98,6 +108,9 @@ type Ethereum struct {
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	privateFallbackSub event.Subscription // Subscription to private txs falling back to public gossip
+	blobSidecarsSub    event.Subscription // Subscription to accepted blocks whose blob sidecars are stored
+
 	APIBackend *EthAPIBackend
 
 	miner     *miner.Miner
@@ -243,14 +256,18 @@ func New(
 
 	eth.bloomIndexer.Start(eth.blockchain)
 
-	// Uncomment the following to enable the new blobpool
-
-	// config.BlobPool.Datadir = ""
-	// blobPool := blobpool.New(config.BlobPool, &chainWithFinalBlock{eth.blockchain})
-
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	eth.bundlePool = bundlepool.New(config.BundlePool, eth.blockchain)
+	eth.privatePool = privatepool.New(config.PrivatePool, eth.blockchain)
+	subpools := []txpool.SubPool{legacyPool, eth.bundlePool, eth.privatePool}
+
+	// Blob txs are only pooled on networks which opted in to them
+	if eth.blockchain.Config().BlobTxsTimestamp != nil {
+		eth.blobPool = blobpool.New(config.BlobPool, &chainWithFinalBlock{eth.blockchain})
+		subpools = append(subpools, eth.blobPool)
+	}
 
-	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, []txpool.SubPool{legacyPool}) //, blobPool})
+	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, subpools)
 	if err != nil {
 		return nil, err
 	}
@@ -354,12 +371,14 @@ func (s *Ethereum) SetEtherbase(etherbase common.Address) {
 
 func (s *Ethereum) Miner() *miner.Miner { return s.miner }
 
-func (s *Ethereum) AccountManager() *accounts.Manager { return s.accountManager }
-func (s *Ethereum) BlockChain() *core.BlockChain      { return s.blockchain }
-func (s *Ethereum) TxPool() *txpool.TxPool            { return s.txPool }
-func (s *Ethereum) EventMux() *event.TypeMux          { return s.eventMux }
-func (s *Ethereum) Engine() consensus.Engine          { return s.engine }
-func (s *Ethereum) ChainDb() ethdb.Database           { return s.chainDb }
+func (s *Ethereum) AccountManager() *accounts.Manager     { return s.accountManager }
+func (s *Ethereum) BlockChain() *core.BlockChain          { return s.blockchain }
+func (s *Ethereum) TxPool() *txpool.TxPool                { return s.txPool }
+func (s *Ethereum) BundlePool() *bundlepool.BundlePool    { return s.bundlePool }
+func (s *Ethereum) PrivatePool() *privatepool.PrivatePool { return s.privatePool }
+func (s *Ethereum) EventMux() *event.TypeMux              { return s.eventMux }
+func (s *Ethereum) Engine() consensus.Engine              { return s.engine }
+func (s *Ethereum) ChainDb() ethdb.Database               { return s.chainDb }
 
 func (s *Ethereum) NetVersion() uint64               { return s.networkID }
 func (s *Ethereum) ArchiveMode() bool                { return !s.config.Pruning }
@@ -373,6 +392,71 @@ func (s *Ethereum) Start() {
 
 	// Regularly update shutdown marker
 	s.shutdownTracker.Start()
+
+	// Publish the private transactions which fall back to public gossip
+	fallbacks := make(chan core.NewTxsEvent, 16)
+	s.privateFallbackSub = s.privatePool.SubscribeFallbacks(fallbacks)
+	go s.publishPrivateFallbacks(fallbacks)
+
+	// Store the sidecars of the blob txs in accepted blocks
+	if s.blobPool != nil {
+		accepted := make(chan core.ChainEvent, 16)
+		s.blobSidecarsSub = s.blockchain.SubscribeChainAcceptedEvent(accepted)
+		go s.storeBlobSidecars(accepted)
+	}
+}
+
+// publishPrivateFallbacks adds the private transactions whose fallback block
+// was reached to the public pools and pushes them to peers, until the
+// subscription is closed.
+func (s *Ethereum) publishPrivateFallbacks(fallbacks chan core.NewTxsEvent) {
+	for {
+		select {
+		case ev := <-fallbacks:
+			errs := s.txPool.Add(ev.Txs, true, false)
+			for i, tx := range ev.Txs {
+				if errs[i] != nil {
+					log.Debug("Failed to publish private transaction", "hash", tx.Hash(), "err", errs[i])
+					continue
+				}
+				log.Debug("Published private transaction", "hash", tx.Hash())
+				s.gossiper.Add(tx)
+			}
+		case <-s.privateFallbackSub.Err():
+			return
+		}
+	}
+}
+
+// storeBlobSidecars stores the sidecars of the blob txs in accepted blocks,
+// which are not part of the blocks, from the blob pool and prunes the ones
+// older than the retention window, until the subscription is closed.
+func (s *Ethereum) storeBlobSidecars(accepted chan core.ChainEvent) {
+	for {
+		select {
+		case ev := <-accepted:
+			number := ev.Block.NumberU64()
+			for _, tx := range ev.Block.Transactions() {
+				if tx.Type() != types.BlobTxType {
+					continue
+				}
+				sidecar := s.blobPool.GetSidecar(tx.Hash())
+				if sidecar == nil {
+					// The tx was included without being seen by this node
+					log.Debug("Blob sidecar unavailable", "hash", tx.Hash(), "number", number)
+					continue
+				}
+				rawdb.WriteBlobSidecar(s.chainDb, number, tx.Hash(), sidecar)
+			}
+			if retention := s.config.BlobSidecarRetention; retention != 0 && number >= retention {
+				if deleted := rawdb.DeleteBlobSidecarsBelow(s.chainDb, number-retention+1); deleted > 0 {
+					log.Debug("Pruned blob sidecars", "count", deleted, "below", number-retention+1)
+				}
+			}
+		case <-s.blobSidecarsSub.Err():
+			return
+		}
+	}
 }
 
 // Stop implements node.Lifecycle, terminating all internal goroutines used by the
@@ -381,6 +465,12 @@ func (s *Ethereum) Start() {
 func (s *Ethereum) Stop() error {
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	if s.privateFallbackSub != nil {
+		s.privateFallbackSub.Unsubscribe()
+	}
+	if s.blobSidecarsSub != nil {
+		s.blobSidecarsSub.Unsubscribe()
+	}
 	s.txPool.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
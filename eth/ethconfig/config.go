@@ -31,7 +31,9 @@ import (
 
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/txpool/blobpool"
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
 	"github.com/ava-labs/coreth/core/txpool/legacypool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
 	"github.com/ava-labs/coreth/eth/gasprice"
 	"github.com/ava-labs/coreth/miner"
 	"github.com/ava-labs/coreth/params"
@@ -66,6 +68,9 @@ func NewDefaultConfig() Config {
 		Miner:                     miner.Config{},
 		TxPool:                    legacypool.DefaultConfig,
 		BlobPool:                  blobpool.DefaultConfig,
+		BundlePool:                bundlepool.DefaultConfig,
+		PrivatePool:               privatepool.DefaultConfig,
+		BlobSidecarRetention:      604_800, // Approx. 2 weeks worth of blocks assuming 2s block time
 		RPCGasCap:                 25000000,
 		RPCEVMTimeout:             5 * time.Second,
 		GPO:                       DefaultFullGPOConfig,
@@ -115,8 +120,14 @@ type Config struct {
 	Miner miner.Config
 
 	// Transaction pool options
-	TxPool   legacypool.Config
-	BlobPool blobpool.Config
+	TxPool      legacypool.Config
+	BlobPool    blobpool.Config
+	BundlePool  bundlepool.Config
+	PrivatePool privatepool.Config
+
+	// Number of accepted blocks the sidecars of their blob transactions are
+	// stored for (0 = forever).
+	BlobSidecarRetention uint64
 
 	// Gas Price Oracle options
 	GPO gasprice.Config
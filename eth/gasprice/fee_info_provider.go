@@ -33,6 +33,7 @@ import (
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common/math"
 	lru "github.com/hashicorp/golang-lru"
 )
 
@@ -54,6 +55,7 @@ type feeInfoProvider struct {
 type feeInfo struct {
 	baseFee, tip *big.Int // baseFee and min. suggested tip for tx to be included in the block
 	timestamp    uint64   // timestamp of the block header
+	gasUsed      uint64   // gas used by the block, including its extra data
 }
 
 // newFeeInfoProvider returns a bounded buffer with [size] slots to
@@ -91,6 +93,10 @@ func (f *feeInfoProvider) addHeader(ctx context.Context, header *types.Header) (
 	feeInfo := &feeInfo{
 		timestamp: header.Time,
 		baseFee:   header.BaseFee,
+		gasUsed:   header.GasUsed,
+	}
+	if header.ExtDataGasUsed != nil && header.ExtDataGasUsed.IsUint64() {
+		feeInfo.gasUsed, _ = math.SafeAdd(feeInfo.gasUsed, header.ExtDataGasUsed.Uint64())
 	}
 	// Don't bias the estimate with blocks containing a limited number of transactions paying to
 	// expedite block production.
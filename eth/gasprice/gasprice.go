@@ -99,6 +99,7 @@ type OracleBackend interface {
 	SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription
 	MinRequiredTip(ctx context.Context, header *types.Header) (*big.Int, error)
 	LastAcceptedBlock() *types.Block
+	GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error)
 }
 
 // Oracle recommends gas prices based on the content of recent
@@ -112,10 +113,13 @@ type Oracle struct {
 	// sink to 0 during a period of slow block production, such that nobody's
 	// transactions will be included until the full block fee duration has
 	// elapsed.
-	minPrice  *big.Int
-	maxPrice  *big.Int
-	cacheLock sync.RWMutex
-	fetchLock sync.Mutex
+	minPrice *big.Int
+	maxPrice *big.Int
+	// [minGasUsed] is the gas usage assumed for a block when estimating the tip
+	// required to cover its block gas cost if recent blocks used no gas.
+	minGasUsed *big.Int
+	cacheLock  sync.RWMutex
+	fetchLock  sync.Mutex
 
 	// clock to decide what set of rules to use when recommending a gas price
 	clock mockable.Clock
@@ -197,6 +201,7 @@ func NewOracle(backend OracleBackend, config Config) (*Oracle, error) {
 		lastBaseFee:         DefaultMinBaseFee,
 		minPrice:            minPrice,
 		maxPrice:            maxPrice,
+		minGasUsed:          minGasUsed,
 		checkBlocks:         blocks,
 		percentile:          percent,
 		maxLookbackSeconds:  maxLookbackSeconds,
@@ -252,7 +257,7 @@ func (oracle *Oracle) estimateNextBaseFee(ctx context.Context) (*big.Int, error)
 	// If the block does have a baseFee, calculate the next base fee
 	// based on the current time and add it to the tip to estimate the
 	// total gas price estimate.
-	_, nextBaseFee, err := dummy.EstimateNextBaseFee(oracle.backend.ChainConfig(), header, oracle.clock.Unix())
+	_, nextBaseFee, err := dummy.EstimateNextBaseFee(oracle.backend.ChainConfig(), oracle.backend, header, oracle.clock.Unix())
 	return nextBaseFee, err
 }
 
@@ -154,6 +154,10 @@ func (b *testBackend) MinRequiredTip(ctx context.Context, header *types.Header)
 	return dummy.MinRequiredTip(b.chain.Config(), header)
 }
 
+func (b *testBackend) GetFeeConfigAt(parent *types.Header) (*params.FeeConfig, error) {
+	return b.chain.GetFeeConfigAt(parent)
+}
+
 func (b *testBackend) CurrentHeader() *types.Header {
 	return b.chain.CurrentHeader()
 }
@@ -393,3 +397,37 @@ func TestSuggestTipCapMaxBlocksSecondsLookback(t *testing.T) {
 		expectedTip:     big.NewInt(92_212_529_423),
 	}, timeCrunchOracleConfig())
 }
+
+func TestSuggestTipCapsByLatency(t *testing.T) {
+	require := require.New(t)
+	backend := newTestBackend(t, params.TestChainConfig, 3, common.Big0, testGenBlock(t, 55, 370))
+	defer backend.teardown()
+
+	oracle, err := NewOracle(backend, defaultOracleConfig())
+	require.NoError(err)
+	head := backend.chain.CurrentBlock()
+	oracle.clock.Set(time.Unix(int64(head.Time), 0))
+
+	tips, err := oracle.SuggestTipCapsByLatency(context.Background(), []uint64{0, 1, 2, 10, 100})
+	require.NoError(err)
+	require.Len(tips, 5)
+
+	// A block built immediately must cover an increased block gas cost, spread
+	// over the gas used by recent blocks.
+	gasUsed := new(big.Int).SetUint64(head.GasUsed)
+	requiredTip := new(big.Int).Mul(tips[0].BlockGasCost, tips[0].BaseFee)
+	requiredTip.Div(requiredTip, gasUsed)
+	require.Positive(tips[0].BlockGasCost.Cmp(head.BlockGasCost))
+	require.Zero(requiredTip.Cmp(tips[0].Tip))
+
+	// The later the block is built, the lower its block gas cost and tip.
+	for i := 1; i < len(tips); i++ {
+		require.LessOrEqual(tips[i].BlockGasCost.Cmp(tips[i-1].BlockGasCost), 0)
+		require.LessOrEqual(tips[i].Tip.Cmp(tips[i-1].Tip), 0)
+	}
+	require.Zero(tips[4].BlockGasCost.Sign())
+	require.Zero(tips[4].Tip.Sign())
+
+	_, err = oracle.SuggestTipCapsByLatency(context.Background(), []uint64{0, maxLatency + 1})
+	require.ErrorIs(err, errLatencyTooHigh)
+}
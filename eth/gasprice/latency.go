@@ -0,0 +1,130 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"golang.org/x/exp/slices"
+)
+
+// maxLatency is the maximum number of seconds from now a tip is suggested for.
+// The block gas cost decays to zero well before then.
+const maxLatency = 24 * 60 * 60
+
+var errLatencyTooHigh = errors.New("latency too high")
+
+// LatencyTip is the tip suggested for a transaction to be included in a block
+// built a given number of seconds from now.
+type LatencyTip struct {
+	Latency      uint64   // Seconds from now until the block is built
+	BaseFee      *big.Int // Estimated base fee of the block, nil before ApricotPhase3
+	BlockGasCost *big.Int // Block gas cost of the block, nil before ApricotPhase4
+	Tip          *big.Int // Suggested tip
+}
+
+// SuggestTipCapsByLatency returns the tips suggested for a transaction to be
+// included in a block built [latencies] seconds from now.
+//
+// Since ApricotPhase4, the tips of a block must cover its block gas cost, which
+// rises when blocks are built faster than the target block rate and decays
+// afterwards. The earlier a block is built after the latest block, the higher
+// the tip required to build it. The required tip is estimated by spreading the
+// block gas cost, priced at the estimated base fee, over the gas recently used
+// by blocks.
+func (oracle *Oracle) SuggestTipCapsByLatency(ctx context.Context, latencies []uint64) ([]*LatencyTip, error) {
+	for _, latency := range latencies {
+		if latency > maxLatency {
+			return nil, fmt.Errorf("%w: %d > %d", errLatencyTooHigh, latency, maxLatency)
+		}
+	}
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	gasUsed, err := oracle.recentGasUsed(ctx, head.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks are never built before the latest block.
+	now := oracle.clock.Unix()
+	if now < head.Time {
+		now = head.Time
+	}
+	if now > math.MaxUint64-maxLatency {
+		return nil, fmt.Errorf("%w: timestamp %d overflows", errLatencyTooHigh, now)
+	}
+	config := oracle.backend.ChainConfig()
+	tips := make([]*LatencyTip, len(latencies))
+	for i, latency := range latencies {
+		timestamp := now + latency
+		tip := &LatencyTip{
+			Latency: latency,
+			Tip:     new(big.Int).Set(oracle.minPrice),
+		}
+		tip.BlockGasCost, err = dummy.EstimateBlockGasCost(config, oracle.backend, head, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		if head.BaseFee != nil {
+			_, tip.BaseFee, err = dummy.EstimateNextBaseFee(config, oracle.backend, head, timestamp)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if tip.BlockGasCost != nil && tip.BaseFee != nil {
+			requiredBlockFee := new(big.Int).Mul(tip.BlockGasCost, tip.BaseFee)
+			requiredTip := requiredBlockFee.Div(requiredBlockFee, gasUsed)
+			if requiredTip.Cmp(tip.Tip) > 0 {
+				tip.Tip = requiredTip
+			}
+		}
+		if tip.Tip.Cmp(oracle.maxPrice) > 0 {
+			tip.Tip = new(big.Int).Set(oracle.maxPrice)
+		}
+		tips[i] = tip
+	}
+	return tips, nil
+}
+
+// recentGasUsed returns the median gas used by the blocks sampled for gas price
+// estimation up to block [latest]. Returns [minGasUsed] if they used no gas.
+func (oracle *Oracle) recentGasUsed(ctx context.Context, latest uint64) (*big.Int, error) {
+	var (
+		lowerBlockNumberLimit = uint64(0)
+		currentTime           = oracle.clock.Unix()
+		gasUsedResults        []uint64
+	)
+	if uint64(oracle.checkBlocks) <= latest {
+		lowerBlockNumberLimit = latest - uint64(oracle.checkBlocks)
+	}
+	for i := latest; i > lowerBlockNumberLimit; i-- {
+		feeInfo, err := oracle.getFeeInfo(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		if feeInfo.timestamp+oracle.maxLookbackSeconds < currentTime {
+			break
+		}
+		gasUsedResults = append(gasUsedResults, feeInfo.gasUsed)
+	}
+	var gasUsed uint64
+	if len(gasUsedResults) > 0 {
+		slices.Sort(gasUsedResults)
+		gasUsed = gasUsedResults[(len(gasUsedResults)-1)/2]
+	}
+	if gasUsed == 0 {
+		// Avoid dividing by zero if [minGasUsed] is configured to zero.
+		return math.BigMax(oracle.minGasUsed, common.Big1), nil
+	}
+	return new(big.Int).SetUint64(gasUsed), nil
+}
@@ -0,0 +1,116 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracetest
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/eth/tracers"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contracts/nativeminter"
+	"github.com/ava-labs/coreth/tests"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPrecompileTracers(t *testing.T) {
+	var (
+		origin    = common.HexToAddress("0x00000000000000000000000000000000feed")
+		recipient = common.HexToAddress("0x00000000000000000000000000000000cafe")
+		to        = nativeminter.ContractAddress
+		txContext = vm.TxContext{
+			Origin:   origin,
+			GasPrice: big.NewInt(1),
+		}
+		context = vm.BlockContext{
+			CanTransfer: core.CanTransfer,
+			Transfer:    core.Transfer,
+			Coinbase:    common.Address{},
+			BlockNumber: new(big.Int).SetUint64(1),
+			Time:        5,
+			Difficulty:  big.NewInt(0x30000),
+			GasLimit:    uint64(6000000),
+			BaseFee:     big.NewInt(0),
+		}
+	)
+	config := *params.TestChainConfig
+	config.PrecompileUpgrades = []params.PrecompileUpgrade{
+		{Config: nativeminter.NewConfig(utils.NewUint64(0), nil, nil, nil, nil)},
+	}
+	input, err := nativeminter.PackMintNativeCoin(recipient, big.NewInt(0x100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mkTracer := func(name string, cfg json.RawMessage) tracers.Tracer {
+		tr, err := tracers.DefaultDirectory.New(name, nil, cfg)
+		if err != nil {
+			t.Fatalf("failed to create tracer: %v", err)
+		}
+		return tr
+	}
+
+	for _, tc := range []struct {
+		name   string
+		tracer tracers.Tracer
+		want   string
+	}{
+		{
+			name:   "callTracer",
+			tracer: mkTracer("callTracer", json.RawMessage(`{ "withLog": true }`)),
+			want:   `{"from":"0x000000000000000000000000000000000000feed","gas":"0x186a0","gasUsed":"0xf688","to":"0x0200000000000000000000000000000000000001","input":"0x4f5aaaba000000000000000000000000000000000000000000000000000000000000cafe0000000000000000000000000000000000000000000000000000000000000100","logs":[{"address":"0x0200000000000000000000000000000000000001","topics":["0x400cd392f3d56fd10bb1dbd5839fdda8298208ddaa97b368faa053e1850930ee","0x000000000000000000000000000000000000000000000000000000000000feed","0x000000000000000000000000000000000000000000000000000000000000cafe"],"data":"0x0000000000000000000000000000000000000000000000000000000000000100","position":"0x0"}],"precompile":{"method":"mintNativeCoin","args":[{"name":"addr","type":"address","value":"0x000000000000000000000000000000000000cafe"},{"name":"amount","type":"uint256","value":"0x100"}],"storage":[{"address":"0x0200000000000000000000000000000000000001","slot":"0x000000000000000000000000000000000000000000000000000000000000feed","before":"0x0000000000000000000000000000000000000000000000000000000000000001","after":"0x0000000000000000000000000000000000000000000000000000000000000001"},{"address":"0x0200000000000000000000000000000000000001","slot":"0x746d730000000000000000000000000000000000000000000000000000000000","before":"0x0000000000000000000000000000000000000000000000000000000000000000","after":"0x0000000000000000000000000000000000000000000000000000000000000100"}]},"value":"0x0","type":"CALL"}`,
+		},
+		{
+			name:   "prestateTracer",
+			tracer: mkTracer("prestateTracer", nil),
+			want:   `{"0x0000000000000000000000000000000000000000":{"balance":"0x0"},"0x000000000000000000000000000000000000cafe":{"balance":"0x0"},"0x000000000000000000000000000000000000feed":{"balance":"0x1c6bf5264c6a0"},"0x0200000000000000000000000000000000000001":{"balance":"0x0","storage":{"0x000000000000000000000000000000000000000000000000000000000000feed":"0x0000000000000000000000000000000000000000000000000000000000000001","0x746d730000000000000000000000000000000000000000000000000000000000":"0x0000000000000000000000000000000000000000000000000000000000000000"}}}`,
+		},
+		{
+			name:   "prestateTracer diffMode",
+			tracer: mkTracer("prestateTracer", json.RawMessage(`{ "diffMode": true }`)),
+			want:   `{"post":{"0x000000000000000000000000000000000000cafe":{"balance":"0x100"},"0x000000000000000000000000000000000000feed":{"balance":"0x1c6bf52634000","nonce":1},"0x0200000000000000000000000000000000000001":{"storage":{"0x746d730000000000000000000000000000000000000000000000000000000000":"0x0000000000000000000000000000000000000000000000000000000000000100"}}},"pre":{"0x000000000000000000000000000000000000cafe":{"balance":"0x0"},"0x000000000000000000000000000000000000feed":{"balance":"0x1c6bf5264c6a0"},"0x0200000000000000000000000000000000000001":{"balance":"0x0"}}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := tests.MakePreState(rawdb.NewMemoryDatabase(),
+				types.GenesisAlloc{
+					origin: types.GenesisAccount{
+						Balance: big.NewInt(500000000000000),
+					},
+				}, false, rawdb.HashScheme)
+			defer state.Close()
+			nativeminter.SetContractNativeMinterStatus(state.StateDB, origin, allowlist.EnabledRole)
+
+			evm := vm.NewEVM(context, txContext, state.StateDB, &config, vm.Config{Tracer: tc.tracer})
+			msg := &core.Message{
+				To:                &to,
+				From:              origin,
+				Value:             big.NewInt(0),
+				Data:              input,
+				GasLimit:          100000,
+				GasPrice:          big.NewInt(0),
+				GasFeeCap:         big.NewInt(0),
+				GasTipCap:         big.NewInt(0),
+				SkipAccountChecks: false,
+			}
+			st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+			if _, err := st.TransitionDb(); err != nil {
+				t.Fatalf("failed to execute transaction: %v", err)
+			}
+			res, err := tc.tracer.GetResult()
+			if err != nil {
+				t.Fatalf("failed to retrieve trace result: %v", err)
+			}
+			if string(res) != tc.want {
+				t.Errorf("trace mismatch\n have: %v\n want: %v\n", string(res), tc.want)
+			}
+		})
+	}
+}
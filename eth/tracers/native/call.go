@@ -68,6 +68,7 @@ type callFrame struct {
 	RevertReason string          `json:"revertReason,omitempty"`
 	Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
 	Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
+	Precompile   *precompileCall `json:"precompile,omitempty" rlp:"-"`
 	// Placed at end on purpose. The RLP will be decoded to 0 instead of
 	// nil if there are non-empty elements after in the struct.
 	Value *big.Int `json:"value,omitempty" rlp:"optional"`
@@ -254,6 +255,32 @@ func (t *callTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 	t.callstack[size-1].Calls = append(t.callstack[size-1].Calls, call)
 }
 
+// CapturePrecompile implements the vm.PrecompileTracer interface to decorate
+// the frame of a precompile with its decoded call, and collect its logs.
+func (t *callTracer) CapturePrecompile(call *vm.PrecompileCall, depth int) {
+	// Avoid processing nested calls when only caring about top call
+	if t.config.OnlyTopCall && depth > 1 {
+		return
+	}
+	// Skip if tracing was interrupted
+	if t.interrupt.Load() {
+		return
+	}
+	frame := &t.callstack[len(t.callstack)-1]
+	frame.Precompile = newPrecompileCall(call)
+	if !t.config.WithLog {
+		return
+	}
+	for _, l := range call.Logs {
+		frame.Logs = append(frame.Logs, callLog{
+			Address:  l.Address,
+			Topics:   l.Topics,
+			Data:     hexutil.Bytes(l.Data),
+			Position: hexutil.Uint(len(frame.Calls)),
+		})
+	}
+}
+
 func (t *callTracer) CaptureTxStart(gasLimit uint64) {
 	t.gasLimit = gasLimit
 }
@@ -111,6 +111,16 @@ func (t *muxTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 	}
 }
 
+// CapturePrecompile forwards the execution of a precompile to the tracers
+// implementing vm.PrecompileTracer.
+func (t *muxTracer) CapturePrecompile(call *vm.PrecompileCall, depth int) {
+	for _, t := range t.tracers {
+		if pt, ok := t.(vm.PrecompileTracer); ok {
+			pt.CapturePrecompile(call, depth)
+		}
+	}
+}
+
 func (t *muxTracer) CaptureTxStart(gasLimit uint64) {
 	for _, t := range t.tracers {
 		t.CaptureTxStart(gasLimit)
@@ -0,0 +1,83 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package native
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// precompileCall is the rendering of a vm.PrecompileCall in a call frame.
+type precompileCall struct {
+	Method     string                `json:"method,omitempty"`
+	Args       []precompileArg       `json:"args,omitempty"`
+	Predicates []precompilePredicate `json:"predicates,omitempty"`
+	Storage    []precompileStorage   `json:"storage,omitempty"`
+}
+
+type precompileArg struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type precompilePredicate struct {
+	Address common.Address `json:"address"`
+	Index   int            `json:"index"`
+	Valid   bool           `json:"valid"`
+}
+
+type precompileStorage struct {
+	Address common.Address `json:"address"`
+	Slot    common.Hash    `json:"slot"`
+	Before  common.Hash    `json:"before"`
+	After   common.Hash    `json:"after"`
+}
+
+func newPrecompileCall(call *vm.PrecompileCall) *precompileCall {
+	res := &precompileCall{Method: call.Method}
+	for _, arg := range call.Args {
+		res.Args = append(res.Args, precompileArg{
+			Name:  arg.Name,
+			Type:  arg.Type,
+			Value: formatPrecompileArg(arg.Value),
+		})
+	}
+	for _, predicate := range call.Predicates {
+		res.Predicates = append(res.Predicates, precompilePredicate(predicate))
+	}
+	for _, access := range call.Storage {
+		res.Storage = append(res.Storage, precompileStorage(access))
+	}
+	return res
+}
+
+// formatPrecompileArg returns [value], decoded from an ABI, with its numbers
+// and bytes in hex, as in the rest of the trace.
+func formatPrecompileArg(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return (*hexutil.Big)(v)
+	case []byte:
+		return hexutil.Bytes(v)
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8:
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Bytes(b)
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		values := make([]interface{}, rv.Len())
+		for i := range values {
+			values[i] = formatPrecompileArg(rv.Index(i).Interface())
+		}
+		return values
+	}
+	return value
+}
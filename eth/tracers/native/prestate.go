@@ -189,6 +189,35 @@ func (t *prestateTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64,
 	}
 }
 
+// CapturePrecompile implements the vm.PrecompileTracer interface to add the
+// accounts and storage slots accessed by a precompile to the prestate. The
+// precompile already ran, so their values before its execution are taken from
+// [call] rather than from the state.
+func (t *prestateTracer) CapturePrecompile(call *vm.PrecompileCall, depth int) {
+	// Skip if tracing was interrupted
+	if t.interrupt.Load() {
+		return
+	}
+	for _, acc := range call.Accounts {
+		if _, ok := t.pre[acc.Address]; ok {
+			continue
+		}
+		t.pre[acc.Address] = &account{
+			Balance: acc.Balance.ToBig(),
+			Nonce:   acc.Nonce,
+			Code:    t.env.StateDB.GetCode(acc.Address),
+			Storage: make(map[common.Hash]common.Hash),
+		}
+	}
+	for _, access := range call.Storage {
+		t.lookupAccount(access.Address)
+		if _, ok := t.pre[access.Address].Storage[access.Slot]; ok {
+			continue
+		}
+		t.pre[access.Address].Storage[access.Slot] = access.Before
+	}
+}
+
 func (t *prestateTracer) CaptureTxStart(gasLimit uint64) {
 	t.gasLimit = gasLimit
 }
@@ -43,6 +43,8 @@ type fakePushGossiper struct{}
 
 func (*fakePushGossiper) Add(*types.Transaction) {}
 
+func (*fakePushGossiper) LastGossiped(common.Hash) (time.Time, bool) { return time.Time{}, false }
+
 // Client exposes the methods provided by the Ethereum RPC client.
 type Client interface {
 	interfaces.BlockNumberReader
@@ -104,6 +104,45 @@ func (s *EthereumAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, e
 	return (*hexutil.Big)(tipcap), err
 }
 
+// maxLatencies is the maximum number of latencies a tip is suggested for at once.
+const maxLatencies = 32
+
+type latencyTipResult struct {
+	Latency              hexutil.Uint64 `json:"latency"`
+	BaseFee              *hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	BlockGasCost         *hexutil.Big   `json:"blockGasCost,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+}
+
+// MaxPriorityFeePerGasByLatency returns suggestions for a gas tip cap for dynamic
+// fee transactions to be included in a block built the given numbers of seconds
+// from now, along with the base fee and block gas cost expected for that block.
+// The sooner the block is built after its parent, the higher its block gas cost
+// and the tip required to cover it.
+func (s *EthereumAPI) MaxPriorityFeePerGasByLatency(ctx context.Context, latencies []math.HexOrDecimal64) ([]*latencyTipResult, error) {
+	if len(latencies) > maxLatencies {
+		return nil, fmt.Errorf("too many latencies: %d > %d", len(latencies), maxLatencies)
+	}
+	secs := make([]uint64, len(latencies))
+	for i, latency := range latencies {
+		secs[i] = uint64(latency)
+	}
+	baseFees, blockGasCosts, tips, err := s.b.SuggestGasTipCapsByLatency(ctx, secs)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*latencyTipResult, len(latencies))
+	for i, latency := range secs {
+		results[i] = &latencyTipResult{
+			Latency:              hexutil.Uint64(latency),
+			BaseFee:              (*hexutil.Big)(baseFees[i]),
+			BlockGasCost:         (*hexutil.Big)(blockGasCosts[i]),
+			MaxPriorityFeePerGas: (*hexutil.Big)(tips[i]),
+		}
+	}
+	return results, nil
+}
+
 type feeHistoryResult struct {
 	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
 	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
@@ -0,0 +1,83 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"context"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// RPCBlobSidecar is the sidecar of a blob transaction included in an accepted
+// block, as served by the blob sidecar API.
+type RPCBlobSidecar struct {
+	BlockHash        common.Hash          `json:"blockHash"`
+	BlockNumber      hexutil.Uint64       `json:"blockNumber"`
+	TxHash           common.Hash          `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64       `json:"transactionIndex"`
+	BlobHashes       []common.Hash        `json:"blobVersionedHashes"`
+	Blobs            []kzg4844.Blob       `json:"blobs"`
+	Commitments      []kzg4844.Commitment `json:"commitments"`
+	Proofs           []kzg4844.Proof      `json:"proofs"`
+}
+
+func newRPCBlobSidecar(tx *types.Transaction, sidecar *types.BlobTxSidecar, blockHash common.Hash, blockNumber uint64, index uint64) *RPCBlobSidecar {
+	return &RPCBlobSidecar{
+		BlockHash:        blockHash,
+		BlockNumber:      hexutil.Uint64(blockNumber),
+		TxHash:           tx.Hash(),
+		TransactionIndex: hexutil.Uint64(index),
+		BlobHashes:       tx.BlobHashes(),
+		Blobs:            sidecar.Blobs,
+		Commitments:      sidecar.Commitments,
+		Proofs:           sidecar.Proofs,
+	}
+}
+
+// GetBlobSidecars returns the sidecars of the blob transactions included in the
+// given block, skipping those whose sidecar was pruned or is not stored by this
+// node. Returns null if the block does not exist.
+func (s *BlockChainAPI) GetBlobSidecars(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*RPCBlobSidecar, error) {
+	block, err := s.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	result := make([]*RPCBlobSidecar, 0)
+	for i, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		sidecar, err := s.b.GetBlobSidecar(ctx, block.NumberU64(), tx.Hash())
+		if err != nil {
+			return nil, err
+		}
+		if sidecar == nil {
+			continue
+		}
+		result = append(result, newRPCBlobSidecar(tx, sidecar, block.Hash(), block.NumberU64(), uint64(i)))
+	}
+	return result, nil
+}
+
+// GetBlobSidecarByTxHash returns the sidecar of the given blob transaction, or
+// null if the transaction is not included in an accepted block or its sidecar
+// was pruned.
+func (s *BlockChainAPI) GetBlobSidecarByTxHash(ctx context.Context, hash common.Hash) (*RPCBlobSidecar, error) {
+	found, tx, blockHash, blockNumber, index, err := s.b.GetTransaction(ctx, hash)
+	if !found || tx == nil || err != nil {
+		return nil, err
+	}
+	if tx.Type() != types.BlobTxType {
+		return nil, nil
+	}
+	sidecar, err := s.b.GetBlobSidecar(ctx, blockNumber, hash)
+	if sidecar == nil || err != nil {
+		return nil, err
+	}
+	return newRPCBlobSidecar(tx, sidecar, blockHash, blockNumber, index), nil
+}
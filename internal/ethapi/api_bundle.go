@@ -0,0 +1,88 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// BundleAPI provides an API to submit bundles of transactions which are
+// included in their target block atomically or not at all.
+type BundleAPI struct {
+	b Backend
+}
+
+// NewBundleAPI creates a new bundle API.
+func NewBundleAPI(b Backend) *BundleAPI {
+	return &BundleAPI{b}
+}
+
+// SendBundleArgs represents the arguments of eth_sendBundle.
+type SendBundleArgs struct {
+	Txs          []hexutil.Bytes `json:"txs"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp *hexutil.Uint64 `json:"minTimestamp"`
+	MaxTimestamp *hexutil.Uint64 `json:"maxTimestamp"`
+}
+
+// SendBundleResult is the result of eth_sendBundle.
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// toBundle decodes the signed transactions of the bundle.
+func (args *SendBundleArgs) toBundle() (*bundlepool.Bundle, error) {
+	bundle := &bundlepool.Bundle{
+		Txs:         make(types.Transactions, len(args.Txs)),
+		BlockNumber: uint64(args.BlockNumber),
+	}
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+		bundle.Txs[i] = tx
+	}
+	if args.MinTimestamp != nil {
+		bundle.MinTimestamp = uint64(*args.MinTimestamp)
+	}
+	if args.MaxTimestamp != nil {
+		bundle.MaxTimestamp = uint64(*args.MaxTimestamp)
+	}
+	return bundle, nil
+}
+
+// SendBundle submits a bundle of signed transactions to be included in order
+// in the block with the given number, and, if given, with a timestamp within
+// the given bounds. Either all transactions of the bundle are included without
+// reverting, or none of them are.
+func (s *BundleAPI) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	bundle, err := args.toBundle()
+	if err != nil {
+		return nil, err
+	}
+	for i, tx := range bundle.Txs {
+		if err := checkTxFee(tx.GasPrice(), tx.Gas(), s.b.RPCTxFeeCap()); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		if !s.b.UnprotectedAllowed(tx) && !tx.Protected() {
+			// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
+			return nil, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+		}
+	}
+	if err := s.b.SendBundle(ctx, bundle); err != nil {
+		return nil, err
+	}
+	hash := bundle.Hash()
+	log.Info("Submitted bundle", "hash", hash, "txs", len(bundle.Txs), "block", bundle.BlockNumber, "minTimestamp", bundle.MinTimestamp, "maxTimestamp", bundle.MaxTimestamp)
+	return &SendBundleResult{BundleHash: hash}, nil
+}
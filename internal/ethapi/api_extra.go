@@ -9,6 +9,9 @@ import (
 
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/nativeminter"
+	"github.com/ava-labs/coreth/precompile/modules"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
 	"github.com/ava-labs/coreth/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -20,6 +23,70 @@ func (api *BlockChainAPI) GetChainConfig(ctx context.Context) *params.ChainConfi
 	return api.b.ChainConfig()
 }
 
+// GetNativeMintedSupply returns the total amount of native coin minted by the
+// native minter precompile in the state of the given block, including the
+// initial mints of its config.
+func (s *BlockChainAPI) GetNativeMintedSupply(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(nativeminter.GetTotalMinted(state)), state.Error()
+}
+
+// GetActivePrecompilesAt returns the configs of the stateful precompiles
+// enabled at [blockTimestamp], keyed by the config key of their module.
+// If [blockTimestamp] is not given, the timestamp of the current head is used.
+func (s *BlockChainAPI) GetActivePrecompilesAt(ctx context.Context, blockTimestamp *uint64) params.Precompiles {
+	return s.b.ChainConfig().EnabledStatefulPrecompiles(s.precompileTimestamp(blockTimestamp))
+}
+
+// PrecompileConfigResult is the configuration of a stateful precompile at a
+// block timestamp.
+type PrecompileConfigResult struct {
+	ConfigKey string         `json:"configKey"`
+	Address   common.Address `json:"address"`
+	Enabled   bool           `json:"enabled"`
+	// Config is the config in effect, if the precompile is enabled.
+	Config precompileconfig.Config `json:"config,omitempty"`
+	// Upgrades are the upgrades of the precompile activated so far, including
+	// the ones disabling it, in activation order.
+	Upgrades []precompileconfig.Config `json:"upgrades"`
+}
+
+// GetPrecompileConfig returns the configuration at [blockTimestamp] of the
+// stateful precompile at [address], along with its activation history.
+// If [blockTimestamp] is not given, the timestamp of the current head is used.
+func (s *BlockChainAPI) GetPrecompileConfig(ctx context.Context, address common.Address, blockTimestamp *uint64) (*PrecompileConfigResult, error) {
+	module, ok := modules.GetPrecompileModuleByAddress(address)
+	if !ok {
+		return nil, fmt.Errorf("no precompile module registered at %s", address)
+	}
+	config := s.b.ChainConfig()
+	upgrades := config.GetActivatingPrecompileConfigs(address, nil, s.precompileTimestamp(blockTimestamp), config.PrecompileUpgrades)
+	result := &PrecompileConfigResult{
+		ConfigKey: module.ConfigKey,
+		Address:   address,
+		Upgrades:  upgrades,
+	}
+	if len(upgrades) > 0 {
+		if latest := upgrades[len(upgrades)-1]; !latest.IsDisabled() {
+			result.Enabled = true
+			result.Config = latest
+		}
+	}
+	return result, nil
+}
+
+// precompileTimestamp returns [blockTimestamp], or the timestamp of the
+// current head if it is nil.
+func (s *BlockChainAPI) precompileTimestamp(blockTimestamp *uint64) uint64 {
+	if blockTimestamp != nil {
+		return *blockTimestamp
+	}
+	return s.b.CurrentHeader().Time
+}
+
 type DetailedExecutionResult struct {
 	UsedGas    uint64        `json:"gas"`        // Total used gas but include the refunded gas
 	ErrCode    int           `json:"errCode"`    // EVM error code
@@ -0,0 +1,79 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/nativeminter"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPrecompileConfig(t *testing.T) {
+	t.Parallel()
+
+	var (
+		admin   = common.HexToAddress("0x0100000000000000000000000000000000000000")
+		enable  = nativeminter.NewConfig(utils.NewUint64(100), []common.Address{admin}, nil, nil, nil)
+		disable = nativeminter.NewDisableConfig(utils.NewUint64(200))
+		config  = *params.TestChainConfig
+	)
+	config.PrecompileUpgrades = []params.PrecompileUpgrade{{Config: enable}, {Config: disable}}
+	api := NewBlockChainAPI(newTestBackend(t, 0, &core.Genesis{Config: &config, Alloc: types.GenesisAlloc{}}, dummy.NewCoinbaseFaker(), nil))
+
+	tests := []struct {
+		name      string
+		timestamp *uint64
+		enabled   bool
+		upgrades  []precompileconfig.Config
+	}{
+		{
+			name:     "head before activation",
+			upgrades: []precompileconfig.Config{},
+		},
+		{
+			name:      "enabled",
+			timestamp: utils.NewUint64(150),
+			enabled:   true,
+			upgrades:  []precompileconfig.Config{enable},
+		},
+		{
+			name:      "disabled",
+			timestamp: utils.NewUint64(200),
+			upgrades:  []precompileconfig.Config{enable, disable},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			res, err := api.GetPrecompileConfig(context.Background(), nativeminter.ContractAddress, test.timestamp)
+			require.NoError(err)
+			require.Equal(nativeminter.ConfigKey, res.ConfigKey)
+			require.Equal(nativeminter.ContractAddress, res.Address)
+			require.Equal(test.enabled, res.Enabled)
+			require.Equal(test.upgrades, res.Upgrades)
+
+			active := api.GetActivePrecompilesAt(context.Background(), test.timestamp)
+			if test.enabled {
+				require.Equal(enable, res.Config)
+				require.Equal(params.Precompiles{nativeminter.ConfigKey: enable}, active)
+			} else {
+				require.Nil(res.Config)
+				require.Empty(active)
+			}
+		})
+	}
+
+	_, err := api.GetPrecompileConfig(context.Background(), common.HexToAddress("0x0300000000000000000000000000000000000000"), nil)
+	require.ErrorContains(t, err, "no precompile module registered")
+}
@@ -0,0 +1,69 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// PrivateTxAPI provides an API to submit transactions which are held only by
+// this node for inclusion in the blocks it builds, without being gossiped.
+type PrivateTxAPI struct {
+	b Backend
+}
+
+// NewPrivateTxAPI creates a new private transaction API.
+func NewPrivateTxAPI(b Backend) *PrivateTxAPI {
+	return &PrivateTxAPI{b}
+}
+
+// PrivateTxArgs represents the options of eth_sendPrivateRawTransaction.
+type PrivateTxArgs struct {
+	// Last block the transaction may be included in before it is dropped,
+	// defaults to the lifetime configured for the node.
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber"`
+	// Number of blocks after which the transaction is gossiped publicly if it
+	// was not included yet, never if unset or zero.
+	FallbackBlocks *hexutil.Uint64 `json:"fallbackBlocks"`
+}
+
+// SendPrivateRawTransaction submits a signed transaction which is held only by
+// this node, without being pushed or pulled by peers, and included when this
+// node builds a block. The transaction is dropped after [opts.MaxBlockNumber],
+// unless [opts.FallbackBlocks] blocks were built before, in which case it is
+// gossiped publicly like any other transaction.
+func (s *PrivateTxAPI) SendPrivateRawTransaction(ctx context.Context, input hexutil.Bytes, opts *PrivateTxArgs) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkTxFee(tx.GasPrice(), tx.Gas(), s.b.RPCTxFeeCap()); err != nil {
+		return common.Hash{}, err
+	}
+	if !s.b.UnprotectedAllowed(tx) && !tx.Protected() {
+		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
+		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+	ptx := &privatepool.PrivateTx{Tx: tx}
+	if opts != nil {
+		if opts.MaxBlockNumber != nil {
+			ptx.MaxBlockNumber = uint64(*opts.MaxBlockNumber)
+		}
+		if opts.FallbackBlocks != nil && *opts.FallbackBlocks != 0 {
+			ptx.FallbackBlockNumber = s.b.CurrentHeader().Number.Uint64() + uint64(*opts.FallbackBlocks)
+		}
+	}
+	if err := s.b.SendPrivateTx(ctx, ptx); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Submitted private transaction", "hash", tx.Hash(), "nonce", tx.Nonce(), "maxBlock", ptx.MaxBlockNumber, "fallbackBlock", ptx.FallbackBlockNumber)
+	return tx.Hash(), nil
+}
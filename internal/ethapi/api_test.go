@@ -49,6 +49,9 @@ import (
 	"github.com/ava-labs/coreth/core/bloombits"
 	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/internal/blocktest"
@@ -57,6 +60,7 @@ import (
 	"github.com/ava-labs/coreth/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -557,6 +561,9 @@ func (b testBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.R
 	receipts := rawdb.ReadReceipts(b.db, hash, header.Number.Uint64(), header.Time, b.chain.Config())
 	return receipts, nil
 }
+func (b testBackend) GetBlobSidecar(ctx context.Context, number uint64, txHash common.Hash) (*types.BlobTxSidecar, error) {
+	return rawdb.ReadBlobSidecar(b.db, number, txHash), nil
+}
 func (b testBackend) GetEVM(ctx context.Context, msg *core.Message, state *state.StateDB, header *types.Header, vmConfig *vm.Config, blockContext *vm.BlockContext) *vm.EVM {
 	if vmConfig == nil {
 		vmConfig = b.chain.GetVMConfig()
@@ -580,6 +587,30 @@ func (b testBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) even
 func (b testBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	panic("implement me")
 }
+func (b testBackend) SendBundle(ctx context.Context, bundle *bundlepool.Bundle) error {
+	panic("implement me")
+}
+func (b testBackend) SendPrivateTx(ctx context.Context, ptx *privatepool.PrivateTx) error {
+	panic("implement me")
+}
+func (b testBackend) SuggestGasTipCapsByLatency(ctx context.Context, latencies []uint64) ([]*big.Int, []*big.Int, []*big.Int, error) {
+	panic("implement me")
+}
+func (b testBackend) TxPoolStatus(txHash common.Hash) txpool.TxStatus {
+	panic("implement me")
+}
+func (b testBackend) TxPoolDropped(txHash common.Hash) (txpool.DroppedTx, bool) {
+	panic("implement me")
+}
+func (b testBackend) SubscribeTxStatusEvent(ch chan<- txpool.TxStatusEvent) event.Subscription {
+	panic("implement me")
+}
+func (b testBackend) TxLastGossiped(txHash common.Hash) (time.Time, bool) {
+	panic("implement me")
+}
+func (b testBackend) MinRequiredTip(ctx context.Context, header *types.Header) (*big.Int, error) {
+	panic("implement me")
+}
 func (b testBackend) GetTransaction(ctx context.Context, txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64, error) {
 	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(b.db, txHash)
 	return true, tx, blockHash, blockNumber, index, nil
@@ -626,6 +657,12 @@ func (b testBackend) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	panic("implement me")
 }
 
+func TestMaxPriorityFeePerGasByLatencyLimit(t *testing.T) {
+	api := NewEthereumAPI(testBackend{})
+	_, err := api.MaxPriorityFeePerGasByLatency(context.Background(), make([]math.HexOrDecimal64, maxLatencies+1))
+	require.ErrorContains(t, err, "too many latencies")
+}
+
 func TestEstimateGas(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -0,0 +1,173 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Statuses reported for transactions by the txpool status API.
+const (
+	txStatusPending  = "pending"
+	txStatusQueued   = "queued"
+	txStatusIncluded = "included"
+	txStatusDropped  = "dropped"
+	txStatusUnknown  = "unknown"
+)
+
+// RPCTxStatus describes where a transaction stands in the transaction pool.
+type RPCTxStatus struct {
+	Status    string          `json:"status"`
+	Reason    string          `json:"reason,omitempty"`    // Why the transaction was dropped, if it was
+	DroppedAt *hexutil.Uint64 `json:"droppedAt,omitempty"` // Unix time the transaction was dropped at
+
+	// Accepted block including the transaction, if it left the pool for it
+	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
+	BlockNumber *hexutil.Uint64 `json:"blockNumber,omitempty"`
+
+	// Fee position of a transaction held by the pool
+	GasFeeCap         *hexutil.Big `json:"maxFeePerGas,omitempty"`
+	GasTipCap         *hexutil.Big `json:"maxPriorityFeePerGas,omitempty"`
+	BaseFee           *hexutil.Big `json:"baseFee,omitempty"`        // Estimated base fee of the next block
+	MinRequiredTip    *hexutil.Big `json:"minRequiredTip,omitempty"` // Tip required to cover the block gas cost of the current head
+	CoversBaseFee     *bool        `json:"coversBaseFee,omitempty"`
+	CoversRequiredTip *bool        `json:"coversRequiredTip,omitempty"`
+
+	LastGossiped *hexutil.Uint64 `json:"lastGossiped,omitempty"` // Unix time the transaction was last sent to peers
+}
+
+// RPCTxStatusEvent is sent to txpool status subscribers when a transaction
+// enters the pool, moves between its pending and queued sets, or is dropped.
+type RPCTxStatusEvent struct {
+	Hash   common.Hash `json:"hash"`
+	Status string      `json:"status"`
+	Reason string      `json:"reason,omitempty"`
+}
+
+// TxStatus returns where the transaction with the given hash stands in the
+// transaction pool: whether it is pending, queued, included in an accepted
+// block or was dropped and why, how
+// its fees compare to the next base fee and the tip required to cover the block
+// gas cost, and when it was last gossiped to peers.
+func (s *TxPoolAPI) TxStatus(ctx context.Context, hash common.Hash) (*RPCTxStatus, error) {
+	result := &RPCTxStatus{Status: txStatusUnknown}
+	if lastGossiped, ok := s.b.TxLastGossiped(hash); ok {
+		result.LastGossiped = unixTime(lastGossiped)
+	}
+	switch s.b.TxPoolStatus(hash) {
+	case txpool.TxStatusPending:
+		result.Status = txStatusPending
+	case txpool.TxStatusQueued:
+		result.Status = txStatusQueued
+	default:
+		// Transactions included in a block leave the pool without being
+		// dropped. The transaction is looked up on a best effort basis, as
+		// the index may not be complete.
+		if found, _, blockHash, blockNumber, _, _ := s.b.GetTransaction(ctx, hash); found {
+			result.Status = txStatusIncluded
+			result.BlockHash = &blockHash
+			result.BlockNumber = (*hexutil.Uint64)(&blockNumber)
+			return result, nil
+		}
+		if dropped, ok := s.b.TxPoolDropped(hash); ok {
+			result.Status = txStatusDropped
+			result.Reason = dropped.Reason
+			result.DroppedAt = unixTime(dropped.Time)
+		}
+		return result, nil
+	}
+
+	tx := s.b.GetPoolTransaction(hash)
+	if tx == nil {
+		// The transaction left the pool in the meantime
+		return result, nil
+	}
+	result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+	result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+
+	baseFee, err := s.b.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseFee != nil {
+		result.BaseFee = (*hexutil.Big)(baseFee)
+		coversBaseFee := tx.GasFeeCap().Cmp(baseFee) >= 0
+		result.CoversBaseFee = &coversBaseFee
+	}
+	// The genesis block has no gas usage to spread the block gas cost over.
+	head := s.b.CurrentHeader()
+	if head.Number.Sign() == 0 {
+		return result, nil
+	}
+	minRequiredTip, err := s.b.MinRequiredTip(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+	if minRequiredTip != nil {
+		result.MinRequiredTip = (*hexutil.Big)(minRequiredTip)
+		// A transaction that doesn't cover the base fee has no effective tip.
+		coversRequiredTip := false
+		if tip, err := tx.EffectiveGasTip(baseFee); err == nil {
+			coversRequiredTip = tip.Cmp(minRequiredTip) >= 0
+		}
+		result.CoversRequiredTip = &coversRequiredTip
+	}
+	return result, nil
+}
+
+// TxStatusEvents creates a subscription that fires when a transaction enters
+// the pool, moves between its pending and queued sets, or is dropped from it.
+func (s *TxPoolAPI) TxStatusEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan txpool.TxStatusEvent, 128)
+		sub := s.b.SubscribeTxStatusEvent(events)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				status := txStatusUnknown
+				switch ev.Status {
+				case txpool.TxStatusPending:
+					status = txStatusPending
+				case txpool.TxStatusQueued:
+					status = txStatusQueued
+				case txpool.TxStatusUnknown:
+					status = txStatusDropped
+				}
+				notifier.Notify(rpcSub.ID, &RPCTxStatusEvent{
+					Hash:   ev.Hash,
+					Status: status,
+					Reason: ev.Reason,
+				})
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+func unixTime(t time.Time) *hexutil.Uint64 {
+	unix := hexutil.Uint64(t.Unix())
+	return &unix
+}
@@ -37,6 +37,9 @@ import (
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/bloombits"
 	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
@@ -53,6 +56,7 @@ type Backend interface {
 	EstimateBaseFee(ctx context.Context) (*big.Int, error)
 	SuggestPrice(ctx context.Context) (*big.Int, error)
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCapsByLatency(ctx context.Context, latencies []uint64) (baseFees, blockGasCosts, tips []*big.Int, err error)
 	FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error)
 	ChainDb() ethdb.Database
 	AccountManager() *accounts.Manager
@@ -75,6 +79,7 @@ type Backend interface {
 	StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error)
 	StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error)
 	GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error)
+	GetBlobSidecar(ctx context.Context, number uint64, txHash common.Hash) (*types.BlobTxSidecar, error)
 	GetEVM(ctx context.Context, msg *core.Message, state *state.StateDB, header *types.Header, vmConfig *vm.Config, blockCtx *vm.BlockContext) *vm.EVM
 	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
 	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
@@ -83,6 +88,8 @@ type Backend interface {
 
 	// Transaction pool API
 	SendTx(ctx context.Context, signedTx *types.Transaction) error
+	SendBundle(ctx context.Context, bundle *bundlepool.Bundle) error
+	SendPrivateTx(ctx context.Context, ptx *privatepool.PrivateTx) error
 	GetTransaction(ctx context.Context, txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64, error)
 	GetPoolTransactions() (types.Transactions, error)
 	GetPoolTransaction(txHash common.Hash) *types.Transaction
@@ -91,6 +98,11 @@ type Backend interface {
 	TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction)
 	TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction)
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
+	TxPoolStatus(txHash common.Hash) txpool.TxStatus
+	TxPoolDropped(txHash common.Hash) (txpool.DroppedTx, bool)
+	SubscribeTxStatusEvent(chan<- txpool.TxStatusEvent) event.Subscription
+	TxLastGossiped(txHash common.Hash) (time.Time, bool)
+	MinRequiredTip(ctx context.Context, header *types.Header) (*big.Int, error)
 
 	ChainConfig() *params.ChainConfig
 	Engine() consensus.Engine
@@ -123,6 +135,14 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 			Namespace: "eth",
 			Service:   NewTransactionAPI(apiBackend, nonceLock),
 			Name:      "internal-transaction",
+		}, {
+			Namespace: "eth",
+			Service:   NewBundleAPI(apiBackend),
+			Name:      "internal-bundle",
+		}, {
+			Namespace: "eth",
+			Service:   NewPrivateTxAPI(apiBackend),
+			Name:      "internal-private-transaction",
 		}, {
 			Namespace: "txpool",
 			Service:   NewTxPoolAPI(apiBackend),
@@ -32,6 +32,8 @@ import (
 	"github.com/ava-labs/coreth/consensus"
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ava-labs/coreth/precompile/precompileconfig"
@@ -43,12 +45,16 @@ import (
 type Backend interface {
 	BlockChain() *core.BlockChain
 	TxPool() *txpool.TxPool
+	BundlePool() *bundlepool.BundlePool
+	PrivatePool() *privatepool.PrivatePool
 }
 
 // Config is the configuration parameters of mining.
 type Config struct {
-	Etherbase                    common.Address `toml:",omitempty"` // Public address for block mining rewards
-	TestOnlyAllowDuplicateBlocks bool           // Allow mining of duplicate blocks (used in tests only)
+	Etherbase                    common.Address   `toml:",omitempty"` // Public address for block mining rewards
+	FeeRecipient                 common.Address   `toml:",omitempty"` // Receives the fees while the reward manager allows fee recipients, the etherbase if empty
+	TestOnlyAllowDuplicateBlocks bool             // Allow mining of duplicate blocks (used in tests only)
+	TxOrdering                   TxOrderingPolicy `toml:"-"` // Order in which pending transactions are included, price-and-nonce if nil
 }
 
 type Miner struct {
@@ -69,6 +75,20 @@ func (miner *Miner) GenerateBlock(predicateContext *precompileconfig.PredicateCo
 	return miner.worker.commitNewWork(predicateContext)
 }
 
+// SkippedTx is a transaction, or a bundle, that was considered for a block
+// but not included in it.
+type SkippedTx struct {
+	Hash   common.Hash
+	Reason string
+}
+
+// SimulateBlock builds the block GenerateBlock would currently build, but
+// only to be inspected. The transactions that were considered but skipped are
+// returned even if no block could be built.
+func (miner *Miner) SimulateBlock(predicateContext *precompileconfig.PredicateContext) (*types.Block, []SkippedTx, error) {
+	return miner.worker.simulateNewWork(predicateContext)
+}
+
 // SubscribePendingLogs starts delivering logs from pending transactions
 // to the given channel.
 func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscription {
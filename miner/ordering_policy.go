@@ -0,0 +1,212 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"container/heap"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+)
+
+// Names of the built-in transaction ordering policies.
+const (
+	PriceAndNonceOrderingName   = "price-and-nonce"
+	ArrivalOrderingName         = "fifo"
+	PrioritySendersOrderingName = "priority-senders"
+)
+
+var (
+	_ TxOrderingPolicy = PriceAndNonceOrdering{}
+	_ TxOrderingPolicy = ArrivalOrdering{}
+	_ TxOrderingPolicy = (*PrioritySendersOrdering)(nil)
+
+	_ OrderedTransactions = (*transactionsByPriceAndNonce)(nil)
+	_ OrderedTransactions = (*orderedTransactions)(nil)
+)
+
+// TxOrderingPolicy determines the order in which the worker attempts to
+// include pending transactions in a block. Transactions from the same sender
+// are always included in nonce order.
+type TxOrderingPolicy interface {
+	// NewTransactions returns the set of [txs] in the policy's order.
+	// The input map is reowned so the caller should not interact any more
+	// with it after providing it.
+	NewTransactions(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) OrderedTransactions
+}
+
+// OrderedTransactions is a set of transactions returned in the order of a
+// TxOrderingPolicy, while supporting removing entire batches of transactions
+// for non-executable accounts.
+type OrderedTransactions interface {
+	// Peek returns the next transaction and its effective miner tip.
+	Peek() (*txpool.LazyTransaction, *uint256.Int)
+	// Shift replaces the next transaction with the following one from the same account.
+	Shift()
+	// Pop removes the next transaction, *not* replacing it with the following
+	// one from the same account.
+	Pop()
+	// Empty returns if there are no transactions left.
+	Empty() bool
+	// Clear removes all transactions.
+	Clear()
+}
+
+// NewTxOrderingPolicy returns the built-in policy named [name]. An empty
+// name selects the default price-and-nonce ordering. [prioritySenders] is
+// only used by the priority senders policy.
+func NewTxOrderingPolicy(name string, prioritySenders []common.Address) (TxOrderingPolicy, error) {
+	switch name {
+	case "", PriceAndNonceOrderingName:
+		return PriceAndNonceOrdering{}, nil
+	case ArrivalOrderingName:
+		return ArrivalOrdering{}, nil
+	case PrioritySendersOrderingName:
+		if len(prioritySenders) == 0 {
+			return nil, fmt.Errorf("%s ordering requires at least one priority sender", PrioritySendersOrderingName)
+		}
+		return NewPrioritySendersOrdering(prioritySenders), nil
+	default:
+		return nil, fmt.Errorf("unknown tx ordering policy %q", name)
+	}
+}
+
+// PriceAndNonceOrdering orders transactions by effective miner tip, and by
+// the time they were first seen if the tips are equal. This is the default.
+type PriceAndNonceOrdering struct{}
+
+func (PriceAndNonceOrdering) NewTransactions(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) OrderedTransactions {
+	return newTransactionsByPriceAndNonce(signer, txs, baseFee)
+}
+
+// ArrivalOrdering orders transactions by the time they were first seen,
+// regardless of their price.
+type ArrivalOrdering struct{}
+
+func (ArrivalOrdering) NewTransactions(_ types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) OrderedTransactions {
+	return newOrderedTransactions(txs, baseFee, func(a, b *txWithMinerFee) bool {
+		return a.tx.Time.Before(b.tx.Time)
+	})
+}
+
+// PrioritySendersOrdering includes the transactions of a set of priority
+// senders before the transactions of any other sender. Within each group,
+// transactions are ordered by price and then by arrival.
+type PrioritySendersOrdering struct {
+	senders map[common.Address]struct{}
+}
+
+func NewPrioritySendersOrdering(senders []common.Address) *PrioritySendersOrdering {
+	p := &PrioritySendersOrdering{senders: make(map[common.Address]struct{}, len(senders))}
+	for _, sender := range senders {
+		p.senders[sender] = struct{}{}
+	}
+	return p
+}
+
+func (p *PrioritySendersOrdering) NewTransactions(_ types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) OrderedTransactions {
+	return newOrderedTransactions(txs, baseFee, func(a, b *txWithMinerFee) bool {
+		_, aPriority := p.senders[a.from]
+		_, bPriority := p.senders[b.from]
+		if aPriority != bPriority {
+			return aPriority
+		}
+		// Same as txByPriceAndTime
+		if cmp := a.fees.Cmp(b.fees); cmp != 0 {
+			return cmp > 0
+		}
+		return a.tx.Time.Before(b.tx.Time)
+	})
+}
+
+// orderedTransactions is a nonce-honouring set of transactions ordered by
+// an arbitrary comparison of the next transaction of each account.
+type orderedTransactions struct {
+	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads   *txHeads                                     // Next transaction for each unique account
+	baseFee *uint256.Int                                 // Current base fee
+}
+
+func newOrderedTransactions(txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, less func(a, b *txWithMinerFee) bool) *orderedTransactions {
+	var baseFeeUint *uint256.Int
+	if baseFee != nil {
+		baseFeeUint = uint256.MustFromBig(baseFee)
+	}
+	heads := &txHeads{txs: make([]*txWithMinerFee, 0, len(txs)), less: less}
+	for from, accTxs := range txs {
+		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint)
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads.txs = append(heads.txs, wrapped)
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(heads)
+
+	return &orderedTransactions{
+		txs:     txs,
+		heads:   heads,
+		baseFee: baseFeeUint,
+	}
+}
+
+func (t *orderedTransactions) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if len(t.heads.txs) == 0 {
+		return nil, nil
+	}
+	return t.heads.txs[0].tx, t.heads.txs[0].fees
+}
+
+func (t *orderedTransactions) Shift() {
+	acc := t.heads.txs[0].from
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+			t.heads.txs[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(t.heads, 0)
+			return
+		}
+	}
+	heap.Pop(t.heads)
+}
+
+func (t *orderedTransactions) Pop() {
+	heap.Pop(t.heads)
+}
+
+func (t *orderedTransactions) Empty() bool {
+	return len(t.heads.txs) == 0
+}
+
+func (t *orderedTransactions) Clear() {
+	t.heads.txs, t.txs = nil, nil
+}
+
+// txHeads implements the heap interface over the next transaction of each
+// account using [less] to order them.
+type txHeads struct {
+	txs  []*txWithMinerFee
+	less func(a, b *txWithMinerFee) bool
+}
+
+func (h *txHeads) Len() int           { return len(h.txs) }
+func (h *txHeads) Less(i, j int) bool { return h.less(h.txs[i], h.txs[j]) }
+func (h *txHeads) Swap(i, j int)      { h.txs[i], h.txs[j] = h.txs[j], h.txs[i] }
+
+func (h *txHeads) Push(x interface{}) {
+	h.txs = append(h.txs, x.(*txWithMinerFee))
+}
+
+func (h *txHeads) Pop() interface{} {
+	old := h.txs
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	h.txs = old[0 : n-1]
+	return x
+}
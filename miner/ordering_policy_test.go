@@ -0,0 +1,115 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// newOrderingTestTxs returns 2 transactions from each of [keys], where the
+// transactions of later keys pay more and were seen earlier.
+func newOrderingTestTxs(t *testing.T, signer types.Signer, keys []*ecdsa.PrivateKey) map[common.Address][]*txpool.LazyTransaction {
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for i, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		for nonce := uint64(0); nonce < 2; nonce++ {
+			tx, err := types.SignTx(types.NewTransaction(nonce, common.Address{}, big.NewInt(100), 100, big.NewInt(int64(i+1)), nil), signer, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tx.SetTime(time.Unix(0, int64(2*(len(keys)-i)+int(nonce))))
+			groups[addr] = append(groups[addr], &txpool.LazyTransaction{
+				Hash:      tx.Hash(),
+				Tx:        tx,
+				Time:      tx.Time(),
+				GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+				GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+				Gas:       tx.Gas(),
+				BlobGas:   tx.BlobGas(),
+			})
+		}
+	}
+	return groups
+}
+
+func drainOrderedTransactions(txset OrderedTransactions) types.Transactions {
+	txs := types.Transactions{}
+	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
+		txs = append(txs, tx.Tx)
+		txset.Shift()
+	}
+	return txs
+}
+
+func TestArrivalOrdering(t *testing.T) {
+	t.Parallel()
+	keys := make([]*ecdsa.PrivateKey, 5)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := types.HomesteadSigner{}
+
+	txs := drainOrderedTransactions(ArrivalOrdering{}.NewTransactions(signer, newOrderingTestTxs(t, signer, keys), nil))
+	if len(txs) != 2*len(keys) {
+		t.Fatalf("expected %d transactions, found %d", 2*len(keys), len(txs))
+	}
+	for i := 0; i+1 < len(txs); i++ {
+		if txs[i].Time().After(txs[i+1].Time()) {
+			t.Errorf("invalid received time ordering: tx #%d (T=%v) > tx #%d (T=%v)", i, txs[i].Time(), i+1, txs[i+1].Time())
+		}
+	}
+}
+
+func TestPrioritySendersOrdering(t *testing.T) {
+	t.Parallel()
+	keys := make([]*ecdsa.PrivateKey, 5)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := types.HomesteadSigner{}
+
+	// The first sender pays the least but has priority.
+	priority := crypto.PubkeyToAddress(keys[0].PublicKey)
+	ordering, err := NewTxOrderingPolicy(PrioritySendersOrderingName, []common.Address{priority})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs := drainOrderedTransactions(ordering.NewTransactions(signer, newOrderingTestTxs(t, signer, keys), nil))
+	if len(txs) != 2*len(keys) {
+		t.Fatalf("expected %d transactions, found %d", 2*len(keys), len(txs))
+	}
+	for i, tx := range txs {
+		from, _ := types.Sender(signer, tx)
+		if (i < 2) != (from == priority) {
+			t.Errorf("unexpected sender of tx #%d: %x", i, from)
+		}
+		if i >= 2 && i+1 < len(txs) && tx.GasPrice().Cmp(txs[i+1].GasPrice()) < 0 {
+			t.Errorf("invalid gasprice ordering: tx #%d (P=%v) < tx #%d (P=%v)", i, tx.GasPrice(), i+1, txs[i+1].GasPrice())
+		}
+	}
+}
+
+func TestNewTxOrderingPolicy(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"", PriceAndNonceOrderingName, ArrivalOrderingName} {
+		if _, err := NewTxOrderingPolicy(name, nil); err != nil {
+			t.Errorf("unexpected error for policy %q: %v", name, err)
+		}
+	}
+	if _, err := NewTxOrderingPolicy(PrioritySendersOrderingName, nil); err == nil {
+		t.Error("expected error for priority senders policy without senders")
+	}
+	if _, err := NewTxOrderingPolicy("random", nil); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
@@ -44,9 +44,11 @@ import (
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/state"
 	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/coreth/precompile/precompileconfig"
 	"github.com/ava-labs/coreth/predicate"
 	"github.com/ethereum/go-ethereum/common"
@@ -84,7 +86,14 @@ type environment struct {
 	// way that the gas pool and state is reset.
 	predicateResults *predicate.Results
 
-	start time.Time // Time that block building began
+	start    time.Time   // Time that block building began
+	simulate bool        // Whether the block is only built to be inspected
+	skipped  []SkippedTx // Transactions considered but not included, in order
+}
+
+// skip records that the transaction with [hash] was not included for [reason].
+func (env *environment) skip(hash common.Hash, reason string) {
+	env.skipped = append(env.skipped, SkippedTx{Hash: hash, Reason: reason})
 }
 
 // worker is the main object which takes care of submitting new work to consensus engine
@@ -133,6 +142,19 @@ func (w *worker) setEtherbase(addr common.Address) {
 
 // commitNewWork generates several new sealing tasks based on the parent block.
 func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateContext) (*types.Block, error) {
+	block, _, err := w.generateWork(predicateContext, false)
+	return block, err
+}
+
+// simulateNewWork builds the block commitNewWork would build, along with the
+// transactions it skipped, without treating the block as new work.
+func (w *worker) simulateNewWork(predicateContext *precompileconfig.PredicateContext) (*types.Block, []SkippedTx, error) {
+	return w.generateWork(predicateContext, true)
+}
+
+// generateWork builds a new block on top of the current block. The skipped
+// transactions are returned even if no block could be built.
+func (w *worker) generateWork(predicateContext *precompileconfig.PredicateContext, simulate bool) (*types.Block, []SkippedTx, error) {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
 
@@ -146,12 +168,11 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		timestamp = parent.Time
 	}
 
-	var gasLimit uint64
-	if w.chainConfig.IsCortina(timestamp) {
-		gasLimit = params.CortinaGasLimit
-	} else if w.chainConfig.IsApricotPhase1(timestamp) {
-		gasLimit = params.ApricotPhase1GasLimit
-	} else {
+	gasLimit, isStatic, err := dummy.StaticGasLimit(w.chainConfig, w.chain, parent, timestamp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get static gas limit: %w", err)
+	}
+	if !isStatic {
 		// The gas limit is set in phase1 to ApricotPhase1GasLimit because the ceiling and floor were set to the same value
 		// such that the gas limit converged to it. Since this is hardbaked now, we remove the ability to configure it.
 		gasLimit = core.CalcGasLimit(parent.GasUsed, parent.GasLimit, params.ApricotPhase1GasLimit, params.ApricotPhase1GasLimit)
@@ -166,10 +187,9 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 
 	// Set BaseFee and Extra data field if we are post ApricotPhase3
 	if w.chainConfig.IsApricotPhase3(timestamp) {
-		var err error
-		header.Extra, header.BaseFee, err = dummy.CalcBaseFee(w.chainConfig, parent, timestamp)
+		header.Extra, header.BaseFee, err = dummy.CalcBaseFee(w.chainConfig, w.chain, parent, timestamp)
 		if err != nil {
-			return nil, fmt.Errorf("failed to calculate new base fee: %w", err)
+			return nil, nil, fmt.Errorf("failed to calculate new base fee: %w", err)
 		}
 	}
 	// Apply EIP-4844, EIP-4788.
@@ -187,17 +207,21 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 	}
 
 	if w.coinbase == (common.Address{}) {
-		return nil, errors.New("cannot mine without etherbase")
+		return nil, nil, errors.New("cannot mine without etherbase")
+	}
+	header.Coinbase, err = w.blockCoinbase(parent, timestamp)
+	if err != nil {
+		return nil, nil, err
 	}
-	header.Coinbase = w.coinbase
 	if err := w.engine.Prepare(w.chain, header); err != nil {
-		return nil, fmt.Errorf("failed to prepare header for mining: %w", err)
+		return nil, nil, fmt.Errorf("failed to prepare header for mining: %w", err)
 	}
 
 	env, err := w.createCurrentEnvironment(predicateContext, parent, header, tstart)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new current environment: %w", err)
+		return nil, nil, fmt.Errorf("failed to create new current environment: %w", err)
 	}
+	env.simulate = simulate
 	if header.ParentBeaconRoot != nil {
 		context := core.NewEVMBlockContext(header, w.chain, nil)
 		vmenv := vm.NewEVM(context, vm.TxContext{}, env.state, w.chainConfig, vm.Config{})
@@ -214,7 +238,7 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 	err = core.ApplyUpgrades(w.chainConfig, &parent.Time, types.NewBlockWithHeader(header), env.state)
 	if err != nil {
 		log.Error("failed to configure precompiles mining new block", "parent", parent.Hash(), "number", header.Number, "timestamp", header.Time, "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
@@ -246,21 +270,61 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 			localBlobTxs[account] = txs
 		}
 	}
-	// Fill the block with all available pending transactions.
+	// Include the bundles targeting this block ahead of any pending transactions.
+	w.commitBundles(env, w.eth.BundlePool().Bundles(env.header.Number.Uint64(), env.header.Time), env.header.Coinbase)
+
+	// Fill the block with all available pending transactions, starting with
+	// the ones submitted privately to this node.
+	ordering := w.txOrdering()
+	if privateTxs := w.eth.PrivatePool().PrivatePending(filter); len(privateTxs) > 0 {
+		plainTxs := ordering.NewTransactions(env.signer, privateTxs, env.header.BaseFee)
+		blobTxs := ordering.NewTransactions(env.signer, nil, env.header.BaseFee)
+
+		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
+	}
 	if len(localPlainTxs) > 0 || len(localBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, localPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, localBlobTxs, env.header.BaseFee)
+		plainTxs := ordering.NewTransactions(env.signer, localPlainTxs, env.header.BaseFee)
+		blobTxs := ordering.NewTransactions(env.signer, localBlobTxs, env.header.BaseFee)
 
 		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
 	}
 	if len(remotePlainTxs) > 0 || len(remoteBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, remotePlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, remoteBlobTxs, env.header.BaseFee)
+		plainTxs := ordering.NewTransactions(env.signer, remotePlainTxs, env.header.BaseFee)
+		blobTxs := ordering.NewTransactions(env.signer, remoteBlobTxs, env.header.BaseFee)
 
 		w.commitTransactions(env, plainTxs, blobTxs, env.header.Coinbase)
 	}
 
-	return w.commit(env)
+	block, err := w.commit(env)
+	return block, env.skipped, err
+}
+
+// txOrdering returns the configured transaction ordering policy.
+func (w *worker) txOrdering() TxOrderingPolicy {
+	if w.config.TxOrdering == nil {
+		return PriceAndNonceOrdering{}
+	}
+	return w.config.TxOrdering
+}
+
+// blockCoinbase returns the coinbase of the block built on top of [parent] at
+// [timestamp]. While the reward manager is active, it decides where the fees go.
+func (w *worker) blockCoinbase(parent *types.Header, timestamp uint64) (common.Address, error) {
+	if !w.chainConfig.IsPrecompileEnabled(rewardmanager.ContractAddress, timestamp) {
+		return w.coinbase, nil
+	}
+	coinbase, allowFeeRecipients, err := dummy.RewardCoinbase(w.chainConfig, w.chain, parent, timestamp)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get reward coinbase: %w", err)
+	}
+	switch {
+	case !allowFeeRecipients:
+		return coinbase, nil
+	case w.config.FeeRecipient != (common.Address{}):
+		return w.config.FeeRecipient, nil
+	default:
+		return w.coinbase, nil
+	}
 }
 
 func (w *worker) createCurrentEnvironment(predicateContext *precompileconfig.PredicateContext, parent *types.Header, header *types.Header, tstart time.Time) (*environment, error) {
@@ -350,7 +414,7 @@ func (w *worker) applyTransaction(env *environment, tx *types.Transaction, coinb
 	return receipt, err
 }
 
-func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, coinbase common.Address) {
+func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs OrderedTransactions, coinbase common.Address) {
 	for {
 		// If we don't have enough gas for any further transactions then we're done.
 		if env.gasPool.Gas() < params.TxGas {
@@ -374,7 +438,7 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// Retrieve the next transaction and abort if all done.
 		var (
 			ltx *txpool.LazyTransaction
-			txs *transactionsByPriceAndNonce
+			txs OrderedTransactions
 		)
 		pltx, ptip := plainTxs.Peek()
 		bltx, btip := blobTxs.Peek()
@@ -397,11 +461,13 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// If we don't have enough space for the next transaction, skip the account.
 		if env.gasPool.Gas() < ltx.Gas {
 			log.Trace("Not enough gas left for transaction", "hash", ltx.Hash, "left", env.gasPool.Gas(), "needed", ltx.Gas)
+			env.skip(ltx.Hash, fmt.Sprintf("not enough gas left in block: left %d, needed %d", env.gasPool.Gas(), ltx.Gas))
 			txs.Pop()
 			continue
 		}
 		if left := uint64(params.MaxBlobGasPerBlock - env.blobs*params.BlobTxBlobGasPerBlob); left < ltx.BlobGas {
 			log.Trace("Not enough blob gas left for transaction", "hash", ltx.Hash, "left", left, "needed", ltx.BlobGas)
+			env.skip(ltx.Hash, fmt.Sprintf("not enough blob gas left in block: left %d, needed %d", left, ltx.BlobGas))
 			txs.Pop()
 			continue
 		}
@@ -416,6 +482,7 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// transction that will fit.
 		if totalTxsSize := env.size + tx.Size(); totalTxsSize > targetTxsSize {
 			log.Trace("Skipping transaction that would exceed target size", "hash", tx.Hash(), "totalTxsSize", totalTxsSize, "txSize", tx.Size())
+			env.skip(ltx.Hash, fmt.Sprintf("exceeds target block size: total %d, limit %d", totalTxsSize, targetTxsSize))
 			txs.Pop()
 			continue
 		}
@@ -428,6 +495,7 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring replay protected transaction", "hash", ltx.Hash, "eip155", w.chainConfig.EIP155Block)
+			env.skip(ltx.Hash, "replay protected before EIP155")
 			txs.Pop()
 			continue
 		}
@@ -440,6 +508,7 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "hash", ltx.Hash, "sender", from, "nonce", tx.Nonce())
+			env.skip(ltx.Hash, err.Error())
 			txs.Shift()
 
 		case errors.Is(err, nil):
@@ -450,11 +519,87 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", ltx.Hash, "err", err)
+			env.skip(ltx.Hash, err.Error())
 			txs.Pop()
 		}
 	}
 }
 
+// commitBundles includes each of [bundles] in order, skipping the bundles
+// which cannot be included in full on top of the transactions included before.
+func (w *worker) commitBundles(env *environment, bundles []*bundlepool.Bundle, coinbase common.Address) {
+	for _, bundle := range bundles {
+		if env.gasPool.Gas() < params.TxGas {
+			log.Trace("Not enough gas for further bundles", "have", env.gasPool, "want", params.TxGas)
+			return
+		}
+		if err := w.commitBundle(env, bundle, coinbase); err != nil {
+			log.Debug("Bundle failed, skipped", "hash", bundle.Hash(), "err", err)
+			env.skip(bundle.Hash(), fmt.Sprintf("bundle failed: %v", err))
+			continue
+		}
+		log.Debug("Included bundle", "hash", bundle.Hash(), "txs", len(bundle.Txs))
+	}
+}
+
+// commitBundle applies the transactions of [bundle] in order. If any of them
+// cannot be applied or reverts, the state and environment are restored to
+// before the bundle, so that it is included atomically or not at all.
+// The size of the transactions of bundles is accounted for in [env.size], so
+// the bundles of a block are bounded by the target block size.
+func (w *worker) commitBundle(env *environment, bundle *bundlepool.Bundle, coinbase common.Address) error {
+	// The state is copied rather than snapshotted, since applying each
+	// transaction finalises the state, which discards its snapshots.
+	var (
+		state   = env.state.Copy()
+		gp      = env.gasPool.Gas()
+		gasUsed = env.header.GasUsed
+		tcount  = env.tcount
+		size    = env.size
+		numTxs  = len(env.txs)
+	)
+	revert := func() {
+		// The copy only holds an inactive copy of the prefetcher.
+		env.state.StopPrefetcher()
+		env.state = state
+		env.gasPool.SetGas(gp)
+		env.header.GasUsed = gasUsed
+		env.tcount = tcount
+		env.size = size
+		for _, tx := range env.txs[numTxs:] {
+			env.predicateResults.DeleteTxResults(tx.Hash())
+		}
+		env.txs = env.txs[:numTxs]
+		env.receipts = env.receipts[:numTxs]
+	}
+	for i, tx := range bundle.Txs {
+		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
+			revert()
+			return fmt.Errorf("transaction %d (%s) is replay protected before EIP155", i, tx.Hash())
+		}
+		if totalTxsSize := env.size + tx.Size(); totalTxsSize > targetTxsSize {
+			revert()
+			return fmt.Errorf("transaction %d (%s) exceeds target block size: total %d, limit %d", i, tx.Hash(), totalTxsSize, targetTxsSize)
+		}
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+
+		receipt, err := w.applyTransaction(env, tx, coinbase)
+		if err != nil {
+			revert()
+			return fmt.Errorf("transaction %d (%s) failed: %w", i, tx.Hash(), err)
+		}
+		env.txs = append(env.txs, tx)
+		env.receipts = append(env.receipts, receipt)
+		env.size += tx.Size()
+		env.tcount++
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			revert()
+			return fmt.Errorf("transaction %d (%s) reverted", i, tx.Hash())
+		}
+	}
+	return nil
+}
+
 // commit runs any post-transaction state modifications, assembles the final block
 // and commits new work if consensus engine is running.
 func (w *worker) commit(env *environment) (*types.Block, error) {
@@ -477,7 +622,8 @@ func (w *worker) commit(env *environment) (*types.Block, error) {
 
 func (w *worker) handleResult(env *environment, block *types.Block, createdAt time.Time, unfinishedReceipts []*types.Receipt) (*types.Block, error) {
 	// Short circuit when receiving duplicate result caused by resubmitting.
-	if !w.config.TestOnlyAllowDuplicateBlocks && w.chain.HasBlock(block.Hash(), block.NumberU64()) {
+	// Simulated blocks are not new work, so they may match a built block.
+	if !env.simulate && !w.config.TestOnlyAllowDuplicateBlocks && w.chain.HasBlock(block.Hash(), block.NumberU64()) {
 		return nil, fmt.Errorf("produced duplicate block (Hash: %s, Number %d)", block.Hash(), block.NumberU64())
 	}
 	// Different block could share same sealhash, deep copy here to prevent write-write conflict.
@@ -509,6 +655,10 @@ func (w *worker) handleResult(env *environment, block *types.Block, createdAt ti
 	}
 	fees := totalFees(block, receipts)
 	feesInEther := new(big.Float).Quo(new(big.Float).SetInt(fees), big.NewFloat(params.Ether))
+	if env.simulate {
+		log.Debug("Simulated new mining work", "number", block.Number(), "hash", hash, "txs", env.tcount, "gas", block.GasUsed(), "skipped", len(env.skipped))
+		return block, nil
+	}
 	log.Info("Commit new mining work", "number", block.Number(), "hash", hash,
 		"uncles", 0, "txs", env.tcount,
 		"gas", block.GasUsed(), "fees", feesInEther,
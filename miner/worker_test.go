@@ -0,0 +1,231 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/constants"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/bundlepool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	testBalance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+
+	// revertAddr holds a contract reverting every call.
+	revertAddr = common.HexToAddress("0x0300000000000000000000000000000000000fd0")
+	revertCode = []byte{0x60, 0x00, 0x60, 0x00, 0xfd} // PUSH1 0 PUSH1 0 REVERT
+)
+
+type testWorkerBackend struct {
+	chain *core.BlockChain
+}
+
+func (b *testWorkerBackend) BlockChain() *core.BlockChain          { return b.chain }
+func (b *testWorkerBackend) TxPool() *txpool.TxPool                { return nil }
+func (b *testWorkerBackend) BundlePool() *bundlepool.BundlePool    { return nil }
+func (b *testWorkerBackend) PrivatePool() *privatepool.PrivatePool { return nil }
+
+// newTestWorker returns a worker building on top of the genesis block of a
+// chain funding [testAddr] and holding the contract at [revertAddr].
+func newTestWorker(t *testing.T) *worker {
+	return newTestWorkerWithConfig(t, params.TestChainConfig, &Config{Etherbase: common.Address{1}})
+}
+
+// newTestWorkerWithConfig returns a worker configured with [config], building
+// on top of the genesis block of a chain configured with [chainConfig].
+func newTestWorkerWithConfig(t *testing.T, chainConfig *params.ChainConfig, config *Config) *worker {
+	gspec := &core.Genesis{
+		Config: chainConfig,
+		Alloc: types.GenesisAlloc{
+			testAddr:   {Balance: testBalance},
+			revertAddr: {Code: revertCode},
+		},
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), core.DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	require.NoError(t, err)
+	t.Cleanup(chain.Stop)
+
+	return newWorker(config, gspec.Config, dummy.NewCoinbaseFaker(), &testWorkerBackend{chain: chain}, nil, &mockable.Clock{})
+}
+
+// newTestEnvironment returns the environment to build the block following the
+// current block of the chain of [w].
+func newTestEnvironment(t *testing.T, w *worker) *environment {
+	parent := w.chain.CurrentBlock()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + 1,
+		BaseFee:    big.NewInt(params.ApricotPhase3InitialBaseFee),
+		Coinbase:   w.coinbase,
+	}
+	require.NoError(t, w.engine.Prepare(w.chain, header))
+	env, err := w.createCurrentEnvironment(nil, parent, header, w.clock.Time())
+	require.NoError(t, err)
+	t.Cleanup(env.state.StopPrefetcher)
+	return env
+}
+
+func newTestTx(t *testing.T, w *worker, nonce uint64, to common.Address, gas uint64) *types.Transaction {
+	tx, err := types.SignTx(types.NewTransaction(nonce, to, common.Big1, gas, big.NewInt(params.LaunchMinGasPrice), nil), types.LatestSigner(w.chainConfig), testKey)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestCommitBundlesAtomic(t *testing.T) {
+	require := require.New(t)
+
+	w := newTestWorker(t)
+	env := newTestEnvironment(t, w)
+	gas := env.gasPool.Gas()
+
+	// The bundle fails on its second transaction, which reverts, so the
+	// transfer before it is not included either.
+	failing := &bundlepool.Bundle{
+		Txs: types.Transactions{
+			newTestTx(t, w, 0, common.Address{2}, params.TxGas),
+			newTestTx(t, w, 1, revertAddr, 100_000),
+		},
+		BlockNumber: 1,
+	}
+	// The bundle after it is included on top of the state before the failed
+	// bundle, reusing its nonces.
+	included := &bundlepool.Bundle{
+		Txs: types.Transactions{
+			newTestTx(t, w, 0, common.Address{3}, params.TxGas),
+			newTestTx(t, w, 1, common.Address{3}, params.TxGas),
+		},
+		BlockNumber: 1,
+	}
+	w.commitBundles(env, []*bundlepool.Bundle{failing, included}, env.header.Coinbase)
+
+	require.Len(env.skipped, 1)
+	require.Equal(failing.Hash(), env.skipped[0].Hash)
+	require.Equal(included.Txs, types.Transactions(env.txs))
+	require.Len(env.receipts, 2)
+	require.Equal(2, env.tcount)
+	require.Equal(included.Txs[0].Size()+included.Txs[1].Size(), env.size)
+	require.Equal(2*params.TxGas, env.header.GasUsed)
+	require.Equal(gas-2*params.TxGas, env.gasPool.Gas())
+	require.Equal(uint64(2), env.state.GetNonce(testAddr))
+	require.Zero(env.state.GetBalance(common.Address{2}).Sign())
+	require.Equal(uint64(2), env.state.GetBalance(common.Address{3}).Uint64())
+}
+
+func TestCommitBundlesSizeLimit(t *testing.T) {
+	require := require.New(t)
+
+	w := newTestWorker(t)
+	env := newTestEnvironment(t, w)
+
+	// The second transaction of the bundle exceeds the target block size.
+	bundle := &bundlepool.Bundle{
+		Txs: types.Transactions{
+			newTestTx(t, w, 0, common.Address{2}, params.TxGas),
+			newTestTx(t, w, 1, common.Address{2}, params.TxGas),
+		},
+		BlockNumber: 1,
+	}
+	env.size = targetTxsSize - bundle.Txs[0].Size() - 1
+	w.commitBundles(env, []*bundlepool.Bundle{bundle}, env.header.Coinbase)
+
+	require.Len(env.skipped, 1)
+	require.Equal(bundle.Hash(), env.skipped[0].Hash)
+	require.Empty(env.txs)
+	require.Equal(targetTxsSize-bundle.Txs[0].Size()-1, env.size)
+	require.Zero(env.header.GasUsed)
+	require.Zero(env.state.GetNonce(testAddr))
+}
+
+func TestBlockCoinbaseRewardManager(t *testing.T) {
+	var (
+		etherbase     = common.Address{1}
+		rewardAddress = common.Address{'r', 'e', 'w', 'a', 'r', 'd'}
+		feeRecipient  = common.Address{'f', 'e', 'e'}
+	)
+	tests := []struct {
+		name          string
+		activation    uint64
+		initialConfig *rewardmanager.InitialRewardConfig
+		feeRecipient  common.Address
+		timestamp     uint64
+		expected      common.Address
+	}{
+		{
+			name:       "not active",
+			activation: 10,
+			timestamp:  9,
+			expected:   etherbase,
+		},
+		{
+			// The fees of the block activating the reward manager are burned.
+			name:          "activation block",
+			activation:    10,
+			initialConfig: &rewardmanager.InitialRewardConfig{RewardAddress: rewardAddress},
+			feeRecipient:  feeRecipient,
+			timestamp:     10,
+			expected:      constants.BlackholeAddr,
+		},
+		{
+			name:          "stored reward address",
+			initialConfig: &rewardmanager.InitialRewardConfig{RewardAddress: rewardAddress},
+			feeRecipient:  feeRecipient,
+			timestamp:     1,
+			expected:      rewardAddress,
+		},
+		{
+			name:      "rewards disabled",
+			timestamp: 1,
+			expected:  constants.BlackholeAddr,
+		},
+		{
+			name:          "fee recipients allowed with fee recipient",
+			initialConfig: &rewardmanager.InitialRewardConfig{AllowFeeRecipients: true},
+			feeRecipient:  feeRecipient,
+			timestamp:     1,
+			expected:      feeRecipient,
+		},
+		{
+			name:          "fee recipients allowed without fee recipient",
+			initialConfig: &rewardmanager.InitialRewardConfig{AllowFeeRecipients: true},
+			timestamp:     1,
+			expected:      etherbase,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			cpcfg := *params.TestChainConfig
+			chainConfig := &cpcfg
+			chainConfig.UpgradeConfig.PrecompileUpgrades = []params.PrecompileUpgrade{
+				{Config: rewardmanager.NewConfig(utils.NewUint64(test.activation), nil, nil, nil, test.initialConfig)},
+			}
+			w := newTestWorkerWithConfig(t, chainConfig, &Config{Etherbase: etherbase, FeeRecipient: test.feeRecipient})
+
+			coinbase, err := w.blockCoinbase(w.chain.CurrentBlock(), test.timestamp)
+			require.NoError(err)
+			require.Equal(test.expected, coinbase)
+		})
+	}
+}
@@ -786,6 +786,19 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if err := c.CheckNetworkUpgradesCompatible(&newcfg.NetworkUpgrades, headTimestamp); err != nil {
 		return err
 	}
+
+	// Check the upgrades this chain opted in to
+	if isForkTimestampIncompatible(c.BlobTxsTimestamp, newcfg.BlobTxsTimestamp, headTimestamp) {
+		return newTimestampCompatError("Blob txs timestamp", c.BlobTxsTimestamp, newcfg.BlobTxsTimestamp)
+	}
+	if isForkTimestampIncompatible(c.SignaturePrecompilesTimestamp, newcfg.SignaturePrecompilesTimestamp, headTimestamp) {
+		return newTimestampCompatError("Signature precompiles timestamp", c.SignaturePrecompilesTimestamp, newcfg.SignaturePrecompilesTimestamp)
+	}
+
+	// Check that the precompile upgrades activated by the head are unchanged
+	if err := c.CheckPrecompilesCompatible(newcfg.PrecompileUpgrades, headTimestamp); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -931,6 +944,12 @@ type Rules struct {
 	// Rules for Avalanche releases
 	AvalancheRules
 
+	// IsBlobTxs is true once the network opted in to blob transactions.
+	IsBlobTxs bool
+	// IsSignaturePrecompiles is true once the network opted in to the P-256
+	// and BLS signature verification precompiles.
+	IsSignaturePrecompiles bool
+
 	// ActivePrecompiles maps addresses to stateful precompiled contracts that are enabled
 	// for this rule set.
 	// Note: none of these addresses should conflict with the address space used by
@@ -972,6 +991,8 @@ func (c *ChainConfig) Rules(blockNum *big.Int, timestamp uint64) Rules {
 	rules := c.rules(blockNum, timestamp)
 
 	rules.AvalancheRules = c.GetAvalancheRules(timestamp)
+	rules.IsBlobTxs = c.IsBlobTxs(timestamp)
+	rules.IsSignaturePrecompiles = c.IsSignaturePrecompiles(timestamp)
 
 	// Initialize the stateful precompiles that should be enabled at [blockTimestamp].
 	rules.ActivePrecompiles = make(map[common.Address]precompileconfig.Config)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/coreth/precompile/modules"
 	"github.com/ava-labs/coreth/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -24,10 +25,38 @@ const (
 // - Timestamps that enable avalanche network upgrades,
 // - Enabling or disabling precompiles as network upgrades.
 type UpgradeConfig struct {
+	// Timestamp at which EIP-4844 blob transactions are allowed in the mempool
+	// and blocks (nil = never). Networks opt in to blob transactions, which
+	// are only enabled once Cancun is active.
+	BlobTxsTimestamp *uint64 `json:"blobTxsTimestamp,omitempty"`
+
+	// Timestamp at which the P-256 and BLS signature verification precompiles
+	// are enabled (nil = never). Networks opt in to these precompiles, which
+	// are only enabled once Cancun is active.
+	SignaturePrecompilesTimestamp *uint64 `json:"signaturePrecompilesTimestamp,omitempty"`
+
+	// EnabledPrecompiles records the precompile modules enabled on the chain,
+	// in addition to the ones enabled by default. Every node of the chain must
+	// select the same modules, at the same addresses, in its config.
+	EnabledPrecompiles []modules.ModuleSelection `json:"enabledPrecompiles,omitempty"`
+
 	// Config for enabling and disabling precompiles as network upgrades.
 	PrecompileUpgrades []PrecompileUpgrade `json:"precompileUpgrades,omitempty"`
 }
 
+// ParseEnabledPrecompiles returns the precompile modules recorded as enabled in
+// [upgradeBytes]. The rest of the upgrade config is not parsed, as the configs
+// of the precompiles can only be parsed once the modules are enabled.
+func ParseEnabledPrecompiles(upgradeBytes []byte) ([]modules.ModuleSelection, error) {
+	var upgradeConfig struct {
+		EnabledPrecompiles []modules.ModuleSelection `json:"enabledPrecompiles"`
+	}
+	if err := json.Unmarshal(upgradeBytes, &upgradeConfig); err != nil {
+		return nil, err
+	}
+	return upgradeConfig.EnabledPrecompiles, nil
+}
+
 // AvalancheContext provides Avalanche specific context directly into the EVM.
 type AvalancheContext struct {
 	SnowCtx *snow.Context
@@ -137,10 +166,28 @@ func (c *ChainConfig) Verify() error {
 	if err := c.verifyPrecompileUpgrades(); err != nil {
 		return fmt.Errorf("invalid precompile upgrades: %w", err)
 	}
+	if c.BlobTxsTimestamp != nil && (c.CancunTime == nil || *c.BlobTxsTimestamp < *c.CancunTime) {
+		return fmt.Errorf("invalid blobTxsTimestamp %d: must not be before cancunTime %s", *c.BlobTxsTimestamp, ptrToString(c.CancunTime))
+	}
+	if c.SignaturePrecompilesTimestamp != nil && (c.CancunTime == nil || *c.SignaturePrecompilesTimestamp < *c.CancunTime) {
+		return fmt.Errorf("invalid signaturePrecompilesTimestamp %d: must not be before cancunTime %s", *c.SignaturePrecompilesTimestamp, ptrToString(c.CancunTime))
+	}
 
 	return nil
 }
 
+// IsBlobTxs returns whether [time] is at or after the opt-in activation of
+// blob transactions.
+func (c *ChainConfig) IsBlobTxs(time uint64) bool {
+	return isTimestampForked(c.BlobTxsTimestamp, time)
+}
+
+// IsSignaturePrecompiles returns whether [time] is at or after the opt-in
+// activation of the P-256 and BLS signature verification precompiles.
+func (c *ChainConfig) IsSignaturePrecompiles(time uint64) bool {
+	return isTimestampForked(c.SignaturePrecompilesTimestamp, time)
+}
+
 // IsPrecompileEnabled returns whether precompile with [address] is enabled at [timestamp].
 func (c *ChainConfig) IsPrecompileEnabled(address common.Address, timestamp uint64) bool {
 	config := c.getActivePrecompileConfig(address, timestamp)
@@ -125,3 +125,75 @@ func TestIsForkTransition(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyBlobTxsTimestamp(t *testing.T) {
+	for name, test := range map[string]struct {
+		cancun, blobTxs *uint64
+		valid           bool
+	}{
+		"not opted in": {
+			cancun:  nil,
+			blobTxs: nil,
+			valid:   true,
+		},
+		"opted in without cancun": {
+			cancun:  nil,
+			blobTxs: utils.NewUint64(100),
+			valid:   false,
+		},
+		"opted in before cancun": {
+			cancun:  utils.NewUint64(100),
+			blobTxs: utils.NewUint64(50),
+			valid:   false,
+		},
+		"opted in at cancun": {
+			cancun:  utils.NewUint64(100),
+			blobTxs: utils.NewUint64(100),
+			valid:   true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := *TestChainConfig
+			config.CancunTime = test.cancun
+			config.BlobTxsTimestamp = test.blobTxs
+			err := config.Verify()
+			assert.Equal(t, test.valid, err == nil, err)
+		})
+	}
+}
+
+func TestVerifySignaturePrecompilesTimestamp(t *testing.T) {
+	for name, test := range map[string]struct {
+		cancun, signaturePrecompiles *uint64
+		valid                        bool
+	}{
+		"not opted in": {
+			cancun:               nil,
+			signaturePrecompiles: nil,
+			valid:                true,
+		},
+		"opted in without cancun": {
+			cancun:               nil,
+			signaturePrecompiles: utils.NewUint64(100),
+			valid:                false,
+		},
+		"opted in before cancun": {
+			cancun:               utils.NewUint64(100),
+			signaturePrecompiles: utils.NewUint64(50),
+			valid:                false,
+		},
+		"opted in after cancun": {
+			cancun:               utils.NewUint64(100),
+			signaturePrecompiles: utils.NewUint64(150),
+			valid:                true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := *TestChainConfig
+			config.CancunTime = test.cancun
+			config.SignaturePrecompilesTimestamp = test.signaturePrecompiles
+			err := config.Verify()
+			assert.Equal(t, test.valid, err == nil, err)
+		})
+	}
+}
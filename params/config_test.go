@@ -133,6 +133,37 @@ func TestCheckCompatible(t *testing.T) {
 				RewindToTime: 0,
 			},
 		},
+		{
+			stored:        &ChainConfig{UpgradeConfig: UpgradeConfig{BlobTxsTimestamp: utils.NewUint64(100)}},
+			new:           &ChainConfig{UpgradeConfig: UpgradeConfig{BlobTxsTimestamp: utils.NewUint64(300)}},
+			headBlock:     10,
+			headTimestamp: 50,
+			wantErr:       nil,
+		},
+		{
+			stored:        &ChainConfig{UpgradeConfig: UpgradeConfig{BlobTxsTimestamp: utils.NewUint64(100)}},
+			new:           &ChainConfig{},
+			headBlock:     20,
+			headTimestamp: 200,
+			wantErr: &ConfigCompatError{
+				What:         "Blob txs timestamp",
+				StoredTime:   utils.NewUint64(100),
+				NewTime:      nil,
+				RewindToTime: 99,
+			},
+		},
+		{
+			stored:        &ChainConfig{},
+			new:           &ChainConfig{UpgradeConfig: UpgradeConfig{SignaturePrecompilesTimestamp: utils.NewUint64(100)}},
+			headBlock:     20,
+			headTimestamp: 200,
+			wantErr: &ConfigCompatError{
+				What:         "Signature precompiles timestamp",
+				StoredTime:   nil,
+				NewTime:      utils.NewUint64(100),
+				RewindToTime: 99,
+			},
+		},
 	}
 
 	for _, test := range tests {
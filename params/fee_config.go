@@ -0,0 +1,110 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Upper bounds of the fields of a [FeeConfig], so a stored fee configuration
+// cannot halt the chain or overflow the dynamic fee algorithm.
+const (
+	MaxFeeConfigTargetBlockRate          uint64 = 60
+	MaxFeeConfigMinBaseFee               int64  = 1_000_000 * GWei
+	MaxFeeConfigBaseFeeChangeDenominator int64  = 1_000
+)
+
+// FeeConfig is a configuration of the dynamic fee algorithm. Once stored by the
+// fee manager precompile, it replaces the parameters hard-coded for each
+// network upgrade for the children of the blocks it is stored in.
+type FeeConfig struct {
+	// GasLimit is the static gas limit of every block.
+	GasLimit *big.Int `json:"gasLimit,omitempty"`
+	// TargetBlockRate is the targeted number of seconds between blocks.
+	TargetBlockRate uint64 `json:"targetBlockRate,omitempty"`
+
+	// MinBaseFee is the lower bound of the base fee, there is no upper bound.
+	MinBaseFee *big.Int `json:"minBaseFee,omitempty"`
+	// TargetGas is the gas targeted within the rollup window.
+	TargetGas *big.Int `json:"targetGas,omitempty"`
+	// BaseFeeChangeDenominator bounds the change of the base fee between
+	// blocks.
+	BaseFeeChangeDenominator *big.Int `json:"baseFeeChangeDenominator,omitempty"`
+
+	// MinBlockGasCost and MaxBlockGasCost bound the block gas cost.
+	MinBlockGasCost *big.Int `json:"minBlockGasCost,omitempty"`
+	MaxBlockGasCost *big.Int `json:"maxBlockGasCost,omitempty"`
+	// BlockGasCostStep is the change of the block gas cost per second off the
+	// target block rate.
+	BlockGasCostStep *big.Int `json:"blockGasCostStep,omitempty"`
+}
+
+// Verify returns an error if a field of [f] is missing or out of range.
+func (f *FeeConfig) Verify() error {
+	switch {
+	case f.GasLimit == nil:
+		return errors.New("gasLimit cannot be nil")
+	case f.MinBaseFee == nil:
+		return errors.New("minBaseFee cannot be nil")
+	case f.TargetGas == nil:
+		return errors.New("targetGas cannot be nil")
+	case f.BaseFeeChangeDenominator == nil:
+		return errors.New("baseFeeChangeDenominator cannot be nil")
+	case f.MinBlockGasCost == nil:
+		return errors.New("minBlockGasCost cannot be nil")
+	case f.MaxBlockGasCost == nil:
+		return errors.New("maxBlockGasCost cannot be nil")
+	case f.BlockGasCostStep == nil:
+		return errors.New("blockGasCostStep cannot be nil")
+	}
+
+	// The gas of a full rollup window bounds the gas that can be targeted.
+	maxTargetGas := new(big.Int).Mul(f.GasLimit, new(big.Int).SetUint64(RollupWindow))
+	switch {
+	case f.GasLimit.Cmp(new(big.Int).SetUint64(MinGasLimit)) < 0 || f.GasLimit.Cmp(new(big.Int).SetUint64(MaxGasLimit)) > 0:
+		return fmt.Errorf("gasLimit = %d must be in [%d, %d]", f.GasLimit, MinGasLimit, MaxGasLimit)
+	case f.TargetBlockRate == 0 || f.TargetBlockRate > MaxFeeConfigTargetBlockRate:
+		return fmt.Errorf("targetBlockRate = %d must be in [1, %d]", f.TargetBlockRate, MaxFeeConfigTargetBlockRate)
+	case f.MinBaseFee.Sign() < 0 || f.MinBaseFee.Cmp(big.NewInt(MaxFeeConfigMinBaseFee)) > 0:
+		return fmt.Errorf("minBaseFee = %d must be in [0, %d]", f.MinBaseFee, MaxFeeConfigMinBaseFee)
+	case f.TargetGas.Sign() <= 0 || f.TargetGas.Cmp(maxTargetGas) > 0:
+		return fmt.Errorf("targetGas = %d must be in [1, %d]", f.TargetGas, maxTargetGas)
+	case f.BaseFeeChangeDenominator.Sign() <= 0 || f.BaseFeeChangeDenominator.Cmp(big.NewInt(MaxFeeConfigBaseFeeChangeDenominator)) > 0:
+		return fmt.Errorf("baseFeeChangeDenominator = %d must be in [1, %d]", f.BaseFeeChangeDenominator, MaxFeeConfigBaseFeeChangeDenominator)
+	case f.MinBlockGasCost.Sign() < 0:
+		return fmt.Errorf("minBlockGasCost = %d cannot be negative", f.MinBlockGasCost)
+	case f.MaxBlockGasCost.Cmp(f.GasLimit) > 0:
+		return fmt.Errorf("maxBlockGasCost = %d cannot be greater than gasLimit = %d", f.MaxBlockGasCost, f.GasLimit)
+	case f.MinBlockGasCost.Cmp(f.MaxBlockGasCost) > 0:
+		return fmt.Errorf("minBlockGasCost = %d cannot be greater than maxBlockGasCost = %d", f.MinBlockGasCost, f.MaxBlockGasCost)
+	case f.BlockGasCostStep.Sign() < 0 || f.BlockGasCostStep.Cmp(f.MaxBlockGasCost) > 0:
+		return fmt.Errorf("blockGasCostStep = %d must be in [0, maxBlockGasCost = %d]", f.BlockGasCostStep, f.MaxBlockGasCost)
+	}
+	return nil
+}
+
+// Equal returns true iff [other] has the same values as [f].
+func (f *FeeConfig) Equal(other *FeeConfig) bool {
+	if other == nil {
+		return false
+	}
+	return bigEqual(f.GasLimit, other.GasLimit) &&
+		f.TargetBlockRate == other.TargetBlockRate &&
+		bigEqual(f.MinBaseFee, other.MinBaseFee) &&
+		bigEqual(f.TargetGas, other.TargetGas) &&
+		bigEqual(f.BaseFeeChangeDenominator, other.BaseFeeChangeDenominator) &&
+		bigEqual(f.MinBlockGasCost, other.MinBlockGasCost) &&
+		bigEqual(f.MaxBlockGasCost, other.MaxBlockGasCost) &&
+		bigEqual(f.BlockGasCostStep, other.BlockGasCostStep)
+}
+
+// bigEqual returns true iff [a] and [b] are both nil or have the same value.
+func bigEqual(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
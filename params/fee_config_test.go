@@ -0,0 +1,95 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeConfigVerify(t *testing.T) {
+	valid := FeeConfig{
+		GasLimit:                 big.NewInt(8_000_000),
+		TargetBlockRate:          2,
+		MinBaseFee:               big.NewInt(25 * GWei),
+		TargetGas:                big.NewInt(15_000_000),
+		BaseFeeChangeDenominator: big.NewInt(36),
+		MinBlockGasCost:          big.NewInt(0),
+		MaxBlockGasCost:          big.NewInt(1_000_000),
+		BlockGasCostStep:         big.NewInt(200_000),
+	}
+	tests := map[string]struct {
+		modify      func(f *FeeConfig)
+		errContains string
+	}{
+		"valid": {
+			modify: func(f *FeeConfig) {},
+		},
+		"missing field": {
+			modify:      func(f *FeeConfig) { f.TargetGas = nil },
+			errContains: "targetGas cannot be nil",
+		},
+		"gas limit below minimum": {
+			modify:      func(f *FeeConfig) { f.GasLimit = big.NewInt(int64(MinGasLimit) - 1) },
+			errContains: "gasLimit",
+		},
+		"gas limit above maximum": {
+			modify:      func(f *FeeConfig) { f.GasLimit = new(big.Int).SetUint64(MaxGasLimit + 1) },
+			errContains: "gasLimit",
+		},
+		"gas limit at maximum": {
+			modify: func(f *FeeConfig) { f.GasLimit = new(big.Int).SetUint64(MaxGasLimit) },
+		},
+		"zero target block rate": {
+			modify:      func(f *FeeConfig) { f.TargetBlockRate = 0 },
+			errContains: "targetBlockRate",
+		},
+		"target block rate above maximum": {
+			modify:      func(f *FeeConfig) { f.TargetBlockRate = MaxFeeConfigTargetBlockRate + 1 },
+			errContains: "targetBlockRate",
+		},
+		"min base fee above maximum": {
+			modify:      func(f *FeeConfig) { f.MinBaseFee = big.NewInt(MaxFeeConfigMinBaseFee + 1) },
+			errContains: "minBaseFee",
+		},
+		"zero target gas": {
+			modify:      func(f *FeeConfig) { f.TargetGas = big.NewInt(0) },
+			errContains: "targetGas",
+		},
+		"target gas above rollup window": {
+			modify:      func(f *FeeConfig) { f.TargetGas = big.NewInt(8_000_000*int64(RollupWindow) + 1) },
+			errContains: "targetGas",
+		},
+		"base fee change denominator above maximum": {
+			modify:      func(f *FeeConfig) { f.BaseFeeChangeDenominator = big.NewInt(MaxFeeConfigBaseFeeChangeDenominator + 1) },
+			errContains: "baseFeeChangeDenominator",
+		},
+		"max block gas cost above gas limit": {
+			modify:      func(f *FeeConfig) { f.MaxBlockGasCost = big.NewInt(8_000_001) },
+			errContains: "maxBlockGasCost",
+		},
+		"min block gas cost above max": {
+			modify:      func(f *FeeConfig) { f.MinBlockGasCost = big.NewInt(1_000_001) },
+			errContains: "minBlockGasCost",
+		},
+		"block gas cost step above max block gas cost": {
+			modify:      func(f *FeeConfig) { f.BlockGasCostStep = big.NewInt(1_000_001) },
+			errContains: "blockGasCostStep",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			feeConfig := valid
+			test.modify(&feeConfig)
+			err := feeConfig.Verify()
+			if test.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, test.errContains)
+		})
+	}
+}
@@ -42,6 +42,9 @@ func (u *PrecompileUpgrade) UnmarshalJSON(data []byte) error {
 	for key, value := range raw {
 		module, ok := modules.GetPrecompileModule(key)
 		if !ok {
+			if modules.IsRegisteredModule(key) {
+				return fmt.Errorf("precompile config %s is for a precompile not enabled on this node", key)
+			}
 			return fmt.Errorf("unknown precompile config: %s", key)
 		}
 		config := module.MakeConfig()
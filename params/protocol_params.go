@@ -167,6 +167,10 @@ const (
 	Bls12381MapG1Gas          uint64 = 5500   // Gas price for BLS12-381 mapping field element to G1 operation
 	Bls12381MapG2Gas          uint64 = 110000 // Gas price for BLS12-381 mapping field element to G2 operation
 
+	P256VerifyGas                uint64 = 3450   // Gas price for the RIP-7212 secp256r1 signature verification
+	BlsSignatureVerifyBaseGas    uint64 = 200000 // Base price for a BLS signature verification, matching warp's per-signature cost
+	BlsSignatureVerifyPerWordGas uint64 = 12     // Per-word price for hashing the message of a BLS signature verification
+
 	// The Refund Quotient is the cap on how much of the used gas can be refunded. Before EIP-3529,
 	// up to half the consumed gas could be refunded. Redefined as 1/5th in EIP-3529
 	RefundQuotient        uint64 = 2
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -89,3 +90,55 @@ func (p *Admin) GetVMConfig(_ *http.Request, _ *struct{}, reply *ConfigReply) er
 	reply.Config = &p.vm.config
 	return nil
 }
+
+type StateSummaryRangeReply struct {
+	Oldest uint64 `json:"oldest"`
+	Newest uint64 `json:"newest"`
+}
+
+// GetStateSummaryRange returns the range of heights for which this node can
+// serve state sync summaries.
+func (p *Admin) GetStateSummaryRange(r *http.Request, _ *struct{}, reply *StateSummaryRangeReply) error {
+	log.Info("Admin: GetStateSummaryRange called")
+
+	oldest, newest, err := p.vm.StateSummaryRange(r.Context())
+	if err != nil {
+		return fmt.Errorf("no state summary available: %w", err)
+	}
+	reply.Oldest = oldest
+	reply.Newest = newest
+	return nil
+}
+
+type PushGossipState struct {
+	Level         int     `json:"level"`
+	DuplicateRate float64 `json:"duplicateRate"`
+}
+
+type GossipBandwidthReply struct {
+	Protocols  map[string]GossipBandwidth `json:"protocols"`
+	Peers      map[string]GossipBandwidth `json:"peers"`
+	PushGossip map[string]PushGossipState `json:"pushGossip"`
+}
+
+// GetGossipBandwidth returns the bytes of tx gossip exchanged per protocol and
+// per peer, and the current adaptive push gossip level of each protocol.
+func (p *Admin) GetGossipBandwidth(_ *http.Request, _ *struct{}, reply *GossipBandwidthReply) error {
+	log.Info("Admin: GetGossipBandwidth called")
+
+	reply.Protocols = p.vm.gossipBandwidth.byProtocol()
+	reply.Peers = make(map[string]GossipBandwidth)
+	for nodeID, bandwidth := range p.vm.gossipBandwidth.byPeer() {
+		reply.Peers[nodeID.String()] = bandwidth
+	}
+	reply.PushGossip = make(map[string]PushGossipState)
+	if ethTxPushGossiper := p.vm.ethTxPushGossiper.Get(); ethTxPushGossiper != nil {
+		level, duplicateRate := ethTxPushGossiper.state()
+		reply.PushGossip[gossipProtocols[p2p.TxGossipHandlerID]] = PushGossipState{Level: level, DuplicateRate: duplicateRate}
+	}
+	if p.vm.atomicTxPushGossiper != nil {
+		level, duplicateRate := p.vm.atomicTxPushGossiper.state()
+		reply.PushGossip[gossipProtocols[p2p.AtomicTxGossipHandlerID]] = PushGossipState{Level: level, DuplicateRate: duplicateRate}
+	}
+	return nil
+}
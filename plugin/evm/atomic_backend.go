@@ -90,9 +90,12 @@ func NewAtomicBackend(
 ) (AtomicBackend, error) {
 	atomicTrieDB := prefixdb.New(atomicTrieDBPrefix, db)
 	metadataDB := prefixdb.New(atomicTrieMetaDBPrefix, db)
+	// The leafs index migration persists its progress outside of [db], so it
+	// does not depend on blocks being accepted.
+	migrationDB := prefixdb.New(atomicTrieMetaDBPrefix, db.GetDatabase())
 	codec := repo.Codec()
 
-	atomicTrie, err := newAtomicTrie(atomicTrieDB, metadataDB, codec, lastAcceptedHeight, commitInterval)
+	atomicTrie, err := newAtomicTrie(atomicTrieDB, metadataDB, migrationDB, codec, lastAcceptedHeight, commitInterval)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,380 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ava-labs/coreth/utils"
+)
+
+const (
+	// atomicLeafsMigrationBatchSize is the number of leafs written per batch
+	// while indexing the leafs of tries committed before the index existed.
+	atomicLeafsMigrationBatchSize = 10_000
+	// atomicLeafsRetainedCommits is the number of most recent commits whose
+	// roots are served from the index, matching the summaries a node serves.
+	atomicLeafsRetainedCommits = maxSummaryRangeLookback + 1
+)
+
+var (
+	// atomicLeafsPrefix prefixes the flat [height]+[blockchainID] -> atomic
+	// requests entries of the leafs index in the atomic trie metadata database.
+	atomicLeafsPrefix = []byte("atomicTrieLeafs")
+	// atomicRootHeightPrefix prefixes the committed root -> height entries.
+	atomicRootHeightPrefix = []byte("atomicTrieRootHeight")
+	// indexedLeafsHeightKey stores the height up to which leafs are indexed.
+	indexedLeafsHeightKey = []byte("atomicTrieIndexedLeafsHeight")
+	// leafsMigrationKey stores the key the migration continues from.
+	leafsMigrationKey = []byte("atomicTrieLeafsMigration")
+)
+
+// atomicLeafsIndex maintains a flat copy of the leafs of the atomic trie
+// alongside its commits. Since the atomic trie is only appended to in order of
+// height, the leafs of the trie committed at height H are exactly the indexed
+// leafs with heights up to H, so leafs of any committed root can be served by
+// iterating the index in key order instead of iterating the trie.
+//
+// Leafs of tries committed before the index was maintained are indexed in the
+// background by migrate. Until it completes, commits only track the last
+// committed root and no root is served from the index.
+type atomicLeafsIndex struct {
+	metadataDB   database.Database
+	leafsDB      database.Database
+	rootHeightDB database.Database
+	trieDB       *triedb.Database
+
+	// The migration writes directly to the database underlying [metadataDB],
+	// so its progress is persisted independently of block acceptance. This is
+	// safe since the indexed leafs of committed heights never change.
+	migrationDB      database.Database
+	migrationLeafsDB database.Database
+
+	commitInterval  uint64
+	retainedCommits uint64
+	batchSize       int
+
+	// lock protects the fields below and serializes indexing commits with
+	// completing the migration.
+	lock                sync.Mutex
+	lastCommittedRoot   common.Hash
+	lastCommittedHeight uint64
+
+	// migrated is set once the leafs of all committed tries are indexed.
+	migrated atomic.Bool
+	// indexedHeight is the height of the last commit whose leafs are indexed.
+	indexedHeight atomic.Uint64
+}
+
+func newAtomicLeafsIndex(
+	metadataDB database.Database, migrationDB database.Database, trieDB *triedb.Database,
+	lastCommittedRoot common.Hash, lastCommittedHeight uint64, commitInterval uint64,
+) (*atomicLeafsIndex, error) {
+	index := &atomicLeafsIndex{
+		metadataDB:          metadataDB,
+		leafsDB:             prefixdb.New(atomicLeafsPrefix, metadataDB),
+		rootHeightDB:        prefixdb.New(atomicRootHeightPrefix, metadataDB),
+		trieDB:              trieDB,
+		migrationDB:         migrationDB,
+		migrationLeafsDB:    prefixdb.New(atomicLeafsPrefix, migrationDB),
+		commitInterval:      commitInterval,
+		retainedCommits:     atomicLeafsRetainedCommits,
+		batchSize:           atomicLeafsMigrationBatchSize,
+		lastCommittedRoot:   lastCommittedRoot,
+		lastCommittedHeight: lastCommittedHeight,
+	}
+	indexedHeightBytes, err := metadataDB.Get(indexedLeafsHeightKey)
+	switch {
+	case err == database.ErrNotFound:
+		// Nothing needs to be migrated if no trie was committed yet.
+		index.migrated.Store(lastCommittedHeight == 0)
+		return index, nil
+	case err != nil:
+		return nil, err
+	}
+	indexedHeight, err := database.ParseUInt64(indexedHeightBytes)
+	if err != nil {
+		return nil, fmt.Errorf("expected value at indexedLeafsHeightKey to be a valid uint64: %w", err)
+	}
+	// If the atomic trie fell back to an earlier commit, the leafs above it
+	// are indexed again as the trie is committed. Since accepted atomic
+	// operations do not change, the entries written again are identical.
+	index.indexedHeight.Store(min(indexedHeight, lastCommittedHeight))
+	index.migrated.Store(true)
+	return index, nil
+}
+
+// index adds the leafs of [t], the atomic trie committed at [height] with
+// [root], which are not indexed yet. If the migration has not completed, the
+// leafs are left to be indexed by the migration.
+func (i *atomicLeafsIndex) index(t *trie.Trie, height uint64, root common.Hash) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.lastCommittedRoot = root
+	i.lastCommittedHeight = height
+	if !i.migrated.Load() {
+		return nil
+	}
+
+	indexedHeight := i.indexedHeight.Load()
+	nodeIt, err := t.NodeIterator(addZeroes(indexedHeight + 1))
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(nodeIt)
+	for it.Next() {
+		if err := i.leafsDB.Put(it.Key, it.Value); err != nil {
+			return err
+		}
+	}
+	if it.Err != nil {
+		return it.Err
+	}
+
+	heightBytes := database.PackUInt64(height)
+	if err := i.rootHeightDB.Put(root[:], heightBytes); err != nil {
+		return err
+	}
+	if err := i.pruneRoots(height); err != nil {
+		return err
+	}
+	if err := i.metadataDB.Put(indexedLeafsHeightKey, heightBytes); err != nil {
+		return err
+	}
+	i.indexedHeight.Store(height)
+	return nil
+}
+
+// pruneRoots removes the root committed [retainedCommits] commits before
+// [height] from [rootHeightDB], as summaries at its height are no longer
+// served. Leafs are kept, since they are part of every later trie.
+func (i *atomicLeafsIndex) pruneRoots(height uint64) error {
+	pruneDistance := i.retainedCommits * i.commitInterval
+	if height <= pruneDistance {
+		return nil
+	}
+	root, err := getRoot(i.metadataDB, height-pruneDistance)
+	if err != nil || root == (common.Hash{}) {
+		return err
+	}
+	return i.rootHeightDB.Delete(root[:])
+}
+
+// migrate indexes the leafs of the tries committed before the index was
+// maintained, in batches of [batchSize] leafs. Progress is persisted after
+// each batch, so an interrupted migration resumes where it stopped. Returns
+// once the index is complete or [quit] is closed.
+func (i *atomicLeafsIndex) migrate(quit <-chan struct{}) error {
+	if i.migrated.Load() {
+		return nil
+	}
+
+	start, err := i.migrationDB.Get(leafsMigrationKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	var (
+		startTime = time.Now()
+		lastLog   = startTime
+		numLeafs  int
+	)
+	log.Info("indexing atomic trie leafs in the background")
+	for {
+		select {
+		case <-quit:
+			log.Info("interrupted indexing atomic trie leafs", "leafs", numLeafs)
+			return nil
+		default:
+		}
+
+		i.lock.Lock()
+		root, height := i.lastCommittedRoot, i.lastCommittedHeight
+		i.lock.Unlock()
+
+		next, done, n, err := i.migrateBatch(root, height, start)
+		if err != nil {
+			return fmt.Errorf("failed to index atomic trie leafs at height %d: %w", height, err)
+		}
+		numLeafs += n
+		start = next
+		if time.Since(lastLog) > progressLogFrequency {
+			log.Info("indexing atomic trie leafs", "height", binary.BigEndian.Uint64(next[:wrappers.LongLen]), "targetHeight", height, "leafs", numLeafs)
+			lastLog = time.Now()
+		}
+		if !done {
+			continue
+		}
+
+		completed, err := i.completeMigration(height)
+		if err != nil {
+			return err
+		}
+		if completed {
+			log.Info("indexed atomic trie leafs", "height", height, "leafs", numLeafs, "duration", time.Since(startTime))
+			return nil
+		}
+		// A trie was committed while indexing the previous one, index the
+		// leafs added by it as well.
+	}
+}
+
+// migrateBatch indexes up to [batchSize] leafs of the trie committed at
+// [height] with [root] starting at [start]. Returns the key to continue from
+// and whether all leafs of the trie are indexed, along with the number of
+// leafs indexed.
+func (i *atomicLeafsIndex) migrateBatch(root common.Hash, height uint64, start []byte) ([]byte, bool, int, error) {
+	t, err := trie.New(trie.TrieID(root), i.trieDB)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	nodeIt, err := t.NodeIterator(start)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	var (
+		it       = trie.NewIterator(nodeIt)
+		batch    = i.migrationLeafsDB.NewBatch()
+		numLeafs int
+		next     []byte
+	)
+	for numLeafs < i.batchSize && it.Next() {
+		if err := batch.Put(it.Key, it.Value); err != nil {
+			return nil, false, 0, err
+		}
+		next = it.Key
+		numLeafs++
+	}
+	if next != nil {
+		// continue after the last indexed key
+		next = common.CopyBytes(next)
+		utils.IncrOne(next)
+	}
+	if it.Err != nil {
+		return nil, false, 0, it.Err
+	}
+	done := numLeafs < i.batchSize
+	if done {
+		// All leafs up to [height] are indexed, so leafs added by later
+		// commits start after it.
+		next = addZeroes(height + 1)
+	}
+	if err := batch.Write(); err != nil {
+		return nil, false, 0, err
+	}
+	if err := i.migrationDB.Put(leafsMigrationKey, next); err != nil {
+		return nil, false, 0, err
+	}
+	return next, done, numLeafs, nil
+}
+
+// completeMigration marks the index as complete if the leafs of the trie
+// committed at [height] are indexed and no later trie was committed since,
+// serving the roots of the retained commits up to [height] from the index.
+func (i *atomicLeafsIndex) completeMigration(height uint64) (bool, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.lastCommittedHeight != height {
+		return false, nil
+	}
+
+	rootHeightDB := prefixdb.New(atomicRootHeightPrefix, i.migrationDB)
+	oldest := uint64(0)
+	if retained := (i.retainedCommits - 1) * i.commitInterval; height > retained {
+		oldest = height - retained
+	}
+	for commitHeight := nearestCommitHeight(height, i.commitInterval); commitHeight >= max(oldest, i.commitInterval); commitHeight -= i.commitInterval {
+		root, err := getRoot(i.metadataDB, commitHeight)
+		if err != nil {
+			return false, err
+		}
+		if root == (common.Hash{}) {
+			continue
+		}
+		if err := rootHeightDB.Put(root[:], database.PackUInt64(commitHeight)); err != nil {
+			return false, err
+		}
+	}
+	if err := i.migrationDB.Put(indexedLeafsHeightKey, database.PackUInt64(height)); err != nil {
+		return false, err
+	}
+	if err := i.migrationDB.Delete(leafsMigrationKey); err != nil {
+		return false, err
+	}
+	i.indexedHeight.Store(height)
+	i.migrated.Store(true)
+	return true, nil
+}
+
+// LeafsIterator returns an iterator over the leafs of the atomic trie
+// committed with [root] in key order, starting at [start]. Returns false if
+// [root] is not a retained committed root whose leafs are indexed.
+func (i *atomicLeafsIndex) LeafsIterator(root common.Hash, start []byte) (ethdb.Iterator, bool) {
+	if !i.migrated.Load() {
+		return nil, false
+	}
+	heightBytes, err := i.rootHeightDB.Get(root[:])
+	if err != nil {
+		return nil, false
+	}
+	height, err := database.ParseUInt64(heightBytes)
+	if err != nil || height > i.indexedHeight.Load() {
+		return nil, false
+	}
+	return &atomicLeafsIterator{
+		Iterator: i.leafsDB.NewIteratorWithStart(start),
+		height:   height,
+	}, true
+}
+
+// atomicLeafsIterator iterates the indexed leafs up to [height].
+type atomicLeafsIterator struct {
+	database.Iterator
+	height uint64
+	done   bool
+}
+
+func (it *atomicLeafsIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if !it.Iterator.Next() {
+		it.done = true
+		return false
+	}
+	key := it.Iterator.Key()
+	if len(key) != atomicKeyLength || binary.BigEndian.Uint64(key[:wrappers.LongLen]) > it.height {
+		it.done = true
+		return false
+	}
+	return true
+}
+
+func (it *atomicLeafsIterator) Key() []byte {
+	if it.done {
+		return nil
+	}
+	return common.CopyBytes(it.Iterator.Key())
+}
+
+func (it *atomicLeafsIterator) Value() []byte {
+	if it.done {
+		return nil
+	}
+	return common.CopyBytes(it.Iterator.Value())
+}
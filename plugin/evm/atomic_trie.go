@@ -85,6 +85,16 @@ type AtomicTrie interface {
 
 	// RejectTrie dereferences root from the trieDB, freeing memory.
 	RejectTrie(root common.Hash) error
+
+	// LeafsIterator returns an iterator over the leafs of the trie committed
+	// with root in key order, starting at start. Returns false if the leafs
+	// of root are not indexed.
+	LeafsIterator(root common.Hash, start []byte) (ethdb.Iterator, bool)
+
+	// MigrateLeafsIndex indexes the leafs of the tries committed before their
+	// leafs were indexed on commit. Returns once all leafs are indexed or quit
+	// is closed.
+	MigrateLeafsIndex(quit <-chan struct{}) error
 }
 
 // AtomicTrieIterator is a stateful iterator that iterates the leafs of an AtomicTrie
@@ -125,12 +135,13 @@ type atomicTrie struct {
 	codec               codec.Manager
 	memoryCap           common.StorageSize
 	tipBuffer           *core.BoundedBuffer[common.Hash]
+	leafsIndex          *atomicLeafsIndex // flat index of the leafs of committed tries
 }
 
 // newAtomicTrie returns a new instance of a atomicTrie with a configurable commitHeightInterval, used in testing.
 // Initializes the trie before returning it.
 func newAtomicTrie(
-	atomicTrieDB database.Database, metadataDB database.Database,
+	atomicTrieDB database.Database, metadataDB database.Database, migrationDB database.Database,
 	codec codec.Manager, lastAcceptedHeight uint64, commitHeightInterval uint64,
 ) (*atomicTrie, error) {
 	root, height, err := lastCommittedRootIfExists(metadataDB)
@@ -160,6 +171,11 @@ func newAtomicTrie(
 		},
 	)
 
+	leafsIndex, err := newAtomicLeafsIndex(metadataDB, migrationDB, trieDB, root, height, commitHeightInterval)
+	if err != nil {
+		return nil, err
+	}
+
 	return &atomicTrie{
 		commitInterval:      commitHeightInterval,
 		metadataDB:          metadataDB,
@@ -169,6 +185,7 @@ func newAtomicTrie(
 		lastCommittedHeight: height,
 		tipBuffer:           core.NewBoundedBuffer(atomicTrieTipBufferSize, trieDB.Dereference),
 		memoryCap:           atomicTrieMemoryCap,
+		leafsIndex:          leafsIndex,
 		// Initialize lastAcceptedRoot to the last committed root.
 		// If there were further blocks processed (ahead of the commit interval),
 		// AtomicBackend will call InsertTrie/AcceptTrie on atomic ops
@@ -212,11 +229,19 @@ func (a *atomicTrie) OpenTrie(root common.Hash) (*trie.Trie, error) {
 	return trie.New(trie.TrieID(root), a.trieDB)
 }
 
-// commit calls commit on the underlying trieDB and updates metadata pointers.
+// commit calls commit on the underlying trieDB, indexes the leafs added since
+// the last commit and updates metadata pointers.
 func (a *atomicTrie) commit(height uint64, root common.Hash) error {
 	if err := a.trieDB.Commit(root, false); err != nil {
 		return err
 	}
+	t, err := a.OpenTrie(root)
+	if err != nil {
+		return err
+	}
+	if err := a.leafsIndex.index(t, height, root); err != nil {
+		return fmt.Errorf("failed to index atomic trie leafs at height %d: %w", height, err)
+	}
 	log.Info("committed atomic trie", "root", root.String(), "height", height)
 	return a.updateLastCommitted(root, height)
 }
@@ -374,3 +399,11 @@ func (a *atomicTrie) RejectTrie(root common.Hash) error {
 	a.trieDB.Dereference(root)
 	return nil
 }
+
+func (a *atomicTrie) LeafsIterator(root common.Hash, start []byte) (ethdb.Iterator, bool) {
+	return a.leafsIndex.LeafsIterator(root, start)
+}
+
+func (a *atomicTrie) MigrateLeafsIndex(quit <-chan struct{}) error {
+	return a.leafsIndex.migrate(quit)
+}
@@ -4,6 +4,7 @@
 package evm
 
 import (
+	"context"
 	"encoding/binary"
 	"testing"
 
@@ -15,12 +16,17 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	"github.com/ava-labs/coreth/sync/handlers"
+	handlerstats "github.com/ava-labs/coreth/sync/handlers/stats"
 )
 
 const testCommitInterval = 100
@@ -738,3 +744,143 @@ func benchmarkApplyToSharedMemory(b *testing.B, disk database.Database, blocks u
 		assert.NoError(b, backend.ApplyToSharedMemory(lastAcceptedHeight))
 	}
 }
+
+func TestAtomicTrieLeafsIndex(t *testing.T) {
+	atomicTrie := newTestAtomicTrie(t)
+
+	// process 305 blocks so that we get three commits (100, 200, 300)
+	for height := uint64(1); height <= testCommitInterval*3+5; /*=305*/ height++ {
+		assert.NoError(t, indexAtomicTxs(atomicTrie, height, testDataImportTx().mustAtomicOps()))
+	}
+
+	for height := uint64(testCommitInterval); height <= testCommitInterval*3; height += testCommitInterval {
+		assertLeafsIndexed(t, atomicTrie, height)
+	}
+
+	// roots which were not committed are not indexed
+	_, ok := atomicTrie.LeafsIterator(atomicTrie.LastAcceptedRoot(), nil)
+	assert.False(t, ok)
+
+	// leafs requests for committed roots are served from the index
+	mockHandlerStats := &handlerstats.MockHandlerStats{}
+	leafsHandler := handlers.NewIndexedLeafsRequestHandler(atomicTrie.TrieDB(), atomicTrie, message.Codec, mockHandlerStats)
+	root, err := atomicTrie.Root(2 * testCommitInterval)
+	assert.NoError(t, err)
+	responseBytes, err := leafsHandler.OnLeafsRequest(context.Background(), ids.GenerateTestNodeID(), 1, message.LeafsRequest{
+		Root:     root,
+		Start:    addZeroes(testCommitInterval / 2),
+		Limit:    testCommitInterval,
+		NodeType: message.AtomicTrieNode,
+	})
+	assert.NoError(t, err)
+	var leafsResponse message.LeafsResponse
+	_, err = message.Codec.Unmarshal(responseBytes, &leafsResponse)
+	assert.NoError(t, err)
+	assert.Len(t, leafsResponse.Keys, testCommitInterval)
+	assert.EqualValues(t, testCommitInterval/2, binary.BigEndian.Uint64(leafsResponse.Keys[0][:wrappers.LongLen]))
+	assert.EqualValues(t, 1, mockHandlerStats.SnapshotReadAttemptCount)
+	assert.EqualValues(t, 1, mockHandlerStats.SnapshotReadSuccessCount)
+}
+
+func TestAtomicTrieLeafsIndexMigration(t *testing.T) {
+	db := versiondb.New(memdb.New())
+	repo, err := NewAtomicTxRepository(db, testTxCodec(), 0)
+	assert.NoError(t, err)
+	atomicBackend, err := NewAtomicBackend(db, testSharedMemory(), nil, repo, 0, common.Hash{}, testCommitInterval)
+	assert.NoError(t, err)
+	tr := atomicBackend.AtomicTrie()
+	for height := uint64(1); height <= testCommitInterval*3; height++ {
+		assert.NoError(t, indexAtomicTxs(tr, height, testDataImportTx().mustAtomicOps()))
+	}
+
+	// remove the index to start from a node which committed tries before
+	// their leafs were indexed
+	metadataDB := prefixdb.New(atomicTrieMetaDBPrefix, db)
+	for _, prefix := range [][]byte{atomicLeafsPrefix, atomicRootHeightPrefix} {
+		prefixDB := prefixdb.New(prefix, metadataDB)
+		it := prefixDB.NewIterator()
+		for it.Next() {
+			assert.NoError(t, prefixDB.Delete(it.Key()))
+		}
+		assert.NoError(t, it.Error())
+		it.Release()
+	}
+	assert.NoError(t, metadataDB.Delete(indexedLeafsHeightKey))
+	assert.NoError(t, db.Commit())
+
+	atomicBackend, err = NewAtomicBackend(db, testSharedMemory(), nil, repo, testCommitInterval*3, common.Hash{}, testCommitInterval)
+	assert.NoError(t, err)
+	tr = atomicBackend.AtomicTrie()
+	leafsIndex := tr.(*atomicTrie).leafsIndex
+	leafsIndex.batchSize = 7
+	leafsIndex.retainedCommits = 2
+
+	// no root is served until the migration completes
+	root, err := tr.Root(testCommitInterval * 3)
+	assert.NoError(t, err)
+	_, ok := tr.LeafsIterator(root, nil)
+	assert.False(t, ok)
+
+	// an interrupted migration resumes from its persisted progress
+	next, done, numLeafs, err := leafsIndex.migrateBatch(root, testCommitInterval*3, nil)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, leafsIndex.batchSize, numLeafs)
+	quit := make(chan struct{})
+	close(quit)
+	assert.NoError(t, tr.MigrateLeafsIndex(quit))
+	progress, err := leafsIndex.migrationDB.Get(leafsMigrationKey)
+	assert.NoError(t, err)
+	assert.Equal(t, next, progress)
+
+	// tries committed before the migration completes are indexed by it
+	for height := uint64(testCommitInterval*3 + 1); height <= testCommitInterval*4; height++ {
+		assert.NoError(t, indexAtomicTxs(tr, height, testDataImportTx().mustAtomicOps()))
+	}
+	assert.NoError(t, tr.MigrateLeafsIndex(make(chan struct{})))
+	assertLeafsIndexed(t, tr, testCommitInterval*3)
+	assertLeafsIndexed(t, tr, testCommitInterval*4)
+	root, err = tr.Root(testCommitInterval * 2)
+	assert.NoError(t, err)
+	_, ok = tr.LeafsIterator(root, nil)
+	assert.False(t, ok)
+
+	// later commits are indexed on commit, pruning roots no longer served
+	for height := uint64(testCommitInterval*4 + 1); height <= testCommitInterval*5; height++ {
+		assert.NoError(t, indexAtomicTxs(tr, height, testDataImportTx().mustAtomicOps()))
+	}
+	assertLeafsIndexed(t, tr, testCommitInterval*4)
+	assertLeafsIndexed(t, tr, testCommitInterval*5)
+	root, err = tr.Root(testCommitInterval * 3)
+	assert.NoError(t, err)
+	_, ok = tr.LeafsIterator(root, nil)
+	assert.False(t, ok)
+}
+
+// assertLeafsIndexed asserts the leafs index serves exactly the leafs of the
+// trie committed at [height].
+func assertLeafsIndexed(t *testing.T, atomicTrie AtomicTrie, height uint64) {
+	t.Helper()
+
+	root, err := atomicTrie.Root(height)
+	assert.NoError(t, err)
+	trieIt, err := atomicTrie.Iterator(root, nil)
+	assert.NoError(t, err)
+	indexIt, ok := atomicTrie.LeafsIterator(root, nil)
+	if !assert.True(t, ok) {
+		return
+	}
+	defer indexIt.Release()
+
+	numLeafs := 0
+	for trieIt.Next() {
+		assert.True(t, indexIt.Next())
+		assert.Equal(t, trieIt.Key(), indexIt.Key())
+		assert.Equal(t, trieIt.Value(), indexIt.Value())
+		numLeafs++
+	}
+	assert.NoError(t, trieIt.Error())
+	assert.False(t, indexIt.Next())
+	assert.NoError(t, indexIt.Error())
+	assert.EqualValues(t, height, numLeafs)
+}
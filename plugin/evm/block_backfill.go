@@ -0,0 +1,291 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/metrics"
+	syncclient "github.com/ava-labs/coreth/sync/client"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// backfillBlocksPerRequest is the number of blocks requested from a peer at once.
+	backfillBlocksPerRequest = 64
+	// backfillRequestTimeout bounds the time spent retrying a request with
+	// peers before backing off.
+	backfillRequestTimeout = 30 * time.Second
+	// backfillRetryDelay is the initial delay before retrying a failed
+	// request, which doubles with each consecutive failure up to
+	// [backfillMaxRetryDelay].
+	backfillRetryDelay    = time.Second
+	backfillMaxRetryDelay = time.Minute
+)
+
+var (
+	errBackfillHeightMismatch = errors.New("unexpected block height")
+	errBackfillBodyMismatch   = errors.New("block body does not match header")
+
+	// backfillHeightGauge reports the height of the next block to backfill and
+	// backfillRemainingGauge the number of blocks left to fetch from peers.
+	backfillHeightGauge    = metrics.NewRegisteredGauge("block_backfill_height", nil)
+	backfillRemainingGauge = metrics.NewRegisteredGauge("block_backfill_remaining", nil)
+)
+
+// backfillTxIndexer indexes the transactions of backfilled blocks.
+type backfillTxIndexer interface {
+	IndexBackfilledBlocks(blocks []*types.Block) error
+}
+
+// blockBackfiller downloads the historical blocks and receipts the node does
+// not have after state sync, which only fetches the last [parentsToGet]
+// blocks below the block synced to and no receipts. Blocks are fetched from
+// peers in descending order of height, verified to form a hash chain with the
+// blocks already on disk, and written to disk along with their receipts,
+// verified against the receipts root of each block, with a checkpoint to
+// resume from after a restart.
+type blockBackfiller struct {
+	client  syncclient.Client
+	chaindb ethdb.Database
+	indexer backfillTxIndexer
+}
+
+func newBlockBackfiller(client syncclient.Client, chaindb ethdb.Database, indexer backfillTxIndexer) *blockBackfiller {
+	return &blockBackfiller{
+		client:  client,
+		chaindb: chaindb,
+		indexer: indexer,
+	}
+}
+
+// scheduleBlockBackfill writes the checkpoint to backfill [block] and the
+// blocks below it, which is picked up by the next call to [blockBackfiller.run].
+func scheduleBlockBackfill(db ethdb.Database, block *types.Block) error {
+	// The parents fetched by state sync are on disk and only miss their
+	// receipts, so they are not counted as remaining.
+	remaining := block.NumberU64() - 1
+	hash, height := block.ParentHash(), block.NumberU64()-1
+	for height > 0 && remaining > 0 {
+		parent := rawdb.ReadHeader(db, hash, height)
+		if parent == nil {
+			break
+		}
+		remaining--
+		hash, height = parent.ParentHash, height-1
+	}
+	return rawdb.WriteBlockBackfillProgress(db, &rawdb.SyncBlocksProgress{
+		Target:     block.Hash(),
+		NextHash:   block.Hash(),
+		NextHeight: block.NumberU64(),
+		Remaining:  remaining,
+	})
+}
+
+// run backfills blocks from the persisted checkpoint until it reaches the
+// genesis block or [ctx] is cancelled. Failed requests to peers are retried
+// with backoff. Returns nil if no backfill is scheduled.
+func (b *blockBackfiller) run(ctx context.Context) error {
+	progress, err := rawdb.ReadBlockBackfillProgress(b.chaindb)
+	if err != nil || progress == nil {
+		return err
+	}
+	log.Info("starting block backfill", "target", progress.Target, "nextHash", progress.NextHash, "nextHeight", progress.NextHeight, "remaining", progress.Remaining)
+
+	var (
+		nextHash   = progress.NextHash
+		nextHeight = progress.NextHeight
+		remaining  = progress.Remaining
+		startTime  = time.Now()
+		lastLog    = startTime
+		fetched    uint64
+	)
+	for {
+		backfillHeightGauge.Update(int64(nextHeight))
+		backfillRemainingGauge.Update(int64(remaining))
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Blocks already on disk (the target and the parents fetched by state
+		// sync, or blocks written before an unclean shutdown) are not fetched
+		// again. Reaching the genesis block completes the backfill.
+		blocks := b.readBlocks(nextHash, nextHeight)
+		if len(blocks) == 0 {
+			blocks, err = b.fetchBlocks(ctx, nextHash, nextHeight)
+			if err != nil {
+				return err
+			}
+			fetched += uint64(len(blocks))
+			remaining -= min(remaining, uint64(len(blocks)))
+		}
+		if err := b.fetchReceipts(ctx, blocks); err != nil {
+			return err
+		}
+
+		if err := b.indexer.IndexBackfilledBlocks(blocks); err != nil {
+			return fmt.Errorf("failed to index backfilled blocks: %w", err)
+		}
+		last := blocks[len(blocks)-1]
+		if last.NumberU64() == 0 {
+			log.Info("finished block backfill", "target", progress.Target, "fetched", fetched, "elapsed", time.Since(startTime))
+			backfillRemainingGauge.Update(0)
+			return rawdb.DeleteBlockBackfillProgress(b.chaindb)
+		}
+		nextHash, nextHeight = last.ParentHash(), last.NumberU64()-1
+		err = rawdb.WriteBlockBackfillProgress(b.chaindb, &rawdb.SyncBlocksProgress{
+			Target:     progress.Target,
+			NextHash:   nextHash,
+			NextHeight: nextHeight,
+			Remaining:  remaining,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write block backfill progress: %w", err)
+		}
+		if time.Since(lastLog) > progressLogFrequency {
+			log.Info("backfilling blocks", "nextHeight", nextHeight, "remaining", remaining, "fetched", fetched, "elapsed", time.Since(startTime))
+			lastLog = time.Now()
+		}
+	}
+}
+
+// readBlocks returns the contiguous blocks on disk starting at [hash] and
+// [height] in descending order of height, up to [backfillBlocksPerRequest].
+func (b *blockBackfiller) readBlocks(hash common.Hash, height uint64) []*types.Block {
+	var blocks []*types.Block
+	for len(blocks) < backfillBlocksPerRequest {
+		block := rawdb.ReadBlock(b.chaindb, hash, height)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+		if height == 0 {
+			break
+		}
+		hash, height = block.ParentHash(), height-1
+	}
+	return blocks
+}
+
+// fetchBlocks requests blocks starting at [hash] and [height] from peers and
+// writes them to disk once verified.
+func (b *blockBackfiller) fetchBlocks(ctx context.Context, hash common.Hash, height uint64) ([]*types.Block, error) {
+	parents := uint16(min(height+1, backfillBlocksPerRequest))
+	var blocks []*types.Block
+	err := retryBackfillRequest(ctx, func(ctx context.Context) error {
+		var err error
+		blocks, err = b.client.GetBlocks(ctx, hash, height, parents)
+		if err != nil {
+			return err
+		}
+		// The client verifies [blocks] form a hash chain starting at [hash].
+		for i, block := range blocks {
+			if err := verifyBackfilledBlock(block, height-uint64(i)); err != nil {
+				return fmt.Errorf("failed to verify block %s: %w", block.Hash(), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	batch := b.chaindb.NewBatch()
+	for _, block := range blocks {
+		rawdb.WriteBlock(batch, block)
+		rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64())
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// fetchReceipts writes the receipts of [blocks] which are not on disk yet,
+// requesting them from peers unless the block has no receipts. The client
+// verifies the receipts against the receipts root of each block.
+func (b *blockBackfiller) fetchReceipts(ctx context.Context, blocks []*types.Block) error {
+	batch := b.chaindb.NewBatch()
+	missing := blocks
+	for len(missing) > 0 {
+		block := missing[0]
+		switch {
+		case rawdb.HasReceipts(b.chaindb, block.Hash(), block.NumberU64()):
+			missing = missing[1:]
+		case block.ReceiptHash() == types.EmptyReceiptsHash:
+			rawdb.WriteReceipts(batch, block.Hash(), block.NumberU64(), nil)
+			missing = missing[1:]
+		default:
+			var receipts []types.Receipts
+			err := retryBackfillRequest(ctx, func(ctx context.Context) error {
+				var err error
+				receipts, err = b.client.GetReceipts(ctx, missing)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			for i, blockReceipts := range receipts {
+				rawdb.WriteReceipts(batch, missing[i].Hash(), missing[i].NumberU64(), blockReceipts)
+			}
+			missing = missing[len(receipts):]
+		}
+	}
+	return batch.Write()
+}
+
+// retryBackfillRequest calls [request] with a context expiring after
+// [backfillRequestTimeout] until it succeeds, backing off exponentially
+// between failed attempts. Returns an error only if [ctx] is cancelled.
+func retryBackfillRequest(ctx context.Context, request func(context.Context) error) error {
+	delay := backfillRetryDelay
+	for {
+		requestCtx, cancel := context.WithTimeout(ctx, backfillRequestTimeout)
+		err := request(requestCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Debug("block backfill request failed, retrying", "delay", delay, "err", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		delay = min(2*delay, backfillMaxRetryDelay)
+	}
+}
+
+// verifyBackfilledBlock checks that [block] has [height] and that its body
+// matches the commitments in its header, which is authenticated by its hash.
+func verifyBackfilledBlock(block *types.Block, height uint64) error {
+	if block.NumberU64() != height {
+		return fmt.Errorf("%w: have %d, want %d", errBackfillHeightMismatch, block.NumberU64(), height)
+	}
+	if hash := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); hash != block.TxHash() {
+		return fmt.Errorf("%w: txs hash %s, header %s", errBackfillBodyMismatch, hash, block.TxHash())
+	}
+	if hash := types.CalcUncleHash(block.Uncles()); hash != block.UncleHash() {
+		return fmt.Errorf("%w: uncle hash %s, header %s", errBackfillBodyMismatch, hash, block.UncleHash())
+	}
+	if extDataHash := block.Header().ExtDataHash; extDataHash != (common.Hash{}) {
+		if hash := types.CalcExtDataHash(block.ExtData()); hash != extDataHash {
+			return fmt.Errorf("%w: extra data hash %s, header %s", errBackfillBodyMismatch, hash, extDataHash)
+		}
+	}
+	return nil
+}
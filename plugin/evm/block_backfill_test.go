@@ -0,0 +1,216 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	statesyncclient "github.com/ava-labs/coreth/sync/client"
+	"github.com/ava-labs/coreth/sync/handlers"
+	handlerstats "github.com/ava-labs/coreth/sync/handlers/stats"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/stretchr/testify/require"
+)
+
+type testBackfillTxIndexer struct {
+	heights []uint64
+}
+
+func (i *testBackfillTxIndexer) IndexBackfilledBlocks(blocks []*types.Block) error {
+	for _, block := range blocks {
+		i.heights = append(i.heights, block.NumberU64())
+	}
+	return nil
+}
+
+// newBackfillTestChain generates a chain of [numBlocks] blocks, with a
+// transaction in every other block, and a mock client serving its blocks and
+// receipts.
+func newBackfillTestChain(t *testing.T, numBlocks int) (*core.Genesis, []*types.Block, *statesyncclient.MockClient) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &core.Genesis{
+			Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	memdb := rawdb.NewMemoryDatabase()
+	genesis := gspec.MustCommit(memdb, triedb.NewDatabase(memdb, nil))
+	blocks, receipts, err := core.GenerateChain(gspec.Config, genesis, dummy.NewETHFaker(), memdb, numBlocks, 0, func(i int, b *core.BlockGen) {
+		if i%2 != 0 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(b.TxNonce(addr), addr, common.Big1, params.TxGas, common.Big1, nil), signer, key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	require.NoError(t, err)
+
+	blocksDB := make(map[common.Hash]*types.Block, len(blocks))
+	receiptsDB := make(map[common.Hash]types.Receipts, len(blocks))
+	for i, blk := range blocks {
+		blocksDB[blk.Hash()] = blk
+		receiptsDB[blk.Hash()] = append(types.Receipts{}, receipts[i]...)
+	}
+	receiptProvider := &handlers.TestReceiptProvider{
+		TestBlockProvider: handlers.TestBlockProvider{
+			GetBlockFn: func(hash common.Hash, height uint64) *types.Block {
+				blk, ok := blocksDB[hash]
+				if !ok || blk.NumberU64() != height {
+					return nil
+				}
+				return blk
+			},
+		},
+		GetReceiptsFn: func(hash common.Hash) types.Receipts {
+			return receiptsDB[hash]
+		},
+	}
+	blocksHandler := handlers.NewBlockRequestHandler(receiptProvider, message.Codec, handlerstats.NewNoopHandlerStats())
+	mockClient := statesyncclient.NewMockClient(message.Codec, nil, nil, blocksHandler)
+	mockClient.SetReceiptsHandler(handlers.NewReceiptsRequestHandler(receiptProvider, message.Codec, handlerstats.NewNoopHandlerStats()))
+	return gspec, blocks, mockClient
+}
+
+// newBackfillSyncerDB returns a database with the genesis block, [target] and
+// its [numParents] parents, as written by state sync, and schedules the
+// backfill of [target].
+func newBackfillSyncerDB(t *testing.T, gspec *core.Genesis, blocks []*types.Block, numParents int) ethdb.Database {
+	syncerDB := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(syncerDB, triedb.NewDatabase(syncerDB, nil))
+	for _, blk := range blocks[len(blocks)-1-numParents:] {
+		rawdb.WriteBlock(syncerDB, blk)
+		rawdb.WriteCanonicalHash(syncerDB, blk.Hash(), blk.NumberU64())
+	}
+	require.NoError(t, scheduleBlockBackfill(syncerDB, blocks[len(blocks)-1]))
+	return syncerDB
+}
+
+// requireBackfilled checks every block of [blocks] and its receipts are on disk.
+func requireBackfilled(t *testing.T, db ethdb.Database, blocks []*types.Block) {
+	for _, blk := range blocks {
+		require.Equal(t, blk.Hash(), rawdb.ReadCanonicalHash(db, blk.NumberU64()))
+		require.NotNil(t, rawdb.ReadBlock(db, blk.Hash(), blk.NumberU64()), "block %d not on disk", blk.NumberU64())
+		receipts := rawdb.ReadRawReceipts(db, blk.Hash(), blk.NumberU64())
+		require.NotNil(t, receipts, "receipts of block %d not on disk", blk.NumberU64())
+		require.Equal(t, blk.ReceiptHash(), types.DeriveSha(receipts, trie.NewStackTrie(nil)))
+	}
+}
+
+func TestBlockBackfill(t *testing.T) {
+	require := require.New(t)
+
+	gspec, blocks, mockClient := newBackfillTestChain(t, 200)
+	const numParents = 32
+	target := blocks[len(blocks)-1]
+	syncerDB := newBackfillSyncerDB(t, gspec, blocks, numParents)
+
+	progress, err := rawdb.ReadBlockBackfillProgress(syncerDB)
+	require.NoError(err)
+	require.Equal(target.Hash(), progress.NextHash)
+	require.Equal(target.NumberU64(), progress.NextHeight)
+	require.Equal(target.NumberU64()-numParents-1, progress.Remaining)
+
+	// Interrupt the backfill after the first response.
+	ctx, cancel := context.WithCancel(context.Background())
+	mockClient.GetBlocksIntercept = func(_ message.BlockRequest, blocks types.Blocks) (types.Blocks, error) {
+		cancel()
+		return blocks, nil
+	}
+	indexer := &testBackfillTxIndexer{}
+	backfiller := newBlockBackfiller(mockClient, syncerDB, indexer)
+	require.ErrorIs(backfiller.run(ctx), context.Canceled)
+
+	// The blocks on disk were indexed with their receipts before the interrupt.
+	progress, err = rawdb.ReadBlockBackfillProgress(syncerDB)
+	require.NoError(err)
+	require.NotNil(progress)
+	require.Equal(target.Hash(), progress.Target)
+	require.EqualValues(target.NumberU64()-numParents-1, progress.NextHeight)
+	requireBackfilled(t, syncerDB, blocks[len(blocks)-1-numParents:])
+
+	// Resuming fetches the remaining blocks and indexes every block in
+	// descending order.
+	mockClient.GetBlocksIntercept = nil
+	require.NoError(backfiller.run(context.Background()))
+	require.EqualValues(target.NumberU64()-numParents-1, mockClient.BlocksReceived())
+	requireBackfilled(t, syncerDB, blocks)
+	require.Len(indexer.heights, int(target.NumberU64())+1)
+	for i, height := range indexer.heights {
+		require.EqualValues(target.NumberU64()-uint64(i), height)
+	}
+
+	progress, err = rawdb.ReadBlockBackfillProgress(syncerDB)
+	require.NoError(err)
+	require.Nil(progress)
+}
+
+func TestBlockBackfillRetry(t *testing.T) {
+	require := require.New(t)
+
+	gspec, blocks, mockClient := newBackfillTestChain(t, 100)
+	syncerDB := newBackfillSyncerDB(t, gspec, blocks, 0)
+
+	// Failed requests for blocks and receipts are retried.
+	var failedBlocks, failedReceipts bool
+	mockClient.GetBlocksIntercept = func(_ message.BlockRequest, blocks types.Blocks) (types.Blocks, error) {
+		if !failedBlocks {
+			failedBlocks = true
+			return nil, errors.New("request failed")
+		}
+		return blocks, nil
+	}
+	mockClient.GetReceiptsIntercept = func(_ message.ReceiptsRequest, receipts []types.Receipts) ([]types.Receipts, error) {
+		if !failedReceipts {
+			failedReceipts = true
+			return nil, errors.New("request failed")
+		}
+		return receipts, nil
+	}
+	indexer := &testBackfillTxIndexer{}
+	require.NoError(newBlockBackfiller(mockClient, syncerDB, indexer).run(context.Background()))
+	require.True(failedBlocks)
+	require.True(failedReceipts)
+	requireBackfilled(t, syncerDB, blocks)
+	require.Len(indexer.heights, len(blocks)+1)
+
+	progress, err := rawdb.ReadBlockBackfillProgress(syncerDB)
+	require.NoError(err)
+	require.Nil(progress)
+}
+
+func TestVerifyBackfilledBlock(t *testing.T) {
+	require := require.New(t)
+
+	gspec := &core.Genesis{Config: params.TestChainConfig}
+	memdb := rawdb.NewMemoryDatabase()
+	genesis := gspec.MustCommit(memdb, triedb.NewDatabase(memdb, nil))
+	blocks, _, err := core.GenerateChain(params.TestChainConfig, genesis, dummy.NewETHFaker(), memdb, 1, 0, func(int, *core.BlockGen) {})
+	require.NoError(err)
+	block := blocks[0]
+
+	require.NoError(verifyBackfilledBlock(block, 1))
+	require.ErrorIs(verifyBackfilledBlock(block, 2), errBackfillHeightMismatch)
+
+	// a block with a body that does not match its header has the same hash
+	tx := types.NewTransaction(0, common.Address{}, common.Big0, params.TxGas, common.Big1, nil)
+	tampered := types.NewBlockWithHeader(block.Header()).WithBody([]*types.Transaction{tx}, nil)
+	require.Equal(block.Hash(), tampered.Hash())
+	require.ErrorIs(verifyBackfilledBlock(tampered, 1), errBackfillBodyMismatch)
+}
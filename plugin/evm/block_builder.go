@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
 	"github.com/ava-labs/coreth/params"
 	"github.com/holiman/uint256"
 
@@ -28,8 +29,9 @@ type blockBuilder struct {
 	ctx         *snow.Context
 	chainConfig *params.ChainConfig
 
-	txPool  *txpool.TxPool
-	mempool *Mempool
+	txPool      *txpool.TxPool
+	privatePool *privatepool.PrivatePool
+	mempool     *Mempool
 
 	shutdownChan <-chan struct{}
 	shutdownWg   *sync.WaitGroup
@@ -57,6 +59,7 @@ func (vm *VM) NewBlockBuilder(notifyBuildBlockChan chan<- commonEng.Message) *bl
 		ctx:                  vm.ctx,
 		chainConfig:          vm.chainConfig,
 		txPool:               vm.txPool,
+		privatePool:          vm.eth.PrivatePool(),
 		mempool:              vm.mempool,
 		shutdownChan:         vm.shutdownChan,
 		shutdownWg:           &vm.shutdownWg,
@@ -101,9 +104,10 @@ func (b *blockBuilder) handleGenerateBlock() {
 // needToBuild returns true if there are outstanding transactions to be issued
 // into a block.
 func (b *blockBuilder) needToBuild() bool {
-	size := b.txPool.PendingSize(txpool.PendingFilter{
+	filter := txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(b.txPool.GasTip()),
-	})
+	}
+	size := b.txPool.PendingSize(filter) + b.privatePool.PendingSize(filter)
 	return size > 0 || b.mempool.Len() > 0
 }
 
@@ -148,6 +152,9 @@ func (b *blockBuilder) awaitSubmittedTxs() {
 	// may orphan transactions that were previously in a preferred block.
 	txSubmitChan := make(chan core.NewTxsEvent)
 	b.txPool.SubscribeTransactions(txSubmitChan, true)
+	// Private txs are not announced with the other txs so they are not gossiped.
+	privateTxChan := make(chan core.NewTxsEvent)
+	b.privatePool.SubscribePrivateTxs(privateTxChan)
 
 	b.shutdownWg.Add(1)
 	go b.ctx.Log.RecoverAndPanic(func() {
@@ -158,6 +165,9 @@ func (b *blockBuilder) awaitSubmittedTxs() {
 			case <-txSubmitChan:
 				log.Trace("New tx detected, trying to generate a block")
 				b.signalTxsReady()
+			case <-privateTxChan:
+				log.Trace("New private tx detected, trying to generate a block")
+				b.signalTxsReady()
 			case <-b.mempool.Pending:
 				log.Trace("New atomic Tx detected, trying to generate a block")
 				b.signalTxsReady()
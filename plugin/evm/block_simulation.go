@@ -0,0 +1,94 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/miner"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+)
+
+var (
+	errAtomicTxsSizeExceeded  = errors.New("atomic txs would exceed target block size")
+	errAtomicGasLimitExceeded = errors.New("atomic txs would exceed atomic gas limit")
+)
+
+// blockSimulation holds the atomic txs skipped while building a block that is
+// only inspected.
+type blockSimulation struct {
+	skipped []skippedAtomicTx
+}
+
+// skippedAtomicTx is an atomic tx that was considered for a block but not
+// included in it.
+type skippedAtomicTx struct {
+	txID   ids.ID
+	reason error
+}
+
+// simulatedBlock is the block the VM would currently build, along with the
+// txs that were skipped while building it.
+type simulatedBlock struct {
+	block            *types.Block // nil if no block could be built
+	atomicTxs        []*Tx
+	skippedTxs       []miner.SkippedTx
+	skippedAtomicTxs []skippedAtomicTx
+}
+
+// simulateBlock builds the block the VM would currently build, without
+// issuing it or removing any txs from the mempools. Returns the reason no
+// block could be built, if any, along with the simulated block.
+// Assumes the context lock is held.
+func (vm *VM) simulateBlock() (*simulatedBlock, error) {
+	simulation := &blockSimulation{}
+	vm.simulation = simulation
+	defer func() { vm.simulation = nil }()
+
+	predicateCtx := &precompileconfig.PredicateContext{
+		SnowCtx: vm.ctx,
+	}
+	block, skippedTxs, err := vm.miner.SimulateBlock(predicateCtx)
+	// Return the atomic txs considered for the block to the mempool.
+	vm.mempool.CancelCurrentTxs()
+
+	result := &simulatedBlock{
+		block:            block,
+		skippedTxs:       skippedTxs,
+		skippedAtomicTxs: simulation.skipped,
+	}
+	if err != nil {
+		return result, err
+	}
+	result.atomicTxs, err = ExtractAtomicTxs(block.ExtData(), vm.chainConfig.IsApricotPhase5(block.Time()), vm.codec)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// discardCurrentAtomicTx discards the current atomic tx [txID] from the
+// mempool since it cannot be included in a block for [reason]. While
+// simulating a block, [txID] is only recorded as skipped instead.
+func (vm *VM) discardCurrentAtomicTx(txID ids.ID, reason error) {
+	if vm.simulation != nil {
+		vm.recordSkippedAtomicTx(txID, reason)
+		return
+	}
+	vm.mempool.DiscardCurrentTx(txID)
+}
+
+// recordSkippedAtomicTx records that the atomic tx [txID] was not included
+// in the simulated block for [reason]. No-op unless simulating a block.
+func (vm *VM) recordSkippedAtomicTx(txID ids.ID, reason error) {
+	if vm.simulation == nil {
+		return
+	}
+	vm.simulation.skipped = append(vm.simulation.skipped, skippedAtomicTx{
+		txID:   txID,
+		reason: reason,
+	})
+}
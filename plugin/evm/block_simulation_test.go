@@ -0,0 +1,91 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateBlock(t *testing.T) {
+	require := require.New(t)
+	importAmount := uint64(50000000000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONLatest, "", "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	// The API acquires the context lock, which is held by the test like by
+	// the consensus engine.
+	simulateBlock := func() (*SimulateBlockReply, error) {
+		vm.ctx.Lock.Unlock()
+		defer vm.ctx.Lock.Lock()
+		return (&SnowmanAPI{vm}).SimulateBlock(context.Background())
+	}
+
+	// Nothing to build yet
+	reply, err := simulateBlock()
+	require.NoError(err)
+	require.Equal(errEmptyBlock.Error(), reply.Error)
+
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	require.NoError(vm.mempool.AddLocalTx(importTx))
+	<-issuer
+
+	reply, err = simulateBlock()
+	require.NoError(err)
+	require.Empty(reply.Error)
+	require.Equal(uint64(1), reply.Number.ToInt().Uint64())
+	require.Equal([]ids.ID{importTx.ID()}, reply.AtomicTxs)
+	require.Empty(reply.Txs)
+	require.NotNil(reply.BaseFee)
+	require.NotNil(reply.BlockGasCost)
+
+	// Simulating must not issue the block nor the atomic tx.
+	require.Zero(vm.blockChain.CurrentBlock().Number.Uint64())
+	_, ok := vm.mempool.GetPendingTx(importTx.ID())
+	require.True(ok)
+
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+
+	txs := make([]*types.Transaction, 2)
+	for i := range txs {
+		tx := types.NewTransaction(uint64(i), testEthAddrs[1], big.NewInt(10), 21000, new(big.Int).Mul(initialBaseFee, big.NewInt(20)), nil)
+		txs[i], err = types.SignTx(tx, types.LatestSignerForChainID(vm.chainID), testKeys[0].ToECDSA())
+		require.NoError(err)
+	}
+	for _, err := range vm.txPool.AddRemotesSync(txs) {
+		require.NoError(err)
+	}
+	<-issuer
+
+	reply, err = simulateBlock()
+	require.NoError(err)
+	require.Empty(reply.Error)
+	require.Equal(uint64(2), reply.Number.ToInt().Uint64())
+	require.Equal([]common.Hash{txs[0].Hash(), txs[1].Hash()}, reply.Txs)
+	require.Empty(reply.AtomicTxs)
+	require.Equal(uint64(2*21000), uint64(reply.GasUsed))
+
+	// The simulated block is the block that is built next.
+	blk, err = vm.BuildBlock(context.Background())
+	require.NoError(err)
+	ethBlock := blk.(*chain.BlockWrapper).Block.(*Block).ethBlock
+	require.Len(ethBlock.Transactions(), 2)
+	require.Equal(txs[0].Hash(), ethBlock.Transactions()[0].Hash())
+}
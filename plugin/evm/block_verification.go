@@ -15,6 +15,8 @@ import (
 	"github.com/ava-labs/coreth/constants"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/feemanager"
+	"github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
 	"github.com/ava-labs/coreth/trie"
 )
 
@@ -104,14 +106,18 @@ func (v blockValidator) SyntacticVerify(b *Block, rules params.Rules) error {
 	}
 
 	// Enforce static gas limit after ApricotPhase1 (prior to ApricotPhase1 it's handled in processing).
-	if rules.IsCortina {
+	switch {
+	case rules.IsPrecompileEnabled(feemanager.ContractAddress):
+		// The gas limit set by the fee manager is read from the state of the
+		// parent, so it is only enforced by the consensus engine.
+	case rules.IsCortina:
 		if ethHeader.GasLimit != params.CortinaGasLimit {
 			return fmt.Errorf(
 				"expected gas limit to be %d after cortina but got %d",
 				params.CortinaGasLimit, ethHeader.GasLimit,
 			)
 		}
-	} else if rules.IsApricotPhase1 {
+	case rules.IsApricotPhase1:
 		if ethHeader.GasLimit != params.ApricotPhase1GasLimit {
 			return fmt.Errorf(
 				"expected gas limit to be %d after apricot phase 1 but got %d",
@@ -168,7 +174,11 @@ func (v blockValidator) SyntacticVerify(b *Block, rules params.Rules) error {
 		return fmt.Errorf("invalid uncle hash %v does not match calculated uncle hash %v", ethHeader.UncleHash, uncleHash)
 	}
 	// Coinbase must match the BlackholeAddr on C-Chain
-	if ethHeader.Coinbase != constants.BlackholeAddr {
+	switch {
+	case rules.IsPrecompileEnabled(rewardmanager.ContractAddress):
+		// The coinbase set by the reward manager is read from the state of the
+		// parent, so it is only enforced by the consensus engine.
+	case ethHeader.Coinbase != constants.BlackholeAddr:
 		return fmt.Errorf("invalid coinbase %v does not match required blackhole address %v", ethHeader.Coinbase, constants.BlackholeAddr)
 	}
 	// Block must not have any uncles
@@ -289,8 +299,8 @@ func (v blockValidator) SyntacticVerify(b *Block, rules params.Rules) error {
 		}
 		if ethHeader.BlobGasUsed == nil {
 			return fmt.Errorf("blob gas used must not be nil in Cancun")
-		} else if *ethHeader.BlobGasUsed > 0 {
-			return fmt.Errorf("blobs not enabled on avalanche networks: used %d blob gas, expected 0", *ethHeader.BlobGasUsed)
+		} else if *ethHeader.BlobGasUsed > 0 && !rules.IsBlobTxs {
+			return fmt.Errorf("blobs not enabled on this network: used %d blob gas, expected 0", *ethHeader.BlobGasUsed)
 		}
 	}
 	return nil
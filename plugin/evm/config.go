@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/txpool/legacypool"
 	"github.com/ava-labs/coreth/eth"
+	"github.com/ava-labs/coreth/miner"
+	"github.com/ava-labs/coreth/precompile/modules"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/spf13/cast"
@@ -43,6 +46,9 @@ const (
 	defaultPushGossipFrequency                    = 100 * time.Millisecond
 	defaultPullGossipFrequency                    = 1 * time.Second
 	defaultTxRegossipFrequency                    = 30 * time.Second
+	defaultPushGossipMinDuplicateRate             = .5
+	defaultPushGossipMaxDuplicateRate             = .9
+	defaultLocalTxsResubmitFrequency              = 1 * time.Minute
 	defaultOfflinePruningBloomFilterSize   uint64 = 512 // Default size (MB) for the offline pruner to use
 	defaultLogLevel                               = "info"
 	defaultLogJSONFormat                          = false
@@ -50,6 +56,8 @@ const (
 	defaultPopulateMissingTriesParallelism        = 1024
 	defaultStateSyncServerTrieCache               = 64 // MB
 	defaultAcceptedCacheSize                      = 32 // blocks
+	// Approx. 2 weeks worth of blocks assuming 2s block time
+	defaultBlobSidecarRetention = 604_800
 
 	// defaultStateSyncMinBlocks is the minimum number of blocks the blockchain
 	// should be ahead of local last accepted to perform state sync.
@@ -60,6 +68,19 @@ const (
 	// - state sync time: ~6 hrs.
 	defaultStateSyncMinBlocks   = 300_000
 	defaultStateSyncRequestSize = 1024 // the number of key/values to ask peers for per request
+
+	// Block requests are cheap and needed by peers to start syncing, so they
+	// are favored over leafs and code requests when requests queue.
+	defaultStateSyncServerLeafsRequestWeight = 2
+	defaultStateSyncServerBlockRequestWeight = 4
+	defaultStateSyncServerCodeRequestWeight  = 1
+
+	// A syncing peer fetches leafs with a handful of threads, so these limits
+	// leave ample room for honest peers while bounding the load a single peer
+	// or a burst of peers can put on the node.
+	defaultStateSyncServerPeerRateLimit         = 100
+	defaultStateSyncServerPeerBurst             = 200
+	defaultStateSyncServerMaxConcurrentRequests = 64
 )
 
 var (
@@ -95,6 +116,13 @@ type Config struct {
 	// If none is specified, then we use the default list [defaultEnabledAPIs]
 	EnabledEthAPIs []string `json:"eth-apis"`
 
+	// EnabledPrecompiles selects the stateful precompile modules compiled in
+	// this binary to enable, in addition to the ones enabled by default.
+	// It must match the precompiles recorded as enabled in the upgrade bytes,
+	// so every node of the chain enables the same precompiles, at the same
+	// addresses.
+	EnabledPrecompiles []modules.ModuleSelection `json:"enabled-precompiles"`
+
 	// Continuous Profiler
 	ContinuousProfilerDir       string   `json:"continuous-profiler-dir"`       // If set to non-empty string creates a continuous profiler
 	ContinuousProfilerFrequency Duration `json:"continuous-profiler-frequency"` // Frequency to run continuous profiler if enabled
@@ -112,9 +140,10 @@ type Config struct {
 	SnapshotCache             int `json:"snapshot-cache"`              // Size of the snapshot disk layer clean cache (MB)
 
 	// Eth Settings
-	Preimages      bool `json:"preimages-enabled"`
-	SnapshotWait   bool `json:"snapshot-wait"`
-	SnapshotVerify bool `json:"snapshot-verification-enabled"`
+	Preimages      bool   `json:"preimages-enabled"`
+	SnapshotWait   bool   `json:"snapshot-wait"`
+	SnapshotVerify bool   `json:"snapshot-verification-enabled"`
+	StateScheme    string `json:"state-scheme"` // Scheme used to store state trie nodes ("hash" or "path"), defaults to the scheme of the existing database
 
 	// Pruning Settings
 	Pruning                         bool    `json:"pruning-enabled"`                    // If enabled, trie roots are only persisted every 4096 blocks
@@ -129,7 +158,8 @@ type Config struct {
 	MetricsExpensiveEnabled bool `json:"metrics-expensive-enabled"` // Debug-level metrics that might impact runtime performance
 
 	// API Settings
-	LocalTxsEnabled bool `json:"local-txs-enabled"`
+	LocalTxsEnabled           bool     `json:"local-txs-enabled"`            // If enabled, txs submitted via this node are prioritized, journaled and resubmitted until accepted
+	LocalTxsResubmitFrequency Duration `json:"local-txs-resubmit-frequency"` // Frequency to resubmit and regossip local txs that are not yet accepted
 
 	TxPoolPriceLimit   uint64   `json:"tx-pool-price-limit"`
 	TxPoolPriceBump    uint64   `json:"tx-pool-price-bump"`
@@ -147,6 +177,11 @@ type Config struct {
 	AllowUnprotectedTxs      bool          `json:"allow-unprotected-txs"`
 	AllowUnprotectedTxHashes []common.Hash `json:"allow-unprotected-tx-hashes"`
 
+	// Block Building Settings
+	TxOrderingPolicy          string           `json:"tx-ordering-policy"`           // Order in which pending txs are included in built blocks: price-and-nonce (default), fifo or priority-senders
+	TxOrderingPrioritySenders []common.Address `json:"tx-ordering-priority-senders"` // Senders whose txs are included first with the priority-senders policy
+	FeeRecipient              common.Address   `json:"fee-recipient"`                // Receives the fees of built blocks while the reward manager allows fee recipients, burned if empty
+
 	// Keystore Settings
 	KeystoreDirectory             string `json:"keystore-directory"` // both absolute and relative supported
 	KeystoreExternalSigner        string `json:"keystore-external-signer"`
@@ -163,6 +198,14 @@ type Config struct {
 	RegossipFrequency         Duration `json:"regossip-frequency"`
 	TxRegossipFrequency       Duration `json:"tx-regossip-frequency"` // Deprecated: use RegossipFrequency instead
 
+	// Adaptive push gossip lowers the push fanout and regossip frequency while
+	// validators pulling from this node already know more than the max
+	// duplicate rate of the txs it recently pushed, and raises them back while
+	// they know less than the min duplicate rate.
+	PushGossipAdaptiveEnabled          bool    `json:"push-gossip-adaptive-enabled"`
+	PushGossipAdaptiveMinDuplicateRate float64 `json:"push-gossip-adaptive-min-duplicate-rate"`
+	PushGossipAdaptiveMaxDuplicateRate float64 `json:"push-gossip-adaptive-max-duplicate-rate"`
+
 	// Log
 	LogLevel      string `json:"log-level"`
 	LogJSONFormat bool   `json:"log-json-format"`
@@ -183,6 +226,15 @@ type Config struct {
 	StateSyncCommitInterval  uint64 `json:"state-sync-commit-interval"`
 	StateSyncMinBlocks       uint64 `json:"state-sync-min-blocks"`
 	StateSyncRequestSize     uint16 `json:"state-sync-request-size"`
+	StateSyncBackfillBlocks  bool   `json:"state-sync-backfill-blocks"` // Downloads the blocks below the block synced to and their receipts from peers in the background
+
+	// Sync server request limits
+	StateSyncServerPeerRateLimit         float64 `json:"state-sync-server-peer-rate-limit"`         // Sync requests per second served to each peer, 0 disables rate limiting
+	StateSyncServerPeerBurst             int     `json:"state-sync-server-peer-burst"`              // Maximum burst of sync requests served to each peer
+	StateSyncServerMaxConcurrentRequests int     `json:"state-sync-server-max-concurrent-requests"` // Sync requests processed at once, 0 disables queuing
+	StateSyncServerLeafsRequestWeight    int     `json:"state-sync-server-leafs-request-weight"`    // Share of processing slots for queued leafs requests
+	StateSyncServerBlockRequestWeight    int     `json:"state-sync-server-block-request-weight"`    // Share of processing slots for queued block requests
+	StateSyncServerCodeRequestWeight     int     `json:"state-sync-server-code-request-weight"`     // Share of processing slots for queued code requests
 
 	// Database Settings
 	InspectDatabase bool `json:"inspect-database"` // Inspects the database on startup if enabled.
@@ -213,6 +265,12 @@ type Config struct {
 	// TxLookupLimit can be still used to control unindexing old transactions.
 	SkipTxIndexing bool `json:"skip-tx-indexing"`
 
+	// BlobSidecarRetention is the number of accepted blocks whose blob sidecars
+	// are kept once blob txs are enabled:
+	//  * 0:   means no limit
+	//  * N:   means N block limit [HEAD-N+1, HEAD] and delete older sidecars
+	BlobSidecarRetention uint64 `json:"blob-sidecar-retention"`
+
 	// WarpOffChainMessages encodes off-chain messages (unrelated to any on-chain event ie. block or AddressedCall)
 	// that the node should be willing to sign.
 	// Note: only supports AddressedCall payloads as defined here:
@@ -269,6 +327,9 @@ func (c *Config) SetDefaults() {
 	c.PushGossipFrequency.Duration = defaultPushGossipFrequency
 	c.PullGossipFrequency.Duration = defaultPullGossipFrequency
 	c.RegossipFrequency.Duration = defaultTxRegossipFrequency
+	c.PushGossipAdaptiveMinDuplicateRate = defaultPushGossipMinDuplicateRate
+	c.PushGossipAdaptiveMaxDuplicateRate = defaultPushGossipMaxDuplicateRate
+	c.LocalTxsResubmitFrequency.Duration = defaultLocalTxsResubmitFrequency
 	c.OfflinePruningBloomFilterSize = defaultOfflinePruningBloomFilterSize
 	c.LogLevel = defaultLogLevel
 	c.LogJSONFormat = defaultLogJSONFormat
@@ -278,8 +339,15 @@ func (c *Config) SetDefaults() {
 	c.StateSyncCommitInterval = defaultSyncableCommitInterval
 	c.StateSyncMinBlocks = defaultStateSyncMinBlocks
 	c.StateSyncRequestSize = defaultStateSyncRequestSize
+	c.StateSyncServerPeerRateLimit = defaultStateSyncServerPeerRateLimit
+	c.StateSyncServerPeerBurst = defaultStateSyncServerPeerBurst
+	c.StateSyncServerMaxConcurrentRequests = defaultStateSyncServerMaxConcurrentRequests
+	c.StateSyncServerLeafsRequestWeight = defaultStateSyncServerLeafsRequestWeight
+	c.StateSyncServerBlockRequestWeight = defaultStateSyncServerBlockRequestWeight
+	c.StateSyncServerCodeRequestWeight = defaultStateSyncServerCodeRequestWeight
 	c.AllowUnprotectedTxHashes = defaultAllowUnprotectedTxHashes
 	c.AcceptedCacheSize = defaultAcceptedCacheSize
+	c.BlobSidecarRetention = defaultBlobSidecarRetention
 }
 
 func (d *Duration) UnmarshalJSON(data []byte) (err error) {
@@ -318,9 +386,30 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cannot use commit interval of 0 with pruning enabled")
 	}
 
+	if c.StateScheme != "" && c.StateScheme != rawdb.HashScheme && c.StateScheme != rawdb.PathScheme {
+		return fmt.Errorf("state-scheme is %q but must be one of %q or %q", c.StateScheme, rawdb.HashScheme, rawdb.PathScheme)
+	}
+
+	if c.StateSyncServerPeerRateLimit < 0 || c.StateSyncServerPeerBurst < 0 || c.StateSyncServerMaxConcurrentRequests < 0 {
+		return fmt.Errorf("state sync server request limits must be non-negative (rate: %f, burst: %d, concurrency: %d)", c.StateSyncServerPeerRateLimit, c.StateSyncServerPeerBurst, c.StateSyncServerMaxConcurrentRequests)
+	}
+	if c.StateSyncServerLeafsRequestWeight < 1 || c.StateSyncServerBlockRequestWeight < 1 || c.StateSyncServerCodeRequestWeight < 1 {
+		return fmt.Errorf("state sync server request weights must be positive (leafs: %d, block: %d, code: %d)", c.StateSyncServerLeafsRequestWeight, c.StateSyncServerBlockRequestWeight, c.StateSyncServerCodeRequestWeight)
+	}
+
+	if _, err := miner.NewTxOrderingPolicy(c.TxOrderingPolicy, c.TxOrderingPrioritySenders); err != nil {
+		return fmt.Errorf("invalid tx-ordering-policy: %w", err)
+	}
+
 	if c.PushGossipPercentStake < 0 || c.PushGossipPercentStake > 1 {
 		return fmt.Errorf("push-gossip-percent-stake is %f but must be in the range [0, 1]", c.PushGossipPercentStake)
 	}
+	if c.PushGossipAdaptiveEnabled && (c.PushGossipAdaptiveMinDuplicateRate < 0 || c.PushGossipAdaptiveMinDuplicateRate > c.PushGossipAdaptiveMaxDuplicateRate || c.PushGossipAdaptiveMaxDuplicateRate > 1) {
+		return fmt.Errorf("push-gossip-adaptive-min-duplicate-rate (%f) and push-gossip-adaptive-max-duplicate-rate (%f) must be ordered in the range [0, 1]", c.PushGossipAdaptiveMinDuplicateRate, c.PushGossipAdaptiveMaxDuplicateRate)
+	}
+	if c.LocalTxsEnabled && c.LocalTxsResubmitFrequency.Duration <= 0 {
+		return fmt.Errorf("local-txs-resubmit-frequency is %s but must be positive", c.LocalTxsResubmitFrequency.Duration)
+	}
 	return nil
 }
 
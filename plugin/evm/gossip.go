@@ -11,6 +11,7 @@ import (
 	"time"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -223,6 +224,21 @@ func (g GossipEthTxMarshaller) UnmarshalGossip(bytes []byte) (*GossipEthTx, erro
 	return tx, tx.Tx.UnmarshalBinary(bytes)
 }
 
+// trackingEthTxMarshaller records when each eth tx was last marshalled to be
+// sent to peers.
+type trackingEthTxMarshaller struct {
+	GossipEthTxMarshaller
+	gossiped *lru.Cache[ethcommon.Hash, time.Time]
+}
+
+func (g trackingEthTxMarshaller) MarshalGossip(tx *GossipEthTx) ([]byte, error) {
+	bytes, err := g.GossipEthTxMarshaller.MarshalGossip(tx)
+	if err == nil {
+		g.gossiped.Add(tx.Tx.Hash(), time.Now())
+	}
+	return bytes, err
+}
+
 type GossipEthTx struct {
 	Tx *types.Transaction
 }
@@ -238,6 +254,10 @@ type EthPushGossiper struct {
 }
 
 func (e *EthPushGossiper) Add(tx *types.Transaction) {
+	// Only txs submitted via this node's APIs are pushed through here.
+	if e.vm.localTxs != nil {
+		e.vm.localTxs.addEthTx(tx)
+	}
 	// eth.Backend is initialized before the [ethTxPushGossiper] is created, so
 	// we just ignore any gossip requests until it is set.
 	ethTxPushGossiper := e.vm.ethTxPushGossiper.Get()
@@ -246,3 +266,9 @@ func (e *EthPushGossiper) Add(tx *types.Transaction) {
 	}
 	ethTxPushGossiper.Add(&GossipEthTx{tx})
 }
+
+// LastGossiped returns when the tx with [hash] was last sent to peers, if it
+// was recently.
+func (e *EthPushGossiper) LastGossiped(hash ethcommon.Hash) (time.Time, bool) {
+	return e.vm.ethTxGossiped.Get(hash)
+}
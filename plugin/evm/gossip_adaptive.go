@@ -0,0 +1,224 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/utils/bloom"
+	"github.com/ava-labs/coreth/metrics"
+)
+
+const (
+	// pushGossipAdaptiveLevels is the number of fanouts used by the adaptive
+	// push gossip, each level halving the fanout of the previous one.
+	pushGossipAdaptiveLevels = 4
+	// pushGossipRecentSize is the number of recently pushed txs checked
+	// against the bloom filters of the validators pulling from this node.
+	pushGossipRecentSize = 256
+	// Pushed txs are expected to be known by validators [pushGossipMinAge]
+	// after being pushed, and are no longer checked after [pushGossipMaxAge]
+	// as they may have been included and removed from their mempools.
+	pushGossipMinAge = time.Second
+	pushGossipMaxAge = 30 * time.Second
+	// pushGossipDuplicateRateWeight is the weight of each pull request in the
+	// moving average of the duplicate rate.
+	pushGossipDuplicateRateWeight = 0.1
+	// pushGossipLevelCooldown is the minimum time between fanout changes, so
+	// the duplicate rate reflects the current fanout before changing it again.
+	pushGossipLevelCooldown = 10 * time.Second
+)
+
+var _ gossip.Gossiper = (*adaptivePushGossiper[*GossipEthTx])(nil)
+
+// pushGossiper is the subset of [gossip.PushGossiper] used by the adaptive
+// push gossip.
+type pushGossiper[T gossip.Gossipable] interface {
+	gossip.Gossiper
+	Add(gossipables ...T)
+}
+
+// newPushGossiperFunc creates a push gossiper with the given fanouts and
+// regossip frequency.
+type newPushGossiperFunc[T gossip.Gossipable] func(
+	gossipParams gossip.BranchingFactor,
+	regossipParams gossip.BranchingFactor,
+	regossipFrequency time.Duration,
+) (pushGossiper[T], error)
+
+type recentPush struct {
+	id   ids.ID
+	time time.Time
+}
+
+// adaptivePushGossiper pushes gossip to fewer peers and regossips it less
+// often as the validators pulling gossip from this node already know the txs
+// it recently pushed, and back to more peers as they stop knowing them.
+//
+// As the fanout of a [gossip.PushGossiper] cannot change, a push gossiper is
+// created per level and new gossip is added to the one of the current level.
+// Gossip keeps being regossiped by the level it was added to.
+type adaptivePushGossiper[T gossip.Gossipable] struct {
+	levels           []pushGossiper[T]
+	minDuplicateRate float64
+	maxDuplicateRate float64
+	cooldown         time.Duration
+	levelGauge       metrics.Gauge
+
+	lock          sync.Mutex
+	level         int
+	lastChange    time.Time
+	duplicateRate float64
+	observed      bool
+	recent        []recentPush
+	next          int
+}
+
+// newAdaptivePushGossiper creates [levels] push gossipers with [newPushGossiper],
+// halving the fanouts and doubling the regossip interval at each level. With
+// a single level, gossip is always pushed with the given fanouts.
+func newAdaptivePushGossiper[T gossip.Gossipable](
+	name string,
+	levels int,
+	minDuplicateRate float64,
+	maxDuplicateRate float64,
+	gossipParams gossip.BranchingFactor,
+	regossipParams gossip.BranchingFactor,
+	regossipFrequency time.Duration,
+	newPushGossiper newPushGossiperFunc[T],
+) (*adaptivePushGossiper[T], error) {
+	a := &adaptivePushGossiper[T]{
+		levels:           make([]pushGossiper[T], levels),
+		minDuplicateRate: minDuplicateRate,
+		maxDuplicateRate: maxDuplicateRate,
+		cooldown:         pushGossipLevelCooldown,
+		levelGauge:       metrics.GetOrRegisterGauge(fmt.Sprintf("gossip_%s_push_level", name), nil),
+		recent:           make([]recentPush, 0, pushGossipRecentSize),
+	}
+	for level := range a.levels {
+		pushGossiper, err := newPushGossiper(
+			scaleBranchingFactor(gossipParams, level),
+			scaleBranchingFactor(regossipParams, level),
+			regossipFrequency<<level,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create push gossiper for level %d: %w", level, err)
+		}
+		a.levels[level] = pushGossiper
+	}
+	a.levelGauge.Update(0)
+	return a, nil
+}
+
+// scaleBranchingFactor divides [factor] by 2^[level], still sampling at least
+// one validator if [factor] samples any.
+func scaleBranchingFactor(factor gossip.BranchingFactor, level int) gossip.BranchingFactor {
+	scaled := gossip.BranchingFactor{
+		StakePercentage: factor.StakePercentage / float64(int(1)<<level),
+		Validators:      factor.Validators >> level,
+		NonValidators:   factor.NonValidators >> level,
+		Peers:           factor.Peers >> level,
+	}
+	if factor.Validators > 0 {
+		scaled.Validators = max(1, scaled.Validators)
+	}
+	return scaled
+}
+
+// Add pushes [gossipables] with the fanout of the current level.
+func (a *adaptivePushGossiper[T]) Add(gossipables ...T) {
+	now := time.Now()
+
+	a.lock.Lock()
+	pushGossiper := a.levels[a.level]
+	for _, gossipable := range gossipables {
+		push := recentPush{id: gossipable.GossipID(), time: now}
+		if len(a.recent) < cap(a.recent) {
+			a.recent = append(a.recent, push)
+			continue
+		}
+		a.recent[a.next] = push
+		a.next = (a.next + 1) % len(a.recent)
+	}
+	a.lock.Unlock()
+
+	pushGossiper.Add(gossipables...)
+}
+
+// Gossip runs a cycle of gossip at every level.
+func (a *adaptivePushGossiper[T]) Gossip(ctx context.Context) error {
+	errs := make([]error, 0, len(a.levels))
+	for _, pushGossiper := range a.levels {
+		errs = append(errs, pushGossiper.Gossip(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// observePullRequest updates the duplicate rate with the share of the txs
+// recently pushed that are in the bloom filter of a pull request served by
+// this node, and moves to a lower fanout if it is above [maxDuplicateRate]
+// or to a higher one if it is below [minDuplicateRate].
+func (a *adaptivePushGossiper[T]) observePullRequest(_ ids.NodeID, requestBytes []byte) {
+	if len(a.levels) == 1 {
+		return
+	}
+	filter, salt, err := gossip.ParseAppRequest(requestBytes)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var checked, known int
+	for _, push := range a.recent {
+		age := now.Sub(push.time)
+		if age < pushGossipMinAge || age > pushGossipMaxAge {
+			continue
+		}
+		checked++
+		if bloom.Contains(filter, push.id[:], salt[:]) {
+			known++
+		}
+	}
+	if checked == 0 {
+		return
+	}
+	rate := float64(known) / float64(checked)
+	if a.observed {
+		a.duplicateRate += pushGossipDuplicateRateWeight * (rate - a.duplicateRate)
+	} else {
+		a.duplicateRate = rate
+		a.observed = true
+	}
+
+	if now.Sub(a.lastChange) < a.cooldown {
+		return
+	}
+	switch {
+	case a.duplicateRate > a.maxDuplicateRate && a.level < len(a.levels)-1:
+		a.level++
+	case a.duplicateRate < a.minDuplicateRate && a.level > 0:
+		a.level--
+	default:
+		return
+	}
+	a.lastChange = now
+	a.levelGauge.Update(int64(a.level))
+}
+
+// state returns the current level and duplicate rate.
+func (a *adaptivePushGossiper[T]) state() (int, float64) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.level, a.duplicateRate
+}
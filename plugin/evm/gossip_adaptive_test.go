@@ -0,0 +1,125 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type testGossipable ids.ID
+
+func (t testGossipable) GossipID() ids.ID { return ids.ID(t) }
+
+type testPushGossiper struct {
+	gossipParams      gossip.BranchingFactor
+	regossipParams    gossip.BranchingFactor
+	regossipFrequency time.Duration
+	added             []testGossipable
+	gossiped          int
+}
+
+func (t *testPushGossiper) Add(gossipables ...testGossipable) {
+	t.added = append(t.added, gossipables...)
+}
+
+func (t *testPushGossiper) Gossip(context.Context) error {
+	t.gossiped++
+	return nil
+}
+
+func TestAdaptivePushGossiper(t *testing.T) {
+	require := require.New(t)
+
+	var levels []*testPushGossiper
+	gossiper, err := newAdaptivePushGossiper[testGossipable](
+		"test",
+		3,
+		.5,
+		.9,
+		gossip.BranchingFactor{StakePercentage: .8, Validators: 8, Peers: 4},
+		gossip.BranchingFactor{Validators: 2},
+		time.Second,
+		func(gossipParams, regossipParams gossip.BranchingFactor, regossipFrequency time.Duration) (pushGossiper[testGossipable], error) {
+			level := &testPushGossiper{
+				gossipParams:      gossipParams,
+				regossipParams:    regossipParams,
+				regossipFrequency: regossipFrequency,
+			}
+			levels = append(levels, level)
+			return level, nil
+		},
+	)
+	require.NoError(err)
+	gossiper.cooldown = 0
+
+	// Each level halves the fanouts and doubles the regossip frequency.
+	require.Len(levels, 3)
+	require.Equal(gossip.BranchingFactor{StakePercentage: .2, Validators: 2, Peers: 1}, levels[2].gossipParams)
+	require.Equal(gossip.BranchingFactor{Validators: 1}, levels[2].regossipParams)
+	require.Equal(4*time.Second, levels[2].regossipFrequency)
+
+	pushed := make([]testGossipable, 10)
+	for i := range pushed {
+		pushed[i] = testGossipable(ids.GenerateTestID())
+	}
+	gossiper.Add(pushed...)
+	require.Len(levels[0].added, len(pushed))
+	for i := range gossiper.recent {
+		gossiper.recent[i].time = time.Now().Add(-2 * pushGossipMinAge)
+	}
+
+	pullRequest := func(known []testGossipable) []byte {
+		filter, err := gossip.NewBloomFilter(prometheus.NewRegistry(), "", 100, 0.01, 0.05)
+		require.NoError(err)
+		for _, gossipable := range known {
+			filter.Add(gossipable)
+		}
+		bloomBytes, salt := filter.Marshal()
+		requestBytes, err := gossip.MarshalAppRequest(bloomBytes, salt)
+		require.NoError(err)
+		return requestBytes
+	}
+
+	// Validators already knowing all the pushed txs lower the fanout, down to
+	// the last level.
+	saturated := pullRequest(pushed)
+	gossiper.observePullRequest(ids.EmptyNodeID, saturated)
+	level, duplicateRate := gossiper.state()
+	require.Equal(1, level)
+	require.Equal(1.0, duplicateRate)
+
+	gossiper.Add(testGossipable(ids.GenerateTestID()))
+	require.Len(levels[1].added, 1)
+
+	gossiper.observePullRequest(ids.EmptyNodeID, saturated)
+	gossiper.observePullRequest(ids.EmptyNodeID, saturated)
+	level, _ = gossiper.state()
+	require.Equal(2, level)
+
+	// The duplicate rate is smoothed, so a single validator missing the pushed
+	// txs does not raise the fanout.
+	missing := pullRequest(nil)
+	gossiper.observePullRequest(ids.EmptyNodeID, missing)
+	level, _ = gossiper.state()
+	require.Equal(2, level)
+
+	for i := 0; i < 20 && level > 0; i++ {
+		gossiper.observePullRequest(ids.EmptyNodeID, missing)
+		level, _ = gossiper.state()
+	}
+	require.Zero(level)
+
+	// Gossip is pushed and regossiped at every level.
+	require.NoError(gossiper.Gossip(context.Background()))
+	for _, level := range levels {
+		require.Equal(1, level.gossiped)
+	}
+}
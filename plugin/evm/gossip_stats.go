@@ -3,9 +3,25 @@
 
 package evm
 
-import "github.com/ava-labs/coreth/metrics"
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
 
-var _ GossipStats = &gossipStats{}
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/coreth/metrics"
+)
+
+var (
+	_ GossipStats      = &gossipStats{}
+	_ common.AppSender = bandwidthSender{}
+	_ p2p.Handler      = bandwidthHandler{}
+)
 
 // GossipStats contains methods for updating incoming and outgoing gossip stats.
 type GossipStats interface {
@@ -65,3 +81,167 @@ func (g *gossipStats) IncAtomicGossipReceivedNew()     { g.atomicGossipReceivedN
 func (g *gossipStats) IncEthTxsGossipReceivedError()   { g.ethTxsGossipReceivedError.Inc(1) }
 func (g *gossipStats) IncEthTxsGossipReceivedKnown()   { g.ethTxsGossipReceivedKnown.Inc(1) }
 func (g *gossipStats) IncEthTxsGossipReceivedNew()     { g.ethTxsGossipReceivedNew.Inc(1) }
+
+// GossipBandwidth is the number of gossip bytes exchanged with a peer or over
+// a protocol.
+type GossipBandwidth struct {
+	Sent     uint64 `json:"sent"`
+	Received uint64 `json:"received"`
+}
+
+// gossipProtocols names the sdk protocols whose bandwidth is accounted.
+var gossipProtocols = map[uint64]string{
+	p2p.TxGossipHandlerID:       "eth_txs",
+	p2p.AtomicTxGossipHandlerID: "atomic",
+}
+
+// protocolBandwidth meters the bytes exchanged over a gossip protocol.
+type protocolBandwidth struct {
+	sent     metrics.Counter
+	received metrics.Counter
+}
+
+// gossipBandwidthStats counts the bytes of push gossip, pull requests and pull
+// responses exchanged per protocol and per connected peer. Bytes pushed to
+// peers sampled by the engine are only accounted per protocol.
+type gossipBandwidthStats struct {
+	protocols map[uint64]protocolBandwidth
+
+	lock  sync.Mutex
+	peers map[ids.NodeID]*GossipBandwidth
+}
+
+func newGossipBandwidthStats() *gossipBandwidthStats {
+	protocols := make(map[uint64]protocolBandwidth, len(gossipProtocols))
+	for handlerID, name := range gossipProtocols {
+		protocols[handlerID] = protocolBandwidth{
+			sent:     metrics.GetOrRegisterCounter(fmt.Sprintf("gossip_%s_bytes_sent", name), nil),
+			received: metrics.GetOrRegisterCounter(fmt.Sprintf("gossip_%s_bytes_received", name), nil),
+		}
+	}
+	return &gossipBandwidthStats{
+		protocols: protocols,
+		peers:     make(map[ids.NodeID]*GossipBandwidth),
+	}
+}
+
+// sent records [size] bytes sent over [handlerID] to [nodeIDs], which may be
+// empty if the recipients are sampled by the engine.
+func (g *gossipBandwidthStats) sent(handlerID uint64, nodeIDs set.Set[ids.NodeID], size int) {
+	protocol, ok := g.protocols[handlerID]
+	if !ok {
+		return
+	}
+	protocol.sent.Inc(int64(size * max(1, nodeIDs.Len())))
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for nodeID := range nodeIDs {
+		g.peer(nodeID).Sent += uint64(size)
+	}
+}
+
+// received records [size] bytes received over [handlerID] from [nodeID].
+func (g *gossipBandwidthStats) received(handlerID uint64, nodeID ids.NodeID, size int) {
+	protocol, ok := g.protocols[handlerID]
+	if !ok {
+		return
+	}
+	protocol.received.Inc(int64(size))
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.peer(nodeID).Received += uint64(size)
+}
+
+// peer returns the bandwidth of [nodeID], assumes the lock is held.
+func (g *gossipBandwidthStats) peer(nodeID ids.NodeID) *GossipBandwidth {
+	bandwidth, ok := g.peers[nodeID]
+	if !ok {
+		bandwidth = &GossipBandwidth{}
+		g.peers[nodeID] = bandwidth
+	}
+	return bandwidth
+}
+
+// disconnected drops the bandwidth of [nodeID], so the peers accounted do not
+// grow with every node ever connected.
+func (g *gossipBandwidthStats) disconnected(nodeID ids.NodeID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	delete(g.peers, nodeID)
+}
+
+// byPeer returns a copy of the bandwidth of every connected peer gossip was
+// exchanged with.
+func (g *gossipBandwidthStats) byPeer() map[ids.NodeID]GossipBandwidth {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	peers := make(map[ids.NodeID]GossipBandwidth, len(g.peers))
+	for nodeID, bandwidth := range g.peers {
+		peers[nodeID] = *bandwidth
+	}
+	return peers
+}
+
+// byProtocol returns the bandwidth of every accounted protocol.
+func (g *gossipBandwidthStats) byProtocol() map[string]GossipBandwidth {
+	protocols := make(map[string]GossipBandwidth, len(g.protocols))
+	for handlerID, protocol := range g.protocols {
+		protocols[gossipProtocols[handlerID]] = GossipBandwidth{
+			Sent:     uint64(protocol.sent.Snapshot().Count()),
+			Received: uint64(protocol.received.Snapshot().Count()),
+		}
+	}
+	return protocols
+}
+
+// bandwidthSender accounts the bytes of the sdk messages sent through it.
+type bandwidthSender struct {
+	common.AppSender
+	stats *gossipBandwidthStats
+}
+
+func (b bandwidthSender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, appRequestBytes []byte) error {
+	if handlerID, n := binary.Uvarint(appRequestBytes); n > 0 {
+		b.stats.sent(handlerID, nodeIDs, len(appRequestBytes))
+	}
+	return b.AppSender.SendAppRequest(ctx, nodeIDs, requestID, appRequestBytes)
+}
+
+func (b bandwidthSender) SendAppGossip(ctx context.Context, config common.SendConfig, appGossipBytes []byte) error {
+	if handlerID, n := binary.Uvarint(appGossipBytes); n > 0 {
+		b.stats.sent(handlerID, config.NodeIDs, len(appGossipBytes))
+	}
+	return b.AppSender.SendAppGossip(ctx, config, appGossipBytes)
+}
+
+// bandwidthHandler accounts the bytes of the gossip and requests handled by
+// [handler] for [handlerID], and of the responses it sends back. Requests
+// served successfully are passed to [onRequest], if set.
+type bandwidthHandler struct {
+	p2p.Handler
+	handlerID uint64
+	stats     *gossipBandwidthStats
+	onRequest func(nodeID ids.NodeID, requestBytes []byte)
+}
+
+func (b bandwidthHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	b.stats.received(b.handlerID, nodeID, len(gossipBytes))
+	b.Handler.AppGossip(ctx, nodeID, gossipBytes)
+}
+
+func (b bandwidthHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	b.stats.received(b.handlerID, nodeID, len(requestBytes))
+	response, err := b.Handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+	if err != nil {
+		return nil, err
+	}
+	b.stats.sent(b.handlerID, set.Of(nodeID), len(response))
+	if b.onRequest != nil {
+		b.onRequest(nodeID, requestBytes)
+	}
+	return response, nil
+}
@@ -0,0 +1,75 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGossipBandwidthStats(t *testing.T) {
+	require := require.New(t)
+
+	stats := newGossipBandwidthStats()
+	before := stats.byProtocol()
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+	)
+	sender := bandwidthSender{AppSender: &enginetest.SenderStub{}, stats: stats}
+	message := func(handlerID uint64, size int) []byte {
+		msg := binary.AppendUvarint(nil, handlerID)
+		return append(msg, make([]byte, size-len(msg))...)
+	}
+
+	// Gossip pushed to peers sampled by the engine is only accounted per
+	// protocol.
+	require.NoError(sender.SendAppGossip(context.Background(), common.SendConfig{Validators: 10}, message(p2p.TxGossipHandlerID, 100)))
+	require.NoError(sender.SendAppRequest(context.Background(), set.Of(nodeID0, nodeID1), 1, message(p2p.AtomicTxGossipHandlerID, 10)))
+	// Other protocols are not accounted.
+	require.NoError(sender.SendAppGossip(context.Background(), common.SendConfig{NodeIDs: set.Of(nodeID0)}, message(p2p.TxGossipHandlerID+100, 1000)))
+
+	var observed []ids.NodeID
+	handler := bandwidthHandler{
+		Handler: p2p.TestHandler{
+			AppRequestF: func(context.Context, ids.NodeID, time.Time, []byte) ([]byte, *common.AppError) {
+				return make([]byte, 50), nil
+			},
+		},
+		handlerID: p2p.TxGossipHandlerID,
+		stats:     stats,
+		onRequest: func(nodeID ids.NodeID, _ []byte) {
+			observed = append(observed, nodeID)
+		},
+	}
+	handler.AppGossip(context.Background(), nodeID1, make([]byte, 30))
+	_, err := handler.AppRequest(context.Background(), nodeID1, time.Time{}, make([]byte, 20))
+	require.Nil(err)
+	require.Equal([]ids.NodeID{nodeID1}, observed)
+
+	require.Equal(map[ids.NodeID]GossipBandwidth{
+		nodeID0: {Sent: 10},
+		nodeID1: {Sent: 60, Received: 50},
+	}, stats.byPeer())
+
+	after := stats.byProtocol()
+	require.Equal(before["eth_txs"].Sent+150, after["eth_txs"].Sent)
+	require.Equal(before["eth_txs"].Received+50, after["eth_txs"].Received)
+	require.Equal(before["atomic"].Sent+20, after["atomic"].Sent)
+
+	// The bandwidth of disconnected peers is dropped.
+	stats.disconnected(nodeID0)
+	require.Equal(map[ids.NodeID]GossipBandwidth{
+		nodeID1: {Sent: 60, Received: 50},
+	}, stats.byPeer())
+}
@@ -0,0 +1,350 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// localTxsFinishedRetention is how long accepted and invalid local txs are
+// still reported by the API after they stop being tracked.
+const localTxsFinishedRetention = time.Hour
+
+// Kinds of txs tracked as local submissions, used as the first byte of their
+// journal key.
+const (
+	localEthTx byte = iota
+	localAtomicTx
+)
+
+// Statuses of local submissions.
+const (
+	localTxPending  = "pending"
+	localTxAccepted = "accepted"
+	localTxInvalid  = "invalid"
+)
+
+var errMalformedLocalTx = errors.New("malformed local tx journal entry")
+
+// invalidEthTxErrs are the errors of the txpool for eth txs that can never be
+// added, however the chain evolves. Other errors, such as insufficient funds
+// or the sender being reserved by another pool, may be resolved later.
+var invalidEthTxErrs = []error{
+	core.ErrNonceTooLow,
+	core.ErrNonceMax,
+	core.ErrIntrinsicGas,
+	core.ErrGasUintOverflow,
+	core.ErrMaxInitCodeSizeExceeded,
+	core.ErrTipAboveFeeCap,
+	core.ErrTipVeryHigh,
+	core.ErrFeeCapVeryHigh,
+	core.ErrBlobTxCreate,
+	core.ErrMissingBlobHashes,
+	txpool.ErrInvalidSender,
+	txpool.ErrNegativeValue,
+	txpool.ErrOversizedData,
+	types.ErrInvalidSig,
+	types.ErrInvalidChainId,
+	types.ErrUnexpectedProtection,
+	types.ErrInvalidTxType,
+	types.ErrTxTypeNotSupported,
+}
+
+// isInvalidEthTxErr returns true if [err] means the tx can never be added.
+func isInvalidEthTxErr(err error) bool {
+	for _, invalidErr := range invalidEthTxErrs {
+		if errors.Is(err, invalidErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// localTx is an eth or atomic tx submitted via this node.
+type localTx struct {
+	kind     byte
+	id       ids.ID
+	ethTx    *types.Transaction
+	atomicTx *Tx
+
+	submitted       time.Time
+	lastResubmitted time.Time
+	resubmissions   uint64
+	status          string
+	reason          string // Why the tx is invalid, if it is
+	finished        time.Time
+}
+
+// localTxTracker journals the eth and atomic txs submitted via this node, so
+// they survive restarts, and resubmits and regossips them on a schedule until
+// they are accepted or become invalid.
+type localTxTracker struct {
+	vm *VM
+	db database.Database
+
+	lock sync.Mutex
+	txs  map[ids.ID]*localTx
+}
+
+func newLocalTxTracker(vm *VM, db database.Database) *localTxTracker {
+	return &localTxTracker{
+		vm:  vm,
+		db:  db,
+		txs: make(map[ids.ID]*localTx),
+	}
+}
+
+// load reads the local txs journaled before a restart.
+func (t *localTxTracker) load() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	it := t.db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		tx, err := t.parse(it.Key(), it.Value())
+		if err != nil {
+			return err
+		}
+		t.txs[tx.id] = tx
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	log.Info("Loaded local txs journal", "txs", len(t.txs))
+	return nil
+}
+
+// parse decodes a journal entry.
+func (t *localTxTracker) parse(key, value []byte) (*localTx, error) {
+	if len(key) != 1+ids.IDLen || len(value) < wrappers.LongLen {
+		return nil, errMalformedLocalTx
+	}
+	tx := &localTx{
+		kind:      key[0],
+		submitted: time.Unix(int64(binary.BigEndian.Uint64(value)), 0),
+		status:    localTxPending,
+	}
+	copy(tx.id[:], key[1:])
+	txBytes := value[wrappers.LongLen:]
+
+	switch tx.kind {
+	case localEthTx:
+		tx.ethTx = new(types.Transaction)
+		if err := tx.ethTx.UnmarshalBinary(txBytes); err != nil {
+			return nil, fmt.Errorf("failed to parse local eth tx %s: %w", tx.id, err)
+		}
+	case localAtomicTx:
+		atomicTx, err := ExtractAtomicTx(txBytes, t.vm.codec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse local atomic tx %s: %w", tx.id, err)
+		}
+		tx.atomicTx = atomicTx
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %d", errMalformedLocalTx, tx.kind)
+	}
+	return tx, nil
+}
+
+// addEthTx starts tracking [tx] submitted via this node.
+func (t *localTxTracker) addEthTx(tx *types.Transaction) {
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		log.Warn("Failed to journal local eth tx", "hash", tx.Hash(), "err", err)
+		return
+	}
+	t.add(&localTx{
+		kind:  localEthTx,
+		id:    ids.ID(tx.Hash()),
+		ethTx: tx,
+	}, txBytes)
+}
+
+// addAtomicTx starts tracking [tx] submitted via this node.
+func (t *localTxTracker) addAtomicTx(tx *Tx) {
+	t.add(&localTx{
+		kind:     localAtomicTx,
+		id:       tx.ID(),
+		atomicTx: tx,
+	}, tx.SignedBytes())
+}
+
+func (t *localTxTracker) add(tx *localTx, txBytes []byte) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if existing, ok := t.txs[tx.id]; ok && existing.status == localTxPending {
+		return
+	}
+	tx.submitted = time.Now()
+	tx.status = localTxPending
+	t.txs[tx.id] = tx
+
+	value := make([]byte, wrappers.LongLen+len(txBytes))
+	binary.BigEndian.PutUint64(value, uint64(tx.submitted.Unix()))
+	copy(value[wrappers.LongLen:], txBytes)
+	if err := t.db.Put(journalKey(tx), value); err != nil {
+		log.Warn("Failed to journal local tx", "id", tx.id, "err", err)
+	}
+}
+
+// journalKey returns the key [tx] is journaled under.
+func journalKey(tx *localTx) []byte {
+	key := make([]byte, 1+ids.IDLen)
+	key[0] = tx.kind
+	copy(key[1:], tx.id[:])
+	return key
+}
+
+// pending returns the tracked txs that are neither accepted nor invalid.
+func (t *localTxTracker) pending() []*localTx {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	pending := make([]*localTx, 0, len(t.txs))
+	for _, tx := range t.txs {
+		if tx.status == localTxPending {
+			pending = append(pending, tx)
+		}
+	}
+	return pending
+}
+
+// resubmit re-adds the pending local txs that left the mempools and regossips
+// them, and stops tracking the ones that were accepted or became invalid.
+func (t *localTxTracker) resubmit() {
+	for _, tx := range t.pending() {
+		var status, reason string
+		switch tx.kind {
+		case localEthTx:
+			status, reason = t.resubmitEthTx(tx.ethTx)
+		case localAtomicTx:
+			status, reason = t.resubmitAtomicTx(tx.atomicTx)
+		}
+		t.update(tx, status, reason)
+	}
+	t.prune()
+}
+
+func (t *localTxTracker) resubmitEthTx(tx *types.Transaction) (string, string) {
+	if lookup, _, _ := t.vm.blockChain.GetTransactionLookup(tx.Hash()); lookup != nil {
+		return localTxAccepted, ""
+	}
+	if !t.vm.txPool.Has(tx.Hash()) {
+		err := t.vm.txPool.Add([]*types.Transaction{tx}, true, false)[0]
+		switch {
+		case err == nil || errors.Is(err, txpool.ErrAlreadyKnown):
+		case isInvalidEthTxErr(err):
+			return localTxInvalid, err.Error()
+		default:
+			// Keep the tx pending and retry on the next resubmission.
+			log.Debug("Failed to resubmit local tx", "hash", tx.Hash(), "err", err)
+			return localTxPending, ""
+		}
+	}
+	if ethTxPushGossiper := t.vm.ethTxPushGossiper.Get(); ethTxPushGossiper != nil {
+		ethTxPushGossiper.Add(&GossipEthTx{tx})
+	}
+	return localTxPending, ""
+}
+
+// resubmitAtomicTx does not hold the context lock, like the gossip handlers
+// adding remote atomic txs to the mempool, so that shutdown never waits on it.
+func (t *localTxTracker) resubmitAtomicTx(tx *Tx) (string, string) {
+	_, status, _, err := t.vm.getAtomicTx(tx.ID())
+	if err != nil {
+		// Keep the tx pending and retry on the next resubmission.
+		log.Warn("Failed to look up local atomic tx", "txID", tx.ID(), "err", err)
+		return localTxPending, ""
+	}
+	switch status {
+	case Accepted:
+		return localTxAccepted, ""
+	case Processing:
+	default:
+		if err := t.vm.mempool.AddLocalTx(tx); err != nil {
+			return localTxInvalid, err.Error()
+		}
+	}
+	t.vm.atomicTxPushGossiper.Add(&GossipAtomicTx{tx})
+	return localTxPending, ""
+}
+
+// update records the outcome of resubmitting [tx], removing it from the
+// journal once it was accepted or became invalid.
+func (t *localTxTracker) update(tx *localTx, status, reason string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	tx.status = status
+	tx.reason = reason
+	if status == localTxPending {
+		tx.lastResubmitted = now
+		tx.resubmissions++
+		return
+	}
+	tx.finished = now
+	if err := t.db.Delete(journalKey(tx)); err != nil {
+		log.Warn("Failed to remove local tx from journal", "id", tx.id, "err", err)
+	}
+	log.Debug("Stopped tracking local tx", "id", tx.id, "status", status, "reason", reason)
+}
+
+// prune forgets the txs that finished more than [localTxsFinishedRetention]
+// ago.
+func (t *localTxTracker) prune() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for id, tx := range t.txs {
+		if tx.status != localTxPending && time.Since(tx.finished) > localTxsFinishedRetention {
+			delete(t.txs, id)
+		}
+	}
+}
+
+// run resubmits the pending local txs every [frequency] until [shutdownChan]
+// is closed.
+func (t *localTxTracker) run(frequency time.Duration, shutdownChan <-chan struct{}) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.resubmit()
+		case <-shutdownChan:
+			return
+		}
+	}
+}
+
+// list returns copies of the tracked txs, oldest submission first.
+func (t *localTxTracker) list() []localTx {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	txs := make([]localTx, 0, len(t.txs))
+	for _, tx := range t.txs {
+		txs = append(txs, *tx)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].submitted.Before(txs[j].submitted)
+	})
+	return txs
+}
@@ -0,0 +1,184 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalTxTracker(t *testing.T) {
+	require := require.New(t)
+	importAmount := uint64(50000000000)
+	issuer, vm, db, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONLatest, `{"local-txs-enabled": true, "local-txs-resubmit-frequency": "1h"}`, "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	require.NotNil(vm.localTxs)
+	getLocalTxs := func() []LocalTx {
+		reply := &GetLocalTxsReply{}
+		require.NoError((&AvaxAPI{vm}).GetLocalTxs(nil, nil, reply))
+		return reply.Txs
+	}
+	acceptBlock := func() {
+		blk, err := vm.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+		require.NoError(blk.Accept(context.Background()))
+		vm.blockChain.DrainAcceptorQueue()
+	}
+
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	require.NoError(vm.issueLocalAtomicTx(importTx))
+	<-issuer
+
+	localTxs := getLocalTxs()
+	require.Len(localTxs, 1)
+	require.Equal("atomic", localTxs[0].Type)
+	require.Equal(importTx.ID().String(), localTxs[0].ID)
+	require.Equal(localTxPending, localTxs[0].Status)
+
+	// A pending tx is regossiped without being accepted.
+	vm.localTxs.resubmit()
+	localTxs = getLocalTxs()
+	require.Equal(localTxPending, localTxs[0].Status)
+	require.Equal(uint64(1), uint64(localTxs[0].Resubmissions))
+
+	acceptBlock()
+	vm.localTxs.resubmit()
+	localTxs = getLocalTxs()
+	require.Equal(localTxAccepted, localTxs[0].Status)
+
+	txs := make([]*types.Transaction, 2)
+	for i := range txs {
+		tx := types.NewTransaction(uint64(i), testEthAddrs[1], big.NewInt(10), 21000, new(big.Int).Mul(initialBaseFee, big.NewInt(20)), nil)
+		txs[i], err = types.SignTx(tx, types.LatestSignerForChainID(vm.chainID), testKeys[0].ToECDSA())
+		require.NoError(err)
+	}
+	// The first tx is submitted via the API, while the second one is only
+	// tracked and must be added to the txpool when resubmitted.
+	require.NoError(vm.eth.APIBackend.SendTx(context.Background(), txs[0]))
+	<-issuer
+	vm.localTxs.addEthTx(txs[1])
+	require.False(vm.txPool.Has(txs[1].Hash()))
+
+	// Only the pending txs are journaled across restarts.
+	restarted := newLocalTxTracker(vm, prefixdb.New(localTxsPrefix, db))
+	require.NoError(restarted.load())
+	journaled := restarted.list()
+	require.Len(journaled, 2)
+	for _, tx := range journaled {
+		require.Equal(localEthTx, tx.kind)
+		require.Equal(localTxPending, tx.status)
+	}
+
+	vm.localTxs.resubmit()
+	require.True(vm.txPool.Has(txs[1].Hash()))
+	// The resubmitted tx is promoted asynchronously.
+	require.Eventually(func() bool {
+		return vm.txPool.Status(txs[1].Hash()) == txpool.TxStatusPending
+	}, time.Second, 10*time.Millisecond)
+	localTxs = getLocalTxs()
+	require.Len(localTxs, 3)
+	for _, tx := range localTxs[1:] {
+		require.Equal("eth", tx.Type)
+		require.Equal(localTxPending, tx.Status)
+	}
+
+	acceptBlock()
+	vm.localTxs.resubmit()
+	for _, tx := range getLocalTxs() {
+		require.Equal(localTxAccepted, tx.Status)
+	}
+	restarted = newLocalTxTracker(vm, prefixdb.New(localTxsPrefix, db))
+	require.NoError(restarted.load())
+	require.Empty(restarted.list())
+
+	// A tx that cannot be added yet is kept pending, as its sender may be
+	// funded later.
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(10), 21000, new(big.Int).Mul(initialBaseFee, big.NewInt(20)), nil)
+	unfunded, err := types.SignTx(tx, types.LatestSignerForChainID(vm.chainID), testKeys[2].ToECDSA())
+	require.NoError(err)
+	vm.localTxs.addEthTx(unfunded)
+	vm.localTxs.resubmit()
+	localTxs = getLocalTxs()
+	require.Len(localTxs, 4)
+	require.Equal(unfunded.Hash().Hex(), localTxs[3].ID)
+	require.Equal(localTxPending, localTxs[3].Status)
+	require.False(vm.txPool.Has(unfunded.Hash()))
+
+	// A tx that can no longer be included is invalid. The txpool resets to
+	// the accepted block asynchronously.
+	require.Eventually(func() bool {
+		return vm.txPool.Status(txs[1].Hash()) == txpool.TxStatusUnknown
+	}, time.Second, 10*time.Millisecond)
+	tx = types.NewTransaction(0, testEthAddrs[1], big.NewInt(20), 21000, new(big.Int).Mul(initialBaseFee, big.NewInt(20)), nil)
+	reused, err := types.SignTx(tx, types.LatestSignerForChainID(vm.chainID), testKeys[0].ToECDSA())
+	require.NoError(err)
+	vm.localTxs.addEthTx(reused)
+	vm.localTxs.resubmit()
+	localTxs = getLocalTxs()
+	require.Len(localTxs, 5)
+	require.Equal(reused.Hash().Hex(), localTxs[4].ID)
+	require.Equal(localTxInvalid, localTxs[4].Status)
+	require.Contains(localTxs[4].Reason, "nonce too low")
+
+	// Only the tx kept pending is still journaled.
+	restarted = newLocalTxTracker(vm, prefixdb.New(localTxsPrefix, db))
+	require.NoError(restarted.load())
+	journaled = restarted.list()
+	require.Len(journaled, 1)
+	require.Equal(unfunded.Hash(), journaled[0].ethTx.Hash())
+}
+
+func TestLocalTxsResubmittedOnRestart(t *testing.T) {
+	require := require.New(t)
+	configJSON := `{"local-txs-enabled": true, "local-txs-resubmit-frequency": "1h"}`
+	issuer, vm, db, _, appSender := GenesisVM(t, true, genesisJSONLatest, configJSON, "")
+
+	// The tx is journaled but not in the txpool when the node stops.
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(10), 21000, new(big.Int).Mul(initialBaseFee, big.NewInt(20)), nil)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(vm.chainID), testKeys[0].ToECDSA())
+	require.NoError(err)
+	vm.localTxs.addEthTx(signedTx)
+	require.NoError(vm.Shutdown(context.Background()))
+
+	restartedVM := &VM{}
+	require.NoError(restartedVM.Initialize(
+		context.Background(),
+		NewContext(),
+		db,
+		BuildGenesisTest(t, genesisJSONLatest),
+		nil,
+		[]byte(configJSON),
+		issuer,
+		[]*commonEng.Fx{},
+		appSender,
+	))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+	require.False(restartedVM.txPool.Has(signedTx.Hash()))
+
+	// The journaled tx is resubmitted once the node is bootstrapped, without
+	// waiting for the resubmission frequency.
+	require.NoError(restartedVM.SetState(context.Background(), snow.Bootstrapping))
+	require.NoError(restartedVM.SetState(context.Background(), snow.NormalOp))
+	require.True(restartedVM.txPool.Has(signedTx.Hash()))
+}
@@ -45,6 +45,11 @@ func init() {
 		c.RegisterType(BlockSignatureRequest{}),
 		c.RegisterType(SignatureResponse{}),
 
+		// Types for serving receipts of historical blocks, registered after
+		// the existing types to keep their type IDs unchanged
+		c.RegisterType(ReceiptsRequest{}),
+		c.RegisterType(ReceiptsResponse{}),
+
 		Codec.RegisterCodec(Version, c),
 	)
 
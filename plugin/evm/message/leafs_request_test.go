@@ -178,6 +178,7 @@ type mockHandler struct {
 	handleAtomicTrieCalled,
 	handleBlockRequestCalled,
 	handleCodeRequestCalled,
+	handleReceiptsRequestCalled,
 	handleMessageSignatureCalled,
 	handleBlockSignatureCalled bool
 }
@@ -202,6 +203,11 @@ func (m *mockHandler) HandleCodeRequest(context.Context, ids.NodeID, uint32, Cod
 	return nil, nil
 }
 
+func (m *mockHandler) HandleReceiptsRequest(context.Context, ids.NodeID, uint32, ReceiptsRequest) ([]byte, error) {
+	m.handleReceiptsRequestCalled = true
+	return nil, nil
+}
+
 func (m *mockHandler) HandleMessageSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest MessageSignatureRequest) ([]byte, error) {
 	m.handleMessageSignatureCalled = true
 	return nil, nil
@@ -216,4 +222,5 @@ func (m *mockHandler) reset() {
 	m.handleAtomicTrieCalled = false
 	m.handleBlockRequestCalled = false
 	m.handleCodeRequestCalled = false
+	m.handleReceiptsRequestCalled = false
 }
@@ -0,0 +1,52 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMarshalReceiptsRequest asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalReceiptsRequest(t *testing.T) {
+	receiptsRequest := ReceiptsRequest{
+		Hash:    common.BytesToHash([]byte("some hash is here yo")),
+		Height:  1337,
+		Parents: 64,
+	}
+
+	base64ReceiptsRequest := "AAAAAAAAAAAAAAAAAABzb21lIGhhc2ggaXMgaGVyZSB5bwAAAAAAAAU5AEA="
+
+	receiptsRequestBytes, err := Codec.Marshal(Version, receiptsRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, base64ReceiptsRequest, base64.StdEncoding.EncodeToString(receiptsRequestBytes))
+
+	var r ReceiptsRequest
+	_, err = Codec.Unmarshal(receiptsRequestBytes, &r)
+	assert.NoError(t, err)
+	assert.Equal(t, receiptsRequest, r)
+}
+
+// TestMarshalReceiptsResponse asserts that the structure or serialization logic hasn't changed, primarily to
+// ensure compatibility with the network.
+func TestMarshalReceiptsResponse(t *testing.T) {
+	receiptsResponse := ReceiptsResponse{
+		Receipts: [][]byte{{0xc0}, []byte("some receipts")},
+	}
+
+	base64ReceiptsResponse := "AAAAAAACAAAAAcAAAAANc29tZSByZWNlaXB0cw=="
+
+	receiptsResponseBytes, err := Codec.Marshal(Version, receiptsResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, base64ReceiptsResponse, base64.StdEncoding.EncodeToString(receiptsResponseBytes))
+
+	var r ReceiptsResponse
+	_, err = Codec.Unmarshal(receiptsResponseBytes, &r)
+	assert.NoError(t, err)
+	assert.Equal(t, receiptsResponse.Receipts, r.Receipts)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/coreth/warp"
 	warpHandlers "github.com/ava-labs/coreth/warp/handlers"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 var _ message.RequestHandler = &networkHandler{}
@@ -24,8 +25,13 @@ type networkHandler struct {
 	stateTrieLeafsRequestHandler  *syncHandlers.LeafsRequestHandler
 	atomicTrieLeafsRequestHandler *syncHandlers.LeafsRequestHandler
 	blockRequestHandler           *syncHandlers.BlockRequestHandler
+	receiptsRequestHandler        *syncHandlers.ReceiptsRequestHandler
 	codeRequestHandler            *syncHandlers.CodeRequestHandler
 	signatureRequestHandler       *warpHandlers.SignatureRequestHandler
+
+	// syncRequestLimiter rate limits and fairly queues requests served to
+	// syncing peers, so serving state sync cannot starve the rest of the node.
+	syncRequestLimiter *syncHandlers.RequestLimiter
 }
 
 // newNetworkHandler constructs the handler for serving network requests.
@@ -33,34 +39,63 @@ func newNetworkHandler(
 	provider syncHandlers.SyncDataProvider,
 	diskDB ethdb.KeyValueReader,
 	evmTrieDB *triedb.Database,
-	atomicTrieDB *triedb.Database,
+	atomicTrie AtomicTrie,
 	warpBackend warp.Backend,
 	networkCodec codec.Manager,
+	limiterConfig syncHandlers.RequestLimiterConfig,
 ) message.RequestHandler {
 	syncStats := syncStats.NewHandlerStats(metrics.Enabled)
 	return &networkHandler{
 		stateTrieLeafsRequestHandler:  syncHandlers.NewLeafsRequestHandler(evmTrieDB, provider, networkCodec, syncStats),
-		atomicTrieLeafsRequestHandler: syncHandlers.NewLeafsRequestHandler(atomicTrieDB, nil, networkCodec, syncStats),
+		atomicTrieLeafsRequestHandler: syncHandlers.NewIndexedLeafsRequestHandler(atomicTrie.TrieDB(), atomicTrie, networkCodec, syncStats),
 		blockRequestHandler:           syncHandlers.NewBlockRequestHandler(provider, networkCodec, syncStats),
+		receiptsRequestHandler:        syncHandlers.NewReceiptsRequestHandler(provider, networkCodec, syncStats),
 		codeRequestHandler:            syncHandlers.NewCodeRequestHandler(diskDB, networkCodec, syncStats),
 		signatureRequestHandler:       warpHandlers.NewSignatureRequestHandler(warpBackend, networkCodec),
+		syncRequestLimiter:            syncHandlers.NewRequestLimiter(limiterConfig, syncStats),
 	}
 }
 
 func (n networkHandler) HandleStateTrieLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest message.LeafsRequest) ([]byte, error) {
-	return n.stateTrieLeafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+	return n.limitSyncRequest(ctx, nodeID, requestID, syncHandlers.LeafsRequestType, func() ([]byte, error) {
+		return n.stateTrieLeafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+	})
 }
 
 func (n networkHandler) HandleAtomicTrieLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest message.LeafsRequest) ([]byte, error) {
-	return n.atomicTrieLeafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+	return n.limitSyncRequest(ctx, nodeID, requestID, syncHandlers.LeafsRequestType, func() ([]byte, error) {
+		return n.atomicTrieLeafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+	})
 }
 
 func (n networkHandler) HandleBlockRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockRequest message.BlockRequest) ([]byte, error) {
-	return n.blockRequestHandler.OnBlockRequest(ctx, nodeID, requestID, blockRequest)
+	return n.limitSyncRequest(ctx, nodeID, requestID, syncHandlers.BlockRequestType, func() ([]byte, error) {
+		return n.blockRequestHandler.OnBlockRequest(ctx, nodeID, requestID, blockRequest)
+	})
+}
+
+func (n networkHandler) HandleReceiptsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, receiptsRequest message.ReceiptsRequest) ([]byte, error) {
+	return n.limitSyncRequest(ctx, nodeID, requestID, syncHandlers.BlockRequestType, func() ([]byte, error) {
+		return n.receiptsRequestHandler.OnReceiptsRequest(ctx, nodeID, requestID, receiptsRequest)
+	})
 }
 
 func (n networkHandler) HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, codeRequest message.CodeRequest) ([]byte, error) {
-	return n.codeRequestHandler.OnCodeRequest(ctx, nodeID, requestID, codeRequest)
+	return n.limitSyncRequest(ctx, nodeID, requestID, syncHandlers.CodeRequestType, func() ([]byte, error) {
+		return n.codeRequestHandler.OnCodeRequest(ctx, nodeID, requestID, codeRequest)
+	})
+}
+
+// limitSyncRequest calls [handle] once the sync request limiter admits the
+// request. Requests dropped by the limiter are not responded to.
+func (n networkHandler) limitSyncRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, requestType syncHandlers.RequestType, handle func() ([]byte, error)) ([]byte, error) {
+	release, ok := n.syncRequestLimiter.Acquire(ctx, nodeID, requestType)
+	if !ok {
+		log.Debug("dropping rate limited sync request", "nodeID", nodeID, "requestID", requestID)
+		return nil, nil
+	}
+	defer release()
+	return handle()
 }
 
 func (n networkHandler) HandleMessageSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, messageSignatureRequest message.MessageSignatureRequest) ([]byte, error) {
@@ -0,0 +1,60 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
+	"github.com/ava-labs/coreth/core/txpool"
+	"github.com/ava-labs/coreth/core/txpool/privatepool"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateTxIncludedWithoutGossip(t *testing.T) {
+	require := require.New(t)
+	importAmount := uint64(50000000000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONLatest, "", "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	acceptBlock := func() *Block {
+		blk, err := vm.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+		require.NoError(blk.Accept(context.Background()))
+		return blk.(*chain.BlockWrapper).Block.(*Block)
+	}
+
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	require.NoError(vm.mempool.AddLocalTx(importTx))
+	<-issuer
+	acceptBlock()
+
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(10), 21000, new(big.Int).Mul(initialBaseFee, big.NewInt(20)), nil)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(vm.chainID), testKeys[0].ToECDSA())
+	require.NoError(err)
+	require.NoError(vm.eth.APIBackend.SendPrivateTx(context.Background(), &privatepool.PrivateTx{Tx: signedTx}))
+
+	// The private tx triggers block building without being pending in the
+	// gossiped pools or pushed to peers.
+	<-issuer
+	require.Zero(vm.txPool.PendingSize(txpool.PendingFilter{}))
+	require.Equal(txpool.TxStatusPending, vm.txPool.Status(signedTx.Hash()))
+	_, gossiped := vm.eth.APIBackend.TxLastGossiped(signedTx.Hash())
+	require.False(gossiped)
+
+	blk := acceptBlock()
+	require.Len(blk.ethBlock.Transactions(), 1)
+	require.Equal(signedTx.Hash(), blk.ethBlock.Transactions()[0].Hash())
+}
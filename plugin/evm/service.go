@@ -36,6 +36,7 @@ var (
 	errNoSourceChain     = errors.New("no source chain provided")
 	errNilTxID           = errors.New("nil transaction ID")
 	errMissingPrivateKey = errors.New("argument 'privateKey' not given")
+	errLocalTxsDisabled  = errors.New("local txs are disabled")
 
 	initialBaseFee = big.NewInt(params.ApricotPhase3InitialBaseFee)
 )
@@ -67,6 +68,76 @@ func (api *SnowmanAPI) IssueBlock(ctx context.Context) error {
 	return nil
 }
 
+// SkippedTxReply is a tx that was considered for the simulated block but not
+// included in it. [Hash] is set for eth txs and bundles, [TxID] for atomic txs.
+type SkippedTxReply struct {
+	Hash   *common.Hash `json:"hash,omitempty"`
+	TxID   *ids.ID      `json:"txID,omitempty"`
+	Reason string       `json:"reason"`
+}
+
+// SimulateBlockReply defines the reply that will be sent from the
+// SimulateBlock API call
+type SimulateBlockReply struct {
+	// Error is the reason no block could be built. If set, only the skipped
+	// txs are populated.
+	Error string `json:"error,omitempty"`
+
+	Number         *hexutil.Big     `json:"number,omitempty"`
+	Timestamp      hexutil.Uint64   `json:"timestamp"`
+	BaseFee        *hexutil.Big     `json:"baseFee,omitempty"`
+	BlockGasCost   *hexutil.Big     `json:"blockGasCost,omitempty"`
+	GasUsed        hexutil.Uint64   `json:"gasUsed"`
+	ExtDataGasUsed *hexutil.Big     `json:"extDataGasUsed,omitempty"`
+	Txs            []common.Hash    `json:"txs"`
+	AtomicTxs      []ids.ID         `json:"atomicTxs"`
+	SkippedTxs     []SkippedTxReply `json:"skippedTxs"`
+}
+
+// SimulateBlock returns the block that would currently be built, without
+// issuing it, along with the reasons txs were skipped while building it.
+func (api *SnowmanAPI) SimulateBlock(ctx context.Context) (*SimulateBlockReply, error) {
+	api.vm.ctx.Lock.Lock()
+	simulated, err := api.vm.simulateBlock()
+	api.vm.ctx.Lock.Unlock()
+	if simulated == nil {
+		return nil, err
+	}
+
+	reply := &SimulateBlockReply{
+		Txs:        []common.Hash{},
+		AtomicTxs:  []ids.ID{},
+		SkippedTxs: make([]SkippedTxReply, 0, len(simulated.skippedTxs)+len(simulated.skippedAtomicTxs)),
+	}
+	for _, skipped := range simulated.skippedTxs {
+		hash := skipped.Hash
+		reply.SkippedTxs = append(reply.SkippedTxs, SkippedTxReply{Hash: &hash, Reason: skipped.Reason})
+	}
+	for _, skipped := range simulated.skippedAtomicTxs {
+		txID := skipped.txID
+		reply.SkippedTxs = append(reply.SkippedTxs, SkippedTxReply{TxID: &txID, Reason: skipped.reason.Error()})
+	}
+	if err != nil {
+		reply.Error = err.Error()
+		return reply, nil
+	}
+
+	block := simulated.block
+	reply.Number = (*hexutil.Big)(block.Number())
+	reply.Timestamp = hexutil.Uint64(block.Time())
+	reply.BaseFee = (*hexutil.Big)(block.BaseFee())
+	reply.BlockGasCost = (*hexutil.Big)(block.BlockGasCost())
+	reply.GasUsed = hexutil.Uint64(block.GasUsed())
+	reply.ExtDataGasUsed = (*hexutil.Big)(block.ExtDataGasUsed())
+	for _, tx := range block.Transactions() {
+		reply.Txs = append(reply.Txs, tx.Hash())
+	}
+	for _, tx := range simulated.atomicTxs {
+		reply.AtomicTxs = append(reply.AtomicTxs, tx.ID())
+	}
+	return reply, nil
+}
+
 // AvaxAPI offers Avalanche network related API methods
 type AvaxAPI struct{ vm *VM }
 
@@ -225,11 +296,7 @@ func (service *AvaxAPI) Import(_ *http.Request, args *ImportArgs, response *api.
 	}
 
 	response.TxID = tx.ID()
-	if err := service.vm.mempool.AddLocalTx(tx); err != nil {
-		return err
-	}
-	service.vm.atomicTxPushGossiper.Add(&GossipAtomicTx{tx})
-	return nil
+	return service.vm.issueLocalAtomicTx(tx)
 }
 
 // ExportAVAXArgs are the arguments to ExportAVAX
@@ -335,11 +402,7 @@ func (service *AvaxAPI) Export(_ *http.Request, args *ExportArgs, response *api.
 	}
 
 	response.TxID = tx.ID()
-	if err := service.vm.mempool.AddLocalTx(tx); err != nil {
-		return err
-	}
-	service.vm.atomicTxPushGossiper.Add(&GossipAtomicTx{tx})
-	return nil
+	return service.vm.issueLocalAtomicTx(tx)
 }
 
 // GetUTXOs gets all utxos for passed in addresses
@@ -445,11 +508,7 @@ func (service *AvaxAPI) IssueTx(r *http.Request, args *api.FormattedTx, response
 	service.vm.ctx.Lock.Lock()
 	defer service.vm.ctx.Lock.Unlock()
 
-	if err := service.vm.mempool.AddLocalTx(tx); err != nil {
-		return err
-	}
-	service.vm.atomicTxPushGossiper.Add(&GossipAtomicTx{tx})
-	return nil
+	return service.vm.issueLocalAtomicTx(tx)
 }
 
 // GetAtomicTxStatusReply defines the GetAtomicTxStatus replies returned from the API
@@ -533,3 +592,53 @@ func (service *AvaxAPI) GetAtomicTx(r *http.Request, args *api.GetTxArgs, reply
 	}
 	return nil
 }
+
+// LocalTx describes a tx submitted via this node
+type LocalTx struct {
+	Type            string      `json:"type"` // "eth" or "atomic"
+	ID              string      `json:"id"`   // Hash of eth txs, ID of atomic txs
+	Status          string      `json:"status"`
+	Reason          string      `json:"reason,omitempty"` // Why the tx is invalid, if it is
+	Submitted       json.Uint64 `json:"submitted"`        // Unix time the tx was submitted at
+	LastResubmitted json.Uint64 `json:"lastResubmitted,omitempty"`
+	Resubmissions   json.Uint64 `json:"resubmissions"`
+}
+
+// GetLocalTxsReply defines the GetLocalTxs replies returned from the API
+type GetLocalTxsReply struct {
+	Txs []LocalTx `json:"txs"`
+}
+
+// GetLocalTxs returns the eth and atomic txs submitted via this node that are
+// tracked until they are accepted or become invalid, and the ones that recently
+// were.
+func (service *AvaxAPI) GetLocalTxs(r *http.Request, _ *struct{}, reply *GetLocalTxsReply) error {
+	log.Info("EVM: GetLocalTxs called")
+
+	if service.vm.localTxs == nil {
+		return errLocalTxsDisabled
+	}
+
+	localTxs := service.vm.localTxs.list()
+	reply.Txs = make([]LocalTx, len(localTxs))
+	for i, tx := range localTxs {
+		reply.Txs[i] = LocalTx{
+			Status:        tx.status,
+			Reason:        tx.reason,
+			Submitted:     json.Uint64(tx.submitted.Unix()),
+			Resubmissions: json.Uint64(tx.resubmissions),
+		}
+		if !tx.lastResubmitted.IsZero() {
+			reply.Txs[i].LastResubmitted = json.Uint64(tx.lastResubmitted.Unix())
+		}
+		switch tx.kind {
+		case localEthTx:
+			reply.Txs[i].Type = "eth"
+			reply.Txs[i].ID = common.Hash(tx.id).Hex()
+		case localAtomicTx:
+			reply.Txs[i].Type = "atomic"
+			reply.Txs[i].ID = tx.id.String()
+		}
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/state/snapshot"
 	"github.com/ava-labs/coreth/eth"
+	"github.com/ava-labs/coreth/metrics"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ava-labs/coreth/plugin/evm/message"
 	syncclient "github.com/ava-labs/coreth/sync/client"
@@ -34,6 +35,10 @@ const (
 
 var stateSyncSummaryKey = []byte("stateSyncSummary")
 
+// resumedBlocksGauge reports the number of parent blocks that were already
+// fetched by an interrupted sync and did not need to be fetched again.
+var resumedBlocksGauge = metrics.NewRegisteredGauge("state_sync_resumed_blocks", nil)
+
 // stateSyncClientConfig defines the options and dependencies needed to construct a StateSyncerClient
 type stateSyncClientConfig struct {
 	enabled    bool
@@ -43,6 +48,9 @@ type stateSyncClientConfig struct {
 	// algorithm.
 	stateSyncMinBlocks   uint64
 	stateSyncRequestSize uint16 // number of key/value pairs to ask peers for per request
+	// If true, a backfill of the blocks below the block synced to is
+	// scheduled once sync finishes.
+	backfillBlocks bool
 
 	lastAcceptedHeight uint64
 
@@ -199,7 +207,7 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 		return block.StateSyncSkipped, fmt.Errorf("failed to commit db: %w", err)
 	}
 
-	log.Info("Starting state sync", "summary", proposedSummary)
+	log.Info("Starting state sync", "summary", proposedSummary, "resume", isResume)
 
 	// create a cancellable ctx for the state sync goroutine
 	ctx, cancel := context.WithCancel(context.Background())
@@ -226,11 +234,26 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 // syncBlocks fetches (up to) [parentsToGet] blocks from peers
 // using [client] and writes them to disk.
 // the process begins with [fromHash] and it fetches parents recursively.
-// fetching starts from the first ancestor not found on disk
+// fetching starts from the checkpoint of a previous attempt to sync to
+// [fromHash] if one exists, or otherwise from the first ancestor not found on disk.
+// A checkpoint is persisted with every batch of blocks received, so an
+// interrupted sync does not fetch the same blocks again.
 func (client *stateSyncerClient) syncBlocks(ctx context.Context, fromHash common.Hash, fromHeight uint64, parentsToGet int) error {
 	nextHash := fromHash
 	nextHeight := fromHeight
 	parentsPerRequest := uint16(32)
+	totalParents := parentsToGet
+
+	progress, err := rawdb.ReadSyncBlocksProgress(client.chaindb)
+	if err != nil {
+		return fmt.Errorf("failed to read sync blocks progress: %w", err)
+	}
+	if progress != nil && progress.Target == fromHash {
+		nextHash = progress.NextHash
+		nextHeight = progress.NextHeight
+		parentsToGet = int(progress.Remaining)
+		log.Info("resuming fetching blocks from checkpoint", "nextHash", nextHash, "nextHeight", nextHeight, "remaining", parentsToGet)
+	}
 
 	// first, check for blocks already available on disk so we don't
 	// request them from peers.
@@ -247,6 +270,7 @@ func (client *stateSyncerClient) syncBlocks(ctx context.Context, fromHash common
 		// block was not found
 		break
 	}
+	resumedBlocksGauge.Update(int64(totalParents - parentsToGet))
 
 	// get any blocks we couldn't find on disk from peers and write
 	// them to disk.
@@ -255,7 +279,7 @@ func (client *stateSyncerClient) syncBlocks(ctx context.Context, fromHash common
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		blocks, err := client.client.GetBlocks(ctx, nextHash, nextHeight, parentsPerRequest)
+		blocks, err := client.client.GetBlocks(ctx, nextHash, nextHeight, uint16(min(int(parentsPerRequest), i+1)))
 		if err != nil {
 			log.Error("could not get blocks from peer", "err", err, "nextHash", nextHash, "remaining", i+1)
 			return err
@@ -268,10 +292,24 @@ func (client *stateSyncerClient) syncBlocks(ctx context.Context, fromHash common
 			nextHash = block.ParentHash()
 			nextHeight--
 		}
+		// Persist the blocks along with a checkpoint to resume from.
+		err = rawdb.WriteSyncBlocksProgress(batch, &rawdb.SyncBlocksProgress{
+			Target:     fromHash,
+			NextHash:   nextHash,
+			NextHeight: nextHeight,
+			Remaining:  uint64(max(i+1, 0)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write sync blocks progress: %w", err)
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
 		log.Info("fetching blocks from peer", "remaining", i+1, "total", parentsToGet)
 	}
 	log.Info("fetched blocks from peer", "total", parentsToGet)
-	return batch.Write()
+	return nil
 }
 
 func (client *stateSyncerClient) syncAtomicTrie(ctx context.Context) error {
@@ -360,6 +398,12 @@ func (client *stateSyncerClient) finishSync() error {
 		return err
 	}
 
+	if client.backfillBlocks {
+		if err := scheduleBlockBackfill(client.chaindb, block); err != nil {
+			return fmt.Errorf("failed to schedule block backfill: %w", err)
+		}
+	}
+
 	if err := client.updateVMMarkers(); err != nil {
 		return fmt.Errorf("error updating vm markers, height=%d, hash=%s, err=%w", block.NumberU64(), block.Hash(), err)
 	}
@@ -397,6 +441,9 @@ func (client *stateSyncerClient) updateVMMarkers() error {
 	if err := client.metadataDB.Delete(stateSyncSummaryKey); err != nil {
 		return err
 	}
+	if err := rawdb.DeleteSyncBlocksProgress(client.chaindb); err != nil {
+		return err
+	}
 	return client.db.Commit()
 }
 
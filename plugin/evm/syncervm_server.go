@@ -16,6 +16,10 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// maxSummaryRangeLookback bounds the number of summaries older than the latest
+// one inspected by StateSummaryRange, so archival nodes do not walk the whole chain.
+const maxSummaryRangeLookback = 256
+
 type stateSyncServerConfig struct {
 	Chain      *core.BlockChain
 	AtomicTrie AtomicTrie
@@ -34,6 +38,7 @@ type stateSyncServer struct {
 type StateSyncServer interface {
 	GetLastStateSummary(context.Context) (block.StateSummary, error)
 	GetStateSummary(context.Context, uint64) (block.StateSummary, error)
+	StateSummaryRange(context.Context) (uint64, uint64, error)
 }
 
 func NewStateSyncServer(config *stateSyncServerConfig) StateSyncServer {
@@ -108,3 +113,28 @@ func (server *stateSyncServer) GetStateSummary(_ context.Context, height uint64)
 	log.Debug("Serving syncable block at requested height", "height", height, "summary", summary)
 	return summary, nil
 }
+
+// StateSummaryRange returns the lowest and highest heights for which a state
+// summary can currently be served. Heights within the range that are divisible
+// by [syncableInterval] are all served by GetStateSummary as long as their state
+// remains available. With the path-based scheme this covers every summary within
+// the retained history, otherwise typically only the latest summary.
+// If no summary is available, [database.ErrNotFound] is returned.
+func (server *stateSyncServer) StateSummaryRange(context.Context) (uint64, uint64, error) {
+	lastHeight := server.chain.LastAcceptedBlock().NumberU64()
+	newest := lastHeight - lastHeight%server.syncableInterval
+	if _, err := server.stateSummaryAtHeight(newest); err != nil {
+		log.Debug("could not get latest state summary", "err", err)
+		return 0, 0, database.ErrNotFound
+	}
+
+	oldest := newest
+	for i := 0; i < maxSummaryRangeLookback && oldest >= server.syncableInterval; i++ {
+		height := oldest - server.syncableInterval
+		if _, err := server.stateSummaryAtHeight(height); err != nil {
+			break
+		}
+		oldest = height
+	}
+	return oldest, newest, nil
+}
@@ -35,8 +35,11 @@ import (
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/metrics"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/message"
 	"github.com/ava-labs/coreth/predicate"
 	statesyncclient "github.com/ava-labs/coreth/sync/client"
+	"github.com/ava-labs/coreth/sync/handlers"
+	handlerstats "github.com/ava-labs/coreth/sync/handlers/stats"
 	"github.com/ava-labs/coreth/sync/statesync"
 	"github.com/ava-labs/coreth/trie"
 	"github.com/ava-labs/coreth/triedb"
@@ -136,7 +139,7 @@ func TestStateSyncToggleEnabledToDisabled(t *testing.T) {
 		if !hasItem {
 			t.Fatal("expected nodeSet to contain at least 1 nodeID")
 		}
-		go vmSetup.serverVM.AppRequest(ctx, nodeID, requestID, time.Now().Add(1*time.Second), request)
+		go vmSetup.syncRequests.forward(ctx, nodeID, requestID, request)
 		return nil
 	}
 	// Disable metrics to prevent duplicate registerer
@@ -217,9 +220,11 @@ func TestStateSyncToggleEnabledToDisabled(t *testing.T) {
 	); err != nil {
 		t.Fatal(err)
 	}
+	vmSetup.syncRequests.setSyncerVM(syncReEnabledVM)
 
 	// override [serverVM]'s SendAppResponse function to trigger AppResponse on [syncerVM]
 	vmSetup.serverAppSender.SendAppResponseF = func(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+		vmSetup.syncRequests.onResponse(requestID)
 		if test.responseIntercept == nil {
 			go syncReEnabledVM.AppResponse(ctx, nodeID, requestID, response)
 		} else {
@@ -275,6 +280,79 @@ func TestVMShutdownWhileSyncing(t *testing.T) {
 	testSyncerVM(t, vmSetup, test)
 }
 
+func TestSyncBlocksResumesFromCheckpoint(t *testing.T) {
+	gspec := &core.Genesis{Config: params.TestChainConfig}
+	memdb := rawdb.NewMemoryDatabase()
+	genesis := gspec.MustCommit(memdb, triedb.NewDatabase(memdb, nil))
+	blocks, _, err := core.GenerateChain(params.TestChainConfig, genesis, dummy.NewETHFaker(), memdb, 96, 0, func(int, *core.BlockGen) {})
+	require.NoError(t, err)
+
+	blocksDB := make(map[common.Hash]*types.Block, len(blocks))
+	for _, blk := range blocks {
+		blocksDB[blk.Hash()] = blk
+	}
+	blockProvider := &handlers.TestBlockProvider{
+		GetBlockFn: func(hash common.Hash, height uint64) *types.Block {
+			blk, ok := blocksDB[hash]
+			if !ok || blk.NumberU64() != height {
+				return nil
+			}
+			return blk
+		},
+	}
+	blocksHandler := handlers.NewBlockRequestHandler(blockProvider, message.Codec, handlerstats.NewNoopHandlerStats())
+	target := blocks[len(blocks)-1]
+
+	// Test with a number of parents that is a multiple of the blocks per
+	// request and one that is not.
+	for _, numParents := range []int{64, 40} {
+		t.Run(fmt.Sprintf("parents=%d", numParents), func(t *testing.T) {
+			require := require.New(t)
+
+			mockClient := statesyncclient.NewMockClient(message.Codec, nil, nil, blocksHandler)
+			syncerDB := rawdb.NewMemoryDatabase()
+			client := &stateSyncerClient{
+				stateSyncClientConfig: &stateSyncClientConfig{
+					chaindb: syncerDB,
+					client:  mockClient,
+				},
+			}
+
+			// Interrupt fetching blocks after the first response.
+			ctx, cancel := context.WithCancel(context.Background())
+			mockClient.GetBlocksIntercept = func(_ message.BlockRequest, blocks types.Blocks) (types.Blocks, error) {
+				cancel()
+				return blocks, nil
+			}
+			err := client.syncBlocks(ctx, target.Hash(), target.NumberU64(), numParents)
+			require.ErrorIs(err, context.Canceled)
+
+			progress, err := rawdb.ReadSyncBlocksProgress(syncerDB)
+			require.NoError(err)
+			require.NotNil(progress)
+			require.Equal(target.Hash(), progress.Target)
+			require.EqualValues(numParents-32, progress.Remaining)
+
+			// Resuming only fetches the blocks that were not fetched yet.
+			mockClient.GetBlocksIntercept = func(request message.BlockRequest, blocks types.Blocks) (types.Blocks, error) {
+				require.LessOrEqual(int(request.Parents), numParents-32)
+				return blocks, nil
+			}
+			require.NoError(client.syncBlocks(context.Background(), target.Hash(), target.NumberU64(), numParents))
+			require.EqualValues(numParents, mockClient.BlocksReceived())
+			for i := 0; i < numParents; i++ {
+				blk := blocks[len(blocks)-1-i]
+				require.NotNil(rawdb.ReadBlock(syncerDB, blk.Hash(), blk.NumberU64()), "block %d not on disk", blk.NumberU64())
+			}
+
+			progress, err = rawdb.ReadSyncBlocksProgress(syncerDB)
+			require.NoError(err)
+			require.NotNil(progress)
+			require.Zero(progress.Remaining)
+		})
+	}
+}
+
 func createSyncServerAndClientVMs(t *testing.T, test syncTest, numBlocks int) *syncVMSetup {
 	var (
 		require      = require.New(t)
@@ -374,8 +452,11 @@ func createSyncServerAndClientVMs(t *testing.T, test syncTest, numBlocks int) *s
 	// override [syncerVM]'s commit interval so the atomic trie works correctly.
 	syncerVM.atomicTrie.(*atomicTrie).commitInterval = test.syncableInterval
 
+	syncRequests := &syncRequestForwarder{serverVM: serverVM, syncerVM: syncerVM}
+
 	// override [serverVM]'s SendAppResponse function to trigger AppResponse on [syncerVM]
 	serverAppSender.SendAppResponseF = func(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+		syncRequests.onResponse(requestID)
 		if test.responseIntercept == nil {
 			go syncerVM.AppResponse(ctx, nodeID, requestID, response)
 		} else {
@@ -398,14 +479,14 @@ func createSyncServerAndClientVMs(t *testing.T, test syncTest, numBlocks int) *s
 	syncerAppSender.SendAppRequestF = func(ctx context.Context, nodeSet set.Set[ids.NodeID], requestID uint32, request []byte) error {
 		nodeID, hasItem := nodeSet.Pop()
 		require.True(hasItem, "expected nodeSet to contain at least 1 nodeID")
-		err := serverVM.AppRequest(ctx, nodeID, requestID, time.Now().Add(1*time.Second), request)
-		require.NoError(err)
+		require.NoError(syncRequests.forward(ctx, nodeID, requestID, request))
 		return nil
 	}
 
 	return &syncVMSetup{
 		serverVM:        serverVM,
 		serverAppSender: serverAppSender,
+		syncRequests:    syncRequests,
 		includedAtomicTxs: []*Tx{
 			importTx,
 			exportTx,
@@ -424,6 +505,7 @@ func createSyncServerAndClientVMs(t *testing.T, test syncTest, numBlocks int) *s
 type syncVMSetup struct {
 	serverVM        *VM
 	serverAppSender *enginetest.Sender
+	syncRequests    *syncRequestForwarder
 
 	includedAtomicTxs []*Tx
 	fundedAccounts    map[*keystore.Key]*types.StateAccount
@@ -435,6 +517,53 @@ type syncVMSetup struct {
 	shutdownOnceSyncerVM *shutdownOnceVM
 }
 
+// syncRequestForwarder delivers the app requests of the syncing VM to the
+// server VM. Requests the server drops without responding, such as requests
+// exceeding its rate limit, are failed on the syncing VM as the engine would
+// once they time out, so the sync client retries them.
+type syncRequestForwarder struct {
+	serverVM *VM
+
+	lock      sync.Mutex
+	syncerVM  *VM
+	responded set.Set[uint32]
+}
+
+func (f *syncRequestForwarder) setSyncerVM(vm *VM) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.syncerVM = vm
+}
+
+// onResponse records the server VM responded to [requestID].
+func (f *syncRequestForwarder) onResponse(requestID uint32) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.responded.Add(requestID)
+}
+
+func (f *syncRequestForwarder) forward(ctx context.Context, nodeID ids.NodeID, requestID uint32, request []byte) error {
+	if err := f.serverVM.AppRequest(ctx, nodeID, requestID, time.Now().Add(1*time.Second), request); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.responded.Contains(requestID) {
+		f.responded.Remove(requestID)
+		return nil
+	}
+	syncerVM := f.syncerVM
+	go func() {
+		if err := syncerVM.AppRequestFailed(context.Background(), nodeID, requestID, commonEng.ErrTimeout); err != nil {
+			log.Error("failed to fail dropped app request", "requestID", requestID, "err", err)
+		}
+	}()
+	return nil
+}
+
 type shutdownOnceVM struct {
 	*VM
 	shutdownOnce sync.Once
@@ -475,6 +604,18 @@ func testSyncerVM(t *testing.T, vmSetup *syncVMSetup, test syncTest) {
 	require.NoError(err, "error getting state sync summary at height")
 	require.Equal(summary, retrievedSummary)
 
+	// the latest summary bounds the range of served summaries, all of which
+	// are retrievable
+	oldest, newest, err := serverVM.StateSummaryRange(context.Background())
+	require.NoError(err, "error getting state summary range")
+	require.Equal(parsedSummary.Height(), newest)
+	require.LessOrEqual(oldest, newest)
+	require.Zero(oldest % test.syncableInterval)
+	for height := oldest; height <= newest; height += test.syncableInterval {
+		_, err := serverVM.GetStateSummary(context.Background(), height)
+		require.NoError(err, "error getting state sync summary at height %d in range", height)
+	}
+
 	syncMode, err := parsedSummary.Accept(context.Background())
 	require.NoError(err, "error accepting state summary")
 	require.Equal(test.syncMode, syncMode)
@@ -672,3 +813,45 @@ func assertSyncPerformedHeights(t *testing.T, db ethdb.Iteratee, expected map[ui
 	require.NoError(t, it.Error())
 	require.Equal(t, expected, found)
 }
+
+func TestStateSummaryRangePathScheme(t *testing.T) {
+	require := require.New(t)
+	const interval = 4
+	issuer, vm, _, _, _ := GenesisVM(t, true, "", fmt.Sprintf(`{"state-scheme":%q,"state-sync-commit-interval":%d}`, rawdb.PathScheme, interval), "")
+	t.Cleanup(func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	})
+	// The atomic trie must be committed at every summary height, while the
+	// state tries are kept as layers until the much longer commit interval.
+	vm.atomicTrie.(*atomicTrie).commitInterval = interval
+
+	// Blocks are built by the VM, since generated chains would not be written
+	// with the path-based scheme.
+	for i := 0; i < 3*interval+1; i++ {
+		vm.clock.Set(vm.clock.Time().Add(2 * time.Second))
+		tx := types.NewTransaction(uint64(i), testEthAddrs[1], common.Big1, params.TxGas, initialBaseFee, nil)
+		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainID), testKeys[0].ToECDSA())
+		require.NoError(err)
+		for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+			require.NoError(err)
+		}
+		<-issuer
+		blk, err := vm.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+		require.NoError(blk.Accept(context.Background()))
+	}
+	vm.blockChain.DrainAcceptorQueue()
+
+	// Every summary since genesis is retained by the path-based scheme.
+	oldest, newest, err := vm.StateSummaryRange(context.Background())
+	require.NoError(err)
+	require.Zero(oldest)
+	require.Equal(uint64(3*interval), newest)
+	for height := oldest; height <= newest; height += interval {
+		summary, err := vm.GetStateSummary(context.Background(), height)
+		require.NoError(err)
+		require.Equal(height, summary.Height())
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/internal/ethapi"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ava-labs/coreth/utils"
 )
@@ -356,6 +357,8 @@ func TestEthTxPushGossipOutbound(t *testing.T) {
 
 	// issue a tx
 	require.NoError(vm.txPool.Add([]*types.Transaction{signedTx}, true, true)[0])
+	_, gossiped := vm.eth.APIBackend.TxLastGossiped(signedTx.Hash())
+	require.False(gossiped)
 	vm.ethTxPushGossiper.Get().Add(&GossipEthTx{signedTx})
 
 	sent := <-sender.SentAppGossip
@@ -371,6 +374,13 @@ func TestEthTxPushGossipOutbound(t *testing.T) {
 	gossipedTx, err := marshaller.UnmarshalGossip(got.Gossip[0])
 	require.NoError(err)
 	require.Equal(ids.ID(signedTx.Hash()), gossipedTx.GossipID())
+
+	// the pool reports the tx as pending and when it was gossiped
+	status, err := ethapi.NewTxPoolAPI(vm.eth.APIBackend).TxStatus(ctx, signedTx.Hash())
+	require.NoError(err)
+	require.Equal("pending", status.Status)
+	require.NotNil(status.LastGossiped)
+	require.NotNil(status.CoversBaseFee)
 }
 
 // Tests that a gossiped tx is added to the mempool and forwarded
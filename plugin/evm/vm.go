@@ -48,6 +48,7 @@ import (
 	"github.com/ava-labs/coreth/rpc"
 	statesyncclient "github.com/ava-labs/coreth/sync/client"
 	"github.com/ava-labs/coreth/sync/client/stats"
+	syncHandlers "github.com/ava-labs/coreth/sync/handlers"
 	"github.com/ava-labs/coreth/warp"
 	"github.com/ava-labs/coreth/warp/handlers"
 
@@ -59,11 +60,13 @@ import (
 	_ "github.com/ava-labs/coreth/eth/tracers/js"
 	_ "github.com/ava-labs/coreth/eth/tracers/native"
 
+	"github.com/ava-labs/coreth/precompile/modules"
 	"github.com/ava-labs/coreth/precompile/precompileconfig"
 	// Force-load precompiles to trigger registration
 	_ "github.com/ava-labs/coreth/precompile/registry"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -154,6 +157,7 @@ const (
 	txGossipThrottlingPeriod             = 10 * time.Second
 	txGossipThrottlingLimit              = 2
 	txGossipPollSize                     = 1
+	ethTxGossipedCacheSize               = 16_384
 )
 
 // Define the API endpoints for the VM
@@ -172,6 +176,7 @@ var (
 	acceptedPrefix  = []byte("snowman_accepted")
 	metadataPrefix  = []byte("metadata")
 	warpPrefix      = []byte("warp")
+	localTxsPrefix  = []byte("local_txs")
 	ethDBPrefix     = []byte("ethdb")
 
 	// Prefixes for atomic trie
@@ -292,11 +297,15 @@ type VM struct {
 	atomicBackend AtomicBackend
 
 	builder *blockBuilder
+	// [simulation] is set while a block is built only to be inspected.
+	// Protected by the context lock.
+	simulation *blockSimulation
 
 	baseCodec codec.Registry
 	codec     codec.Manager
 	clock     mockable.Clock
 	mempool   *Mempool
+	localTxs  *localTxTracker // nil if local txs are disabled
 
 	shutdownChan chan struct{}
 	shutdownWg   sync.WaitGroup
@@ -331,11 +340,16 @@ type VM struct {
 	// Initialize only sets these if nil so they can be overridden in tests
 	p2pSender             commonEng.AppSender
 	ethTxGossipHandler    p2p.Handler
-	ethTxPushGossiper     avalancheUtils.Atomic[*gossip.PushGossiper[*GossipEthTx]]
+	ethTxPushGossiper     avalancheUtils.Atomic[*adaptivePushGossiper[*GossipEthTx]]
 	ethTxPullGossiper     gossip.Gossiper
 	atomicTxGossipHandler p2p.Handler
-	atomicTxPushGossiper  *gossip.PushGossiper[*GossipAtomicTx]
+	atomicTxPushGossiper  *adaptivePushGossiper[*GossipAtomicTx]
 	atomicTxPullGossiper  gossip.Gossiper
+
+	// ethTxGossiped records when eth txs were last sent to peers
+	ethTxGossiped *lru.Cache[common.Hash, time.Time]
+	// gossipBandwidth counts the bytes of tx gossip exchanged with peers
+	gossipBandwidth *gossipBandwidthStats
 }
 
 // CodecRegistry implements the secp256k1fx interface
@@ -369,7 +383,7 @@ func (vm *VM) Initialize(
 	toEngine chan<- commonEng.Message,
 	fxs []*commonEng.Fx,
 	appSender commonEng.AppSender,
-) error {
+) (err error) {
 	vm.config.SetDefaults()
 	if len(configBytes) > 0 {
 		if err := json.Unmarshal(configBytes, &vm.config); err != nil {
@@ -439,6 +453,31 @@ func (vm *VM) Initialize(
 		log.Info("Completed database inspection", "elapsed", time.Since(start))
 	}
 
+	// Every node of the chain must enable the precompiles recorded in the
+	// upgrade bytes, at the same addresses.
+	var chainPrecompiles []modules.ModuleSelection
+	if len(upgradeBytes) > 0 {
+		chainPrecompiles, err = params.ParseEnabledPrecompiles(upgradeBytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse upgrade bytes: %w", err)
+		}
+	}
+	if !modules.EqualSelections(vm.config.EnabledPrecompiles, chainPrecompiles) {
+		return fmt.Errorf("enabled precompiles %v differ from the ones of the chain %v", vm.config.EnabledPrecompiles, chainPrecompiles)
+	}
+	// Select the precompiles of this node before parsing any precompile config,
+	// so the configs of the precompiles that are not enabled are rejected. The
+	// selection is process-wide, so it is undone if the VM fails to start.
+	restoreModules, err := modules.EnableModules(vm.config.EnabledPrecompiles)
+	if err != nil {
+		return fmt.Errorf("failed to enable precompiles: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			restoreModules()
+		}
+	}()
+
 	g := new(core.Genesis)
 	if err := json.Unmarshal(genesisBytes, g); err != nil {
 		return err
@@ -473,6 +512,23 @@ func (vm *VM) Initialize(
 		})
 	}
 
+	// Apply the upgrades this chain opted in to, which are not scheduled by
+	// the network upgrades. The activation of these upgrades is checked
+	// against the last accepted block when the genesis is set up.
+	var upgradeConfig params.UpgradeConfig
+	if len(upgradeBytes) > 0 {
+		if err := json.Unmarshal(upgradeBytes, &upgradeConfig); err != nil {
+			return fmt.Errorf("failed to parse upgrade bytes: %w", err)
+		}
+	}
+	g.Config.BlobTxsTimestamp = upgradeConfig.BlobTxsTimestamp
+	g.Config.SignaturePrecompilesTimestamp = upgradeConfig.SignaturePrecompilesTimestamp
+	g.Config.EnabledPrecompiles = upgradeConfig.EnabledPrecompiles
+
+	// Enable and disable the stateful precompiles as scheduled by the upgrade
+	// bytes. Upgrades activated by the last accepted block cannot be changed.
+	g.Config.PrecompileUpgrades = append(g.Config.PrecompileUpgrades, upgradeConfig.PrecompileUpgrades...)
+
 	// Set the Avalanche Context on the ChainConfig
 	g.Config.AvalancheContext = params.AvalancheContext{
 		SnowCtx: chainCtx,
@@ -526,7 +582,13 @@ func (vm *VM) Initialize(
 	vm.ethConfig.AllowUnfinalizedQueries = vm.config.AllowUnfinalizedQueries
 	vm.ethConfig.AllowUnprotectedTxs = vm.config.AllowUnprotectedTxs
 	vm.ethConfig.AllowUnprotectedTxHashes = vm.config.AllowUnprotectedTxHashes
+	vm.ethConfig.Miner.FeeRecipient = vm.config.FeeRecipient
+	vm.ethConfig.Miner.TxOrdering, err = miner.NewTxOrderingPolicy(vm.config.TxOrderingPolicy, vm.config.TxOrderingPrioritySenders)
+	if err != nil {
+		return err
+	}
 	vm.ethConfig.Preimages = vm.config.Preimages
+	vm.ethConfig.StateScheme = vm.config.StateScheme
 	vm.ethConfig.Pruning = vm.config.Pruning
 	vm.ethConfig.TrieCleanCache = vm.config.TrieCleanCache
 	vm.ethConfig.TrieDirtyCache = vm.config.TrieDirtyCache
@@ -548,6 +610,13 @@ func (vm *VM) Initialize(
 	vm.ethConfig.AcceptedCacheSize = vm.config.AcceptedCacheSize
 	vm.ethConfig.TransactionHistory = vm.config.TransactionHistory
 	vm.ethConfig.SkipTxIndexing = vm.config.SkipTxIndexing
+	vm.ethConfig.BlobSidecarRetention = vm.config.BlobSidecarRetention
+	// Without a chain data directory, blob txs are only pooled in memory
+	// rather than in the working directory.
+	vm.ethConfig.BlobPool.Datadir = ""
+	if len(chainCtx.ChainDataDir) != 0 {
+		vm.ethConfig.BlobPool.Datadir = filepath.Join(chainCtx.ChainDataDir, "blobpool")
+	}
 
 	// Create directory for offline pruning
 	if len(vm.ethConfig.OfflinePruningDataDirectory) != 0 {
@@ -580,13 +649,22 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return fmt.Errorf("failed to initialize mempool: %w", err)
 	}
+	if vm.config.LocalTxsEnabled {
+		// Like warpDB, the journal is not part of versiondb as local txs are
+		// journaled independently of block acceptance.
+		vm.localTxs = newLocalTxTracker(vm, prefixdb.New(localTxsPrefix, db))
+		if err := vm.localTxs.load(); err != nil {
+			return fmt.Errorf("failed to load local txs journal: %w", err)
+		}
+	}
 
 	// initialize peer network
 	if vm.p2pSender == nil {
 		vm.p2pSender = appSender
 	}
 
-	p2pNetwork, err := p2p.NewNetwork(vm.ctx.Log, vm.p2pSender, vm.sdkMetrics, "p2p")
+	vm.gossipBandwidth = newGossipBandwidthStats()
+	p2pNetwork, err := p2p.NewNetwork(vm.ctx.Log, bandwidthSender{AppSender: vm.p2pSender, stats: vm.gossipBandwidth}, vm.sdkMetrics, "p2p")
 	if err != nil {
 		return fmt.Errorf("failed to initialize p2p network: %w", err)
 	}
@@ -648,6 +726,13 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to create atomic backend: %w", err)
 	}
 	vm.atomicTrie = vm.atomicBackend.AtomicTrie()
+	vm.shutdownWg.Add(1)
+	go func() {
+		defer vm.shutdownWg.Done()
+		if err := vm.atomicTrie.MigrateLeafsIndex(vm.shutdownChan); err != nil {
+			log.Error("failed to index atomic trie leafs", "err", err)
+		}
+	}()
 
 	go vm.ctx.Log.RecoverAndPanic(vm.startContinuousProfiler)
 
@@ -690,6 +775,7 @@ func (vm *VM) initializeChain(lastAcceptedHash common.Hash) error {
 		return err
 	}
 	callbacks := vm.createConsensusCallbacks()
+	vm.ethTxGossiped = lru.NewCache[common.Hash, time.Time](ethTxGossipedCacheSize)
 	vm.eth, err = eth.New(
 		node,
 		&vm.ethConfig,
@@ -781,6 +867,7 @@ func (vm *VM) initializeStateSyncClient(lastAcceptedHeight uint64) error {
 		skipResume:           vm.config.StateSyncSkipResume,
 		stateSyncMinBlocks:   vm.config.StateSyncMinBlocks,
 		stateSyncRequestSize: vm.config.StateSyncRequestSize,
+		backfillBlocks:       vm.config.StateSyncBackfillBlocks,
 		lastAcceptedHeight:   lastAcceptedHeight, // TODO clean up how this is passed around
 		chaindb:              vm.chaindb,
 		metadataDB:           vm.metadataDB,
@@ -869,7 +956,7 @@ func (vm *VM) preBatchOnFinalizeAndAssemble(header *types.Header, state *state.S
 		if err := vm.verifyTx(tx, header.ParentHash, header.BaseFee, state, rules); err != nil {
 			// Discard the transaction from the mempool on failed verification.
 			log.Debug("discarding tx from mempool on failed verification", "txID", tx.ID(), "err", err)
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.discardCurrentAtomicTx(tx.ID(), err)
 			state.RevertToSnapshot(snapshot)
 			continue
 		}
@@ -920,6 +1007,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 		// Ensure that adding [tx] to the block will not exceed the block size soft limit.
 		txSize := len(tx.SignedBytes())
 		if size+txSize > targetAtomicTxsSize {
+			vm.recordSkippedAtomicTx(tx.ID(), errAtomicTxsSizeExceeded)
 			vm.mempool.CancelCurrentTx(tx.ID())
 			break
 		}
@@ -939,6 +1027,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 		// ensure [gasUsed] + [batchGasUsed] doesnt exceed the [atomicGasLimit]
 		if totalGasUsed := new(big.Int).Add(batchGasUsed, txGasUsed); totalGasUsed.Cmp(params.AtomicGasLimit) > 0 {
 			// Send [tx] back to the mempool's tx heap.
+			vm.recordSkippedAtomicTx(tx.ID(), errAtomicGasLimitExceeded)
 			vm.mempool.CancelCurrentTx(tx.ID())
 			break
 		}
@@ -951,7 +1040,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 			// block will most likely be accepted.
 			// Discard the transaction from the mempool on failed verification.
 			log.Debug("discarding tx due to overlapping input utxos", "txID", tx.ID())
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.discardCurrentAtomicTx(tx.ID(), errConflictingAtomicInputs)
 			continue
 		}
 
@@ -962,7 +1051,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 			// Note: prior to this point, we have not modified [state] so there is no need to
 			// revert to a snapshot if we discard the transaction prior to this point.
 			log.Debug("discarding tx from mempool due to failed verification", "txID", tx.ID(), "err", err)
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.discardCurrentAtomicTx(tx.ID(), err)
 			state.RevertToSnapshot(snapshot)
 			continue
 		}
@@ -1096,6 +1185,9 @@ func (vm *VM) SetState(_ context.Context, state snow.State) error {
 		if err := vm.initBlockBuilding(); err != nil {
 			return fmt.Errorf("failed to initialize block building: %w", err)
 		}
+		if vm.config.StateSyncBackfillBlocks {
+			vm.startBlockBackfill()
+		}
 		vm.bootstrapped = true
 		return vm.fx.Bootstrapped()
 	default:
@@ -1103,12 +1195,50 @@ func (vm *VM) SetState(_ context.Context, state snow.State) error {
 	}
 }
 
+// startBlockBackfill backfills the blocks below the block the node state
+// synced to in the background, if a backfill was scheduled by state sync.
+func (vm *VM) startBlockBackfill() {
+	backfiller := newBlockBackfiller(
+		statesyncclient.NewClient(
+			&statesyncclient.ClientConfig{
+				NetworkClient: vm.client,
+				Codec:         vm.networkCodec,
+				Stats:         stats.NewClientSyncerStats(),
+				BlockParser:   vm,
+			},
+		),
+		vm.chaindb,
+		vm.blockChain,
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	vm.shutdownWg.Add(1)
+	go func() {
+		defer vm.shutdownWg.Done()
+		defer cancel()
+
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-vm.shutdownChan:
+				cancel()
+			case <-done:
+			}
+		}()
+		err := backfiller.run(ctx)
+		close(done)
+		if err != nil && ctx.Err() == nil {
+			log.Error("block backfill failed", "err", err)
+		}
+	}()
+}
+
 // initBlockBuilding starts goroutines to manage block building
 func (vm *VM) initBlockBuilding() error {
 	ctx, cancel := context.WithCancel(context.TODO())
 	vm.cancel = cancel
 
 	ethTxGossipMarshaller := GossipEthTxMarshaller{}
+	trackingEthTxGossipMarshaller := trackingEthTxMarshaller{gossiped: vm.ethTxGossiped}
 	ethTxGossipClient := vm.Network.NewClient(p2p.TxGossipHandlerID, p2p.WithValidatorSampling(vm.validators))
 	ethTxGossipMetrics, err := gossip.NewMetrics(vm.sdkMetrics, ethTxGossipNamespace)
 	if err != nil {
@@ -1141,19 +1271,35 @@ func (vm *VM) initBlockBuilding() error {
 		Peers:      vm.config.PushRegossipNumPeers,
 	}
 
+	pushGossipLevels := 1
+	if vm.config.PushGossipAdaptiveEnabled {
+		pushGossipLevels = pushGossipAdaptiveLevels
+	}
+
 	ethTxPushGossiper := vm.ethTxPushGossiper.Get()
 	if ethTxPushGossiper == nil {
-		ethTxPushGossiper, err = gossip.NewPushGossiper[*GossipEthTx](
-			ethTxGossipMarshaller,
-			ethTxPool,
-			vm.validators,
-			ethTxGossipClient,
-			ethTxGossipMetrics,
+		ethTxPushGossiper, err = newAdaptivePushGossiper[*GossipEthTx](
+			gossipProtocols[p2p.TxGossipHandlerID],
+			pushGossipLevels,
+			vm.config.PushGossipAdaptiveMinDuplicateRate,
+			vm.config.PushGossipAdaptiveMaxDuplicateRate,
 			pushGossipParams,
 			pushRegossipParams,
-			pushGossipDiscardedElements,
-			txGossipTargetMessageSize,
 			vm.config.RegossipFrequency.Duration,
+			func(gossipParams, regossipParams gossip.BranchingFactor, regossipFrequency time.Duration) (pushGossiper[*GossipEthTx], error) {
+				return gossip.NewPushGossiper[*GossipEthTx](
+					trackingEthTxGossipMarshaller,
+					ethTxPool,
+					vm.validators,
+					ethTxGossipClient,
+					ethTxGossipMetrics,
+					gossipParams,
+					regossipParams,
+					pushGossipDiscardedElements,
+					txGossipTargetMessageSize,
+					regossipFrequency,
+				)
+			},
 		)
 		if err != nil {
 			return fmt.Errorf("failed to initialize eth tx push gossiper: %w", err)
@@ -1162,17 +1308,28 @@ func (vm *VM) initBlockBuilding() error {
 	}
 
 	if vm.atomicTxPushGossiper == nil {
-		vm.atomicTxPushGossiper, err = gossip.NewPushGossiper[*GossipAtomicTx](
-			atomicTxGossipMarshaller,
-			vm.mempool,
-			vm.validators,
-			atomicTxGossipClient,
-			atomicTxGossipMetrics,
+		vm.atomicTxPushGossiper, err = newAdaptivePushGossiper[*GossipAtomicTx](
+			gossipProtocols[p2p.AtomicTxGossipHandlerID],
+			pushGossipLevels,
+			vm.config.PushGossipAdaptiveMinDuplicateRate,
+			vm.config.PushGossipAdaptiveMaxDuplicateRate,
 			pushGossipParams,
 			pushRegossipParams,
-			pushGossipDiscardedElements,
-			txGossipTargetMessageSize,
 			vm.config.RegossipFrequency.Duration,
+			func(gossipParams, regossipParams gossip.BranchingFactor, regossipFrequency time.Duration) (pushGossiper[*GossipAtomicTx], error) {
+				return gossip.NewPushGossiper[*GossipAtomicTx](
+					atomicTxGossipMarshaller,
+					vm.mempool,
+					vm.validators,
+					atomicTxGossipClient,
+					atomicTxGossipMetrics,
+					gossipParams,
+					regossipParams,
+					pushGossipDiscardedElements,
+					txGossipTargetMessageSize,
+					regossipFrequency,
+				)
+			},
 		)
 		if err != nil {
 			return fmt.Errorf("failed to initialize atomic tx push gossiper: %w", err)
@@ -1188,7 +1345,7 @@ func (vm *VM) initBlockBuilding() error {
 	if vm.ethTxGossipHandler == nil {
 		vm.ethTxGossipHandler = newTxGossipHandler[*GossipEthTx](
 			vm.ctx.Log,
-			ethTxGossipMarshaller,
+			trackingEthTxGossipMarshaller,
 			ethTxPool,
 			ethTxGossipMetrics,
 			txGossipTargetMessageSize,
@@ -1198,7 +1355,12 @@ func (vm *VM) initBlockBuilding() error {
 		)
 	}
 
-	if err := vm.Network.AddHandler(p2p.TxGossipHandlerID, vm.ethTxGossipHandler); err != nil {
+	if err := vm.Network.AddHandler(p2p.TxGossipHandlerID, bandwidthHandler{
+		Handler:   vm.ethTxGossipHandler,
+		handlerID: p2p.TxGossipHandlerID,
+		stats:     vm.gossipBandwidth,
+		onRequest: ethTxPushGossiper.observePullRequest,
+	}); err != nil {
 		return err
 	}
 
@@ -1215,7 +1377,12 @@ func (vm *VM) initBlockBuilding() error {
 		)
 	}
 
-	if err := vm.Network.AddHandler(p2p.AtomicTxGossipHandlerID, vm.atomicTxGossipHandler); err != nil {
+	if err := vm.Network.AddHandler(p2p.AtomicTxGossipHandlerID, bandwidthHandler{
+		Handler:   vm.atomicTxGossipHandler,
+		handlerID: p2p.AtomicTxGossipHandlerID,
+		stats:     vm.gossipBandwidth,
+		onRequest: vm.atomicTxPushGossiper.observePullRequest,
+	}); err != nil {
 		return err
 	}
 
@@ -1273,6 +1440,30 @@ func (vm *VM) initBlockBuilding() error {
 		vm.shutdownWg.Done()
 	}()
 
+	if vm.localTxs != nil {
+		// Resubmit the txs loaded from the journal right away rather than
+		// after the first resubmission interval.
+		vm.localTxs.resubmit()
+		vm.shutdownWg.Add(1)
+		go func() {
+			vm.localTxs.run(vm.config.LocalTxsResubmitFrequency.Duration, vm.shutdownChan)
+			vm.shutdownWg.Done()
+		}()
+	}
+
+	return nil
+}
+
+// issueLocalAtomicTx adds [tx] submitted via this node to the mempool, tracks
+// it as a local submission if enabled and gossips it.
+func (vm *VM) issueLocalAtomicTx(tx *Tx) error {
+	if err := vm.mempool.AddLocalTx(tx); err != nil {
+		return err
+	}
+	if vm.localTxs != nil {
+		vm.localTxs.addAtomicTx(tx)
+	}
+	vm.atomicTxPushGossiper.Add(&GossipAtomicTx{tx})
 	return nil
 }
 
@@ -1282,21 +1473,34 @@ func (vm *VM) setAppRequestHandlers() {
 	// Create separate EVM TrieDB (read only) for serving leafs requests.
 	// We create a separate TrieDB here, so that it has a separate cache from the one
 	// used by the node when processing blocks.
-	evmTrieDB := triedb.NewDatabase(
-		vm.chaindb,
-		&triedb.Config{
-			HashDB: &hashdb.Config{
-				CleanCacheSize: vm.config.StateSyncServerTrieCache * units.MiB,
+	// The path-based scheme keeps recent states as in-memory layers, so the chain's
+	// TrieDB is shared instead, allowing leafs to be served for any retained root.
+	evmTrieDB := vm.blockChain.TrieDB()
+	if evmTrieDB.Scheme() != rawdb.PathScheme {
+		evmTrieDB = triedb.NewDatabase(
+			vm.chaindb,
+			&triedb.Config{
+				HashDB: &hashdb.Config{
+					CleanCacheSize: vm.config.StateSyncServerTrieCache * units.MiB,
+				},
 			},
-		},
-	)
+		)
+	}
 	networkHandler := newNetworkHandler(
 		vm.blockChain,
 		vm.chaindb,
 		evmTrieDB,
-		vm.atomicTrie.TrieDB(),
+		vm.atomicTrie,
 		vm.warpBackend,
 		vm.networkCodec,
+		syncHandlers.RequestLimiterConfig{
+			PeerRequestsPerSecond: vm.config.StateSyncServerPeerRateLimit,
+			PeerBurst:             vm.config.StateSyncServerPeerBurst,
+			MaxConcurrentRequests: vm.config.StateSyncServerMaxConcurrentRequests,
+			LeafsWeight:           vm.config.StateSyncServerLeafsRequestWeight,
+			BlockWeight:           vm.config.StateSyncServerBlockRequestWeight,
+			CodeWeight:            vm.config.StateSyncServerCodeRequestWeight,
+		},
 	)
 	vm.Network.SetRequestHandler(networkHandler)
 }
@@ -1316,9 +1520,18 @@ func (vm *VM) Shutdown(context.Context) error {
 	close(vm.shutdownChan)
 	vm.eth.Stop()
 	vm.shutdownWg.Wait()
+	// Restore the precompiles enabled before the VM started.
+	modules.EnableAllModules()
 	return nil
 }
 
+// Disconnected drops the gossip bandwidth accounted for [nodeID] and removes
+// it from the peer list.
+func (vm *VM) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	vm.gossipBandwidth.disconnected(nodeID)
+	return vm.Network.Disconnected(ctx, nodeID)
+}
+
 // buildBlock builds a block to be wrapped by ChainState
 func (vm *VM) buildBlock(ctx context.Context) (snowman.Block, error) {
 	return vm.buildBlockWithContext(ctx, nil)
@@ -1677,7 +1890,7 @@ func (vm *VM) verifyTxAtTip(tx *Tx) error {
 	var nextBaseFee *big.Int
 	timestamp := uint64(vm.clock.Time().Unix())
 	if vm.chainConfig.IsApricotPhase3(timestamp) {
-		_, nextBaseFee, err = dummy.EstimateNextBaseFee(vm.chainConfig, parentHeader, timestamp)
+		_, nextBaseFee, err = dummy.EstimateNextBaseFee(vm.chainConfig, vm.blockChain, parentHeader, timestamp)
 		if err != nil {
 			// Return extremely detailed error since CalcBaseFee should never encounter an issue here
 			return fmt.Errorf("failed to calculate base fee with parent timestamp (%d), parent ExtraData: (0x%x), and current timestamp (%d): %w", parentHeader.Time, parentHeader.Extra, timestamp, err)
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/holiman/uint256"
@@ -58,9 +59,15 @@ import (
 
 	"github.com/ava-labs/coreth/consensus/dummy"
 	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/eth"
+	"github.com/ava-labs/coreth/internal/ethapi"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contracts/nativeminter"
+	"github.com/ava-labs/coreth/precompile/contracts/txallowlist"
+	warpcontract "github.com/ava-labs/coreth/precompile/contracts/warp"
+	"github.com/ava-labs/coreth/precompile/modules"
 	"github.com/ava-labs/coreth/rpc"
 
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
@@ -785,6 +792,16 @@ func TestBuildEthTxBlock(t *testing.T) {
 		t.Fatalf("Expected last accepted blockID to be the accepted block: %s, but found %s", blk2.ID(), lastAcceptedID)
 	}
 
+	// The transactions that left the pool for the block are reported as included
+	vm.blockChain.DrainAcceptorQueue()
+	status, err := ethapi.NewTxPoolAPI(vm.eth.APIBackend).TxStatus(context.Background(), txs[0].Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != "included" || status.BlockHash == nil || *status.BlockHash != common.Hash(blk2.ID()) {
+		t.Fatalf("Expected tx to be included in the accepted block %s, but found %+v", blk2.ID(), status)
+	}
+
 	ethBlk1 := blk1.(*chain.BlockWrapper).Block.(*Block).ethBlock
 	if ethBlk1Root := ethBlk1.Root(); !vm.blockChain.HasState(ethBlk1Root) {
 		t.Fatalf("Expected blk1 state root to not yet be pruned after blk2 was accepted because of tip buffer")
@@ -3123,6 +3140,112 @@ func TestBuildInvalidBlockHead(t *testing.T) {
 	}
 }
 
+func TestEnabledPrecompiles(t *testing.T) {
+	t.Cleanup(modules.EnableAllModules)
+
+	const (
+		txAllowList        = `{"configKey":"txAllowListConfig","address":"0x0200000000000000000000000000000000000002"}`
+		nativeMinter       = `{"configKey":"contractNativeMinterConfig","address":"0x0200000000000000000000000000000000000001"}`
+		txAllowListUpgrade = `{"txAllowListConfig":{"blockTimestamp":100000000000,"adminAddresses":["0x0100000000000000000000000000000000000000"]}}`
+	)
+	tests := []struct {
+		name        string
+		upgradeJSON string
+		configJSON  string
+		expectedErr error
+		errContains string
+	}{
+		{
+			name:        "not enabled",
+			upgradeJSON: `{"enabledPrecompiles":[` + txAllowList + `],"precompileUpgrades":[` + txAllowListUpgrade + `]}`,
+			configJSON:  `{}`,
+			errContains: "differ from the ones of the chain",
+		},
+		{
+			name:        "more enabled than the chain",
+			upgradeJSON: `{"enabledPrecompiles":[` + txAllowList + `],"precompileUpgrades":[` + txAllowListUpgrade + `]}`,
+			configJSON:  `{"enabled-precompiles":[` + txAllowList + `,` + nativeMinter + `]}`,
+			errContains: "differ from the ones of the chain",
+		},
+		{
+			name:        "enabled",
+			upgradeJSON: `{"enabledPrecompiles":[` + txAllowList + `],"precompileUpgrades":[` + txAllowListUpgrade + `]}`,
+			configJSON:  `{"enabled-precompiles":[` + txAllowList + `]}`,
+		},
+		{
+			name:        "address mismatch",
+			upgradeJSON: `{"enabledPrecompiles":[{"configKey":"txAllowListConfig","address":"0x0200000000000000000000000000000000000003"}]}`,
+			configJSON:  `{"enabled-precompiles":[{"configKey":"txAllowListConfig","address":"0x0200000000000000000000000000000000000003"}]}`,
+			expectedErr: modules.ErrModuleAddressMismatch,
+		},
+		{
+			name:        "unknown module",
+			upgradeJSON: `{"enabledPrecompiles":[{"configKey":"unknownConfig","address":"0x0200000000000000000000000000000000000002"}]}`,
+			configJSON:  `{"enabled-precompiles":[{"configKey":"unknownConfig","address":"0x0200000000000000000000000000000000000002"}]}`,
+			expectedErr: modules.ErrUnknownModule,
+		},
+		{
+			name:        "malformed enabled precompiles",
+			upgradeJSON: `{"enabledPrecompiles":{}}`,
+			configJSON:  `{}`,
+			errContains: "failed to parse upgrade bytes",
+		},
+		{
+			// The precompiles are enabled before the upgrade is rejected.
+			name:        "malformed precompile upgrade",
+			upgradeJSON: `{"enabledPrecompiles":[` + txAllowList + `],"precompileUpgrades":[{"txAllowListConfig":{"blockTimestamp":"soon"}}]}`,
+			configJSON:  `{"enabled-precompiles":[` + txAllowList + `]}`,
+			errContains: "failed to parse upgrade bytes",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vm := &VM{}
+			ctx, dbManager, genesisBytes, issuer, _ := setupGenesis(t, genesisJSONLatest)
+			appSender := &enginetest.Sender{T: t}
+			appSender.CantSendAppGossip = true
+			appSender.SendAppGossipF = func(context.Context, commonEng.SendConfig, []byte) error { return nil }
+			err := vm.Initialize(
+				context.Background(),
+				ctx,
+				dbManager,
+				genesisBytes,
+				[]byte(test.upgradeJSON),
+				[]byte(test.configJSON),
+				issuer,
+				[]*commonEng.Fx{},
+				appSender,
+			)
+			if test.expectedErr != nil || test.errContains != "" {
+				if test.expectedErr != nil {
+					require.ErrorIs(err, test.expectedErr)
+				} else {
+					require.ErrorContains(err, test.errContains)
+				}
+				// A VM failing to start leaves every precompile enabled.
+				_, ok := modules.GetPrecompileModule(nativeminter.ConfigKey)
+				require.True(ok)
+				return
+			}
+			require.NoError(err)
+
+			_, ok := modules.GetPrecompileModule(txallowlist.ConfigKey)
+			require.True(ok)
+			_, ok = modules.GetPrecompileModule(nativeminter.ConfigKey)
+			require.False(ok)
+			_, ok = modules.GetPrecompileModule(warpcontract.ConfigKey)
+			require.True(ok)
+
+			// Shutting down the VM enables every precompile again.
+			require.NoError(vm.Shutdown(context.Background()))
+			_, ok = modules.GetPrecompileModule(nativeminter.ConfigKey)
+			require.True(ok)
+		})
+	}
+}
+
 func TestConfigureLogLevel(t *testing.T) {
 	configTests := []struct {
 		name                     string
@@ -3982,9 +4105,96 @@ func TestNoBlobsAllowed(t *testing.T) {
 	vmBlock, err := vm.newBlock(blocks[0])
 	require.NoError(err)
 	_, err = vm.ParseBlock(ctx, vmBlock.Bytes())
-	require.ErrorContains(err, "blobs not enabled on avalanche networks")
+	require.ErrorContains(err, "blobs not enabled on this network")
 	err = vmBlock.Verify(ctx)
-	require.ErrorContains(err, "blobs not enabled on avalanche networks")
+	require.ErrorContains(err, "blobs not enabled on this network")
+}
+
+func TestMalformedUpgradeBytes(t *testing.T) {
+	vm := &VM{}
+	ctx, dbManager, genesisBytes, issuer, _ := setupGenesis(t, genesisJSONCancun)
+	err := vm.Initialize(
+		context.Background(),
+		ctx,
+		dbManager,
+		genesisBytes,
+		[]byte(`{"blobTxsTimestamp":`),
+		[]byte(""),
+		issuer,
+		[]*commonEng.Fx{},
+		&enginetest.Sender{T: t},
+	)
+	require.ErrorContains(t, err, "failed to parse upgrade bytes")
+}
+
+func TestBlobTxsOptIn(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	importAmount := uint64(1000000000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONCancun, "", `{"blobTxsTimestamp":0}`, map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() { require.NoError(vm.Shutdown(ctx)) }()
+
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	require.NoError(vm.mempool.AddLocalTx(importTx))
+	<-issuer
+
+	blk1, err := vm.BuildBlock(ctx)
+	require.NoError(err)
+	require.NoError(blk1.Verify(ctx))
+	require.NoError(vm.SetPreference(ctx, blk1.ID()))
+	require.NoError(blk1.Accept(ctx))
+
+	// Blob txs are pooled and included in blocks once the network opted in
+	var (
+		blob          = kzg4844.Blob{}
+		commitment, _ = kzg4844.BlobToCommitment(blob)
+		proof, _      = kzg4844.ComputeBlobProof(blob, commitment)
+		sidecar       = &types.BlobTxSidecar{
+			Blobs:       []kzg4844.Blob{blob},
+			Commitments: []kzg4844.Commitment{commitment},
+			Proofs:      []kzg4844.Proof{proof},
+		}
+		// A single tx block must pay for the block gas cost
+		gasPrice = uint256.NewInt(10_000 * params.GWei)
+	)
+	tx, err := types.SignTx(types.NewTx(&types.BlobTx{
+		ChainID:    uint256.MustFromBig(vm.chainID),
+		Nonce:      0,
+		GasTipCap:  gasPrice,
+		GasFeeCap:  gasPrice,
+		Gas:        params.TxGas,
+		To:         testEthAddrs[1],
+		Value:      new(uint256.Int),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	}), types.NewCancunSigner(vm.chainID), testKeys[0].ToECDSA())
+	require.NoError(err)
+	errs := vm.txPool.AddRemotesSync([]*types.Transaction{tx})
+	require.NoError(errs[0])
+	<-issuer
+
+	blk2, err := vm.BuildBlock(ctx)
+	require.NoError(err)
+	require.NoError(blk2.Verify(ctx))
+	require.NoError(vm.SetPreference(ctx, blk2.ID()))
+	require.NoError(blk2.Accept(ctx))
+	vm.blockChain.DrainAcceptorQueue()
+
+	ethBlock := blk2.(*chain.BlockWrapper).Block.(*Block).ethBlock
+	require.Len(ethBlock.Transactions(), 1)
+	require.Equal(tx.Hash(), ethBlock.Transactions()[0].Hash())
+	require.Equal(uint64(params.BlobTxBlobGasPerBlob), *ethBlock.BlobGasUsed())
+
+	// The sidecar is stored once the block is accepted
+	require.Eventually(func() bool {
+		return rawdb.ReadBlobSidecar(vm.chaindb, ethBlock.NumberU64(), tx.Hash()) != nil
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(sidecar, rawdb.ReadBlobSidecar(vm.chaindb, ethBlock.NumberU64(), tx.Hash()))
 }
 
 func TestMinFeeSetAtEtna(t *testing.T) {
@@ -0,0 +1,177 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package allowlist implements a role based allow list that stateful
+// precompiles can embed to gate access to a resource.
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/accounts/abi"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/vmerrs"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	ModifyAllowListGasCost = contract.WriteGasCostPerSlot
+	ReadAllowListGasCost   = contract.ReadGasCostPerSlot
+)
+
+var (
+	// AllowListRawABI contains the raw ABI of the functions shared by every
+	// allow list precompile.
+	//go:embed allowlist.abi
+	AllowListRawABI string
+
+	AllowListABI = contract.ParseABI(AllowListRawABI)
+
+	// ErrCannotModifyAllowList is returned when the caller is not allowed to
+	// make the requested change to the allow list.
+	ErrCannotModifyAllowList = errors.New("cannot modify allow list")
+)
+
+// GetAllowListStatus returns the role of [address] in the allow list of the
+// precompile at [precompileAddr].
+func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, address common.Address) Role {
+	// Roles are keyed by the address, so precompiles embedding an allow list
+	// must store their own state under keys that cannot collide with it.
+	addressKey := common.BytesToHash(address.Bytes())
+	return Role(state.GetState(precompileAddr, addressKey))
+}
+
+// SetAllowListRole sets the role of [address] in the allow list of the
+// precompile at [precompileAddr] to [role].
+// Assumes [role] has already been verified as valid.
+func SetAllowListRole(state contract.StateDB, precompileAddr common.Address, address common.Address, role Role) {
+	addressKey := common.BytesToHash(address.Bytes())
+	state.SetState(precompileAddr, addressKey, common.Hash(role))
+}
+
+// PackModifyAllowList packs [address] into the input of the function setting
+// its role to [role].
+// This function is mostly used for tests.
+func PackModifyAllowList(address common.Address, role Role) ([]byte, error) {
+	switch role {
+	case AdminRole:
+		return AllowListABI.Pack("setAdmin", address)
+	case ManagerRole:
+		return AllowListABI.Pack("setManager", address)
+	case EnabledRole:
+		return AllowListABI.Pack("setEnabled", address)
+	case NoRole:
+		return AllowListABI.Pack("setNone", address)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRole, role)
+	}
+}
+
+// PackReadAllowList packs [address] into the input of readAllowList.
+// This function is mostly used for tests.
+func PackReadAllowList(address common.Address) ([]byte, error) {
+	return AllowListABI.Pack("readAllowList", address)
+}
+
+// unpackAddressInput unpacks the single address argument of the allow list
+// function [method].
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func unpackAddressInput(method string, input []byte) (common.Address, error) {
+	// Strict mode is not used since it was disabled with Durango.
+	res, err := AllowListABI.UnpackInput(method, input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(res[0], new(common.Address)).(*common.Address), nil
+}
+
+// createAllowListRoleSetter returns the execution function of [method], which
+// sets the role of its address argument to [role] in the allow list of the
+// precompile at [precompileAddr].
+func createAllowListRoleSetter(precompileAddr common.Address, method string, role Role) contract.RunStatefulPrecompileFunc {
+	return func(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ModifyAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+		modifyAddress, err := unpackAddressInput(method, input)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("invalid %s input: %w", method, err)
+		}
+
+		stateDB := accessibleState.GetStateDB()
+		// Verify that the caller is allowed to move the address from its
+		// current role to [role].
+		callerRole := GetAllowListStatus(stateDB, precompileAddr, caller)
+		modifyRole := GetAllowListStatus(stateDB, precompileAddr, modifyAddress)
+		if !callerRole.CanModify(modifyRole, role) {
+			return nil, remainingGas, fmt.Errorf("%w: caller %s (%s) cannot change %s from %s to %s", ErrCannotModifyAllowList, caller, callerRole, modifyAddress, modifyRole, role)
+		}
+		SetAllowListRole(stateDB, precompileAddr, modifyAddress, role)
+		return []byte{}, remainingGas, nil
+	}
+}
+
+// createReadAllowList returns the execution function of readAllowList, which
+// returns the role of its address argument in the allow list of the
+// precompile at [precompileAddr].
+func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAllowListGasCost); err != nil {
+			return nil, 0, err
+		}
+		readAddress, err := unpackAddressInput("readAllowList", input)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("invalid readAllowList input: %w", err)
+		}
+		role := GetAllowListStatus(accessibleState.GetStateDB(), precompileAddr, readAddress)
+		return role.Bytes(), remainingGas, nil
+	}
+}
+
+// isManagerActivated returns true if the manager role can be assigned, which
+// is only the case after Durango.
+func isManagerActivated(accessibleState contract.AccessibleState) bool {
+	return accessibleState.GetChainConfig().IsDurango(accessibleState.GetBlockContext().Timestamp())
+}
+
+// CreateAllowListFunctions returns the functions reading and modifying the
+// allow list of the precompile at [precompileAddr], so they can be combined
+// with the functions of the precompile itself.
+func CreateAllowListFunctions(precompileAddr common.Address) []*contract.StatefulPrecompileFunction {
+	var functions []*contract.StatefulPrecompileFunction
+	for name, method := range AllowListABI.Methods {
+		switch name {
+		case "readAllowList":
+			functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, createReadAllowList(precompileAddr)))
+		case "setAdmin":
+			functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, name, AdminRole)))
+		case "setEnabled":
+			functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, name, EnabledRole)))
+		case "setManager":
+			functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, createAllowListRoleSetter(precompileAddr, name, ManagerRole), isManagerActivated))
+		case "setNone":
+			functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, createAllowListRoleSetter(precompileAddr, name, NoRole)))
+		default:
+			panic(fmt.Errorf("allow list method (%s) has no implementation", name))
+		}
+	}
+	return functions
+}
+
+// CreateAllowListPrecompile returns a StatefulPrecompiledContract with only
+// the functions of the allow list of the precompile at [precompileAddr].
+func CreateAllowListPrecompile(precompileAddr common.Address) contract.StatefulPrecompiledContract {
+	// Construct the contract with no fallback function.
+	allowListContract, err := contract.NewStatefulPrecompileContract(nil, CreateAllowListFunctions(precompileAddr))
+	if err != nil {
+		panic(err)
+	}
+	return allowListContract
+}
@@ -0,0 +1,294 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package allowlisttest provides the tests shared by every precompile
+// embedding an allow list.
+package allowlisttest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/modules"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	TestAdminAddr   = common.HexToAddress("0x0000000000000000000000000000000000000011")
+	TestManagerAddr = common.HexToAddress("0x0000000000000000000000000000000000000022")
+	TestEnabledAddr = common.HexToAddress("0x0000000000000000000000000000000000000033")
+	TestNoRoleAddr  = common.HexToAddress("0x0000000000000000000000000000000000000044")
+)
+
+// MkConfigWithAllowList returns a config of [module] activated at genesis with
+// the roles of [allowListConfig]. The config of [module] must embed
+// allowlist.AllowListConfig.
+func MkConfigWithAllowList(module modules.Module, allowListConfig *allowlist.AllowListConfig) precompileconfig.Config {
+	cfg := module.MakeConfig()
+	jsonBytes, err := json.Marshal(allowListConfig)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(jsonBytes, cfg); err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal([]byte(`{"blockTimestamp":0}`), cfg); err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// AllowListTests returns the tests of the allow list functions of [module],
+// run with the test addresses assigned their respective roles.
+func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.PrecompileTest {
+	contractAddress := module.Address
+	config := MkConfigWithAllowList(module, &allowlist.AllowListConfig{
+		AdminAddresses:   []common.Address{TestAdminAddr},
+		ManagerAddresses: []common.Address{TestManagerAddr},
+		EnabledAddresses: []common.Address{TestEnabledAddr},
+	})
+	modify := func(address common.Address, role allowlist.Role) []byte {
+		input, err := allowlist.PackModifyAllowList(address, role)
+		require.NoError(t, err)
+		return input
+	}
+	read := func(address common.Address) []byte {
+		input, err := allowlist.PackReadAllowList(address)
+		require.NoError(t, err)
+		return input
+	}
+	expectRole := func(address common.Address, role allowlist.Role) func(t testing.TB, state contract.StateDB) {
+		return func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, role, allowlist.GetAllowListStatus(state, contractAddress, address))
+		}
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"admin set admin": {
+			Caller:      TestAdminAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.AdminRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectRole(TestNoRoleAddr, allowlist.AdminRole),
+		},
+		"admin set manager": {
+			Caller:      TestAdminAddr,
+			Input:       modify(TestEnabledAddr, allowlist.ManagerRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectRole(TestEnabledAddr, allowlist.ManagerRole),
+		},
+		"admin set enabled": {
+			Caller:      TestAdminAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.EnabledRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectRole(TestNoRoleAddr, allowlist.EnabledRole),
+		},
+		"admin set none on admin": {
+			Caller:      TestAdminAddr,
+			Input:       modify(TestAdminAddr, allowlist.NoRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectRole(TestAdminAddr, allowlist.NoRole),
+		},
+		"manager set enabled": {
+			Caller:      TestManagerAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.EnabledRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectRole(TestNoRoleAddr, allowlist.EnabledRole),
+		},
+		"manager set none on enabled": {
+			Caller:      TestManagerAddr,
+			Input:       modify(TestEnabledAddr, allowlist.NoRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectRole(TestEnabledAddr, allowlist.NoRole),
+		},
+		"manager set admin": {
+			Caller:      TestManagerAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.AdminRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			Config:      config,
+			ExpectedErr: allowlist.ErrCannotModifyAllowList.Error(),
+			AfterHook:   expectRole(TestNoRoleAddr, allowlist.NoRole),
+		},
+		"manager set none on admin": {
+			Caller:      TestManagerAddr,
+			Input:       modify(TestAdminAddr, allowlist.NoRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			Config:      config,
+			ExpectedErr: allowlist.ErrCannotModifyAllowList.Error(),
+			AfterHook:   expectRole(TestAdminAddr, allowlist.AdminRole),
+		},
+		"enabled set enabled": {
+			Caller:      TestEnabledAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.EnabledRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			Config:      config,
+			ExpectedErr: allowlist.ErrCannotModifyAllowList.Error(),
+		},
+		"no role set enabled": {
+			Caller:      TestNoRoleAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.EnabledRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			Config:      config,
+			ExpectedErr: allowlist.ErrCannotModifyAllowList.Error(),
+		},
+		"admin set enabled readOnly": {
+			Caller:      TestAdminAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.EnabledRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost,
+			ReadOnly:    true,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"admin set enabled insufficient gas": {
+			Caller:      TestAdminAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.EnabledRole),
+			SuppliedGas: allowlist.ModifyAllowListGasCost - 1,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"admin set manager before Durango": {
+			Caller:      TestAdminAddr,
+			Input:       modify(TestNoRoleAddr, allowlist.ManagerRole),
+			SuppliedGas: 0,
+			ChainConfig: func() precompileconfig.ChainConfig {
+				chainConfig := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				chainConfig.EXPECT().IsDurango(gomock.Any()).AnyTimes().Return(false)
+				return chainConfig
+			}(),
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"read allow list admin": {
+			Caller:      TestNoRoleAddr,
+			Input:       read(TestAdminAddr),
+			SuppliedGas: allowlist.ReadAllowListGasCost,
+			ExpectedRes: allowlist.AdminRole.Bytes(),
+			Config:      config,
+		},
+		"read allow list no role readOnly": {
+			Caller:      TestNoRoleAddr,
+			Input:       read(TestNoRoleAddr),
+			SuppliedGas: allowlist.ReadAllowListGasCost,
+			ReadOnly:    true,
+			ExpectedRes: allowlist.NoRole.Bytes(),
+			Config:      config,
+		},
+		"read allow list insufficient gas": {
+			Caller:      TestNoRoleAddr,
+			Input:       read(TestAdminAddr),
+			SuppliedGas: allowlist.ReadAllowListGasCost - 1,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"initial config sets roles": {
+			Config: MkConfigWithAllowList(module, &allowlist.AllowListConfig{
+				AdminAddresses:   []common.Address{TestNoRoleAddr},
+				EnabledAddresses: []common.Address{TestAdminAddr},
+			}),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, allowlist.AdminRole, allowlist.GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+				require.Equal(t, allowlist.EnabledRole, allowlist.GetAllowListStatus(state, contractAddress, TestAdminAddr))
+			},
+		},
+	}
+	return tests
+}
+
+// RunPrecompileWithAllowListTests runs the allow list tests of [module]
+// together with its own [contractTests].
+func RunPrecompileWithAllowListTests(t *testing.T, module modules.Module, newStateDB func(t testing.TB) contract.StateDB, contractTests map[string]testutils.PrecompileTest) {
+	t.Helper()
+
+	tests := AllowListTests(t, module)
+	for name, test := range contractTests {
+		if _, exists := tests[name]; exists {
+			t.Fatalf("duplicate test name: %s", name)
+		}
+		tests[name] = test
+	}
+	testutils.RunPrecompileTests(t, module, newStateDB, tests)
+}
+
+// VerifyTests returns the config verification tests of the allow list
+// embedded by the config of [module].
+func VerifyTests(t testing.TB, module modules.Module) map[string]testutils.ConfigVerifyTest {
+	return map[string]testutils.ConfigVerifyTest{
+		"valid allow list": {
+			Config: MkConfigWithAllowList(module, &allowlist.AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr},
+				ManagerAddresses: []common.Address{TestManagerAddr},
+				EnabledAddresses: []common.Address{TestEnabledAddr},
+			}),
+		},
+		"duplicate admin": {
+			Config: MkConfigWithAllowList(module, &allowlist.AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr, TestAdminAddr},
+			}),
+			ExpectedError: "duplicate address",
+		},
+		"admin and enabled overlap": {
+			Config: MkConfigWithAllowList(module, &allowlist.AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr},
+				EnabledAddresses: []common.Address{TestAdminAddr},
+			}),
+			ExpectedError: "cannot set address",
+		},
+		"admin and manager overlap": {
+			Config: MkConfigWithAllowList(module, &allowlist.AllowListConfig{
+				AdminAddresses:   []common.Address{TestAdminAddr},
+				ManagerAddresses: []common.Address{TestAdminAddr},
+			}),
+			ExpectedError: "cannot set address",
+		},
+		"manager before Durango": {
+			Config: MkConfigWithAllowList(module, &allowlist.AllowListConfig{
+				ManagerAddresses: []common.Address{TestManagerAddr},
+			}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				chainConfig := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				chainConfig.EXPECT().IsDurango(gomock.Any()).AnyTimes().Return(false)
+				return chainConfig
+			}(),
+			ExpectedError: "cannot set manager addresses before Durango",
+		},
+	}
+}
+
+// EqualTests returns the config equality tests of the allow list embedded by
+// the config of [module].
+func EqualTests(module modules.Module) map[string]testutils.ConfigEqualTest {
+	return map[string]testutils.ConfigEqualTest{
+		"different admins": {
+			Config:   MkConfigWithAllowList(module, &allowlist.AllowListConfig{AdminAddresses: []common.Address{TestAdminAddr}}),
+			Other:    MkConfigWithAllowList(module, &allowlist.AllowListConfig{AdminAddresses: []common.Address{TestManagerAddr}}),
+			Expected: false,
+		},
+		"different enabled order": {
+			Config:   MkConfigWithAllowList(module, &allowlist.AllowListConfig{EnabledAddresses: []common.Address{TestAdminAddr, TestEnabledAddr}}),
+			Other:    MkConfigWithAllowList(module, &allowlist.AllowListConfig{EnabledAddresses: []common.Address{TestEnabledAddr, TestAdminAddr}}),
+			Expected: false,
+		},
+		"same allow list": {
+			Config:   MkConfigWithAllowList(module, &allowlist.AllowListConfig{AdminAddresses: []common.Address{TestAdminAddr}, ManagerAddresses: []common.Address{TestManagerAddr}}),
+			Other:    MkConfigWithAllowList(module, &allowlist.AllowListConfig{AdminAddresses: []common.Address{TestAdminAddr}, ManagerAddresses: []common.Address{TestManagerAddr}}),
+			Expected: true,
+		},
+	}
+}
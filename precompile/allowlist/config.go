@@ -0,0 +1,94 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errManagerBeforeDurango = errors.New("cannot set manager addresses before Durango")
+
+// AllowListConfig specifies the initial roles of an allow list, set when the
+// precompile embedding it is activated.
+type AllowListConfig struct {
+	AdminAddresses   []common.Address `json:"adminAddresses,omitempty"`
+	ManagerAddresses []common.Address `json:"managerAddresses,omitempty"`
+	EnabledAddresses []common.Address `json:"enabledAddresses,omitempty"`
+}
+
+// Configure sets the initial roles of the allow list of the precompile at
+// [precompileAddr] in [state].
+func (c *AllowListConfig) Configure(chainConfig precompileconfig.ChainConfig, precompileAddr common.Address, state contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	for _, enabledAddr := range c.EnabledAddresses {
+		SetAllowListRole(state, precompileAddr, enabledAddr, EnabledRole)
+	}
+	for _, managerAddr := range c.ManagerAddresses {
+		SetAllowListRole(state, precompileAddr, managerAddr, ManagerRole)
+	}
+	for _, adminAddr := range c.AdminAddresses {
+		SetAllowListRole(state, precompileAddr, adminAddr, AdminRole)
+	}
+	return nil
+}
+
+// Equal returns true iff [other] has the same addresses in the same order for
+// each role.
+func (c *AllowListConfig) Equal(other *AllowListConfig) bool {
+	if other == nil {
+		return false
+	}
+	return areEqualAddressLists(c.AdminAddresses, other.AdminAddresses) &&
+		areEqualAddressLists(c.ManagerAddresses, other.ManagerAddresses) &&
+		areEqualAddressLists(c.EnabledAddresses, other.EnabledAddresses)
+}
+
+// areEqualAddressLists returns true iff [a] and [b] have the same addresses in
+// the same order.
+func areEqualAddressLists(a []common.Address, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify returns an error if an address is given more than one role, or if
+// managers are configured by an [upgrade] activating before Durango.
+func (c *AllowListConfig) Verify(chainConfig precompileconfig.ChainConfig, upgrade precompileconfig.Upgrade) error {
+	roles := make(map[common.Address]Role)
+	for _, list := range []struct {
+		role      Role
+		addresses []common.Address
+	}{
+		{EnabledRole, c.EnabledAddresses},
+		{ManagerRole, c.ManagerAddresses},
+		{AdminRole, c.AdminAddresses},
+	} {
+		for _, addr := range list.addresses {
+			if role, ok := roles[addr]; ok {
+				if role == list.role {
+					return fmt.Errorf("duplicate address %s in %s list", addr, role)
+				}
+				return fmt.Errorf("cannot set address %s as both %s and %s", addr, role, list.role)
+			}
+			roles[addr] = list.role
+		}
+	}
+
+	if len(c.ManagerAddresses) != 0 && upgrade.Timestamp() != nil {
+		if !chainConfig.IsDurango(*upgrade.Timestamp()) {
+			return errManagerBeforeDurango
+		}
+	}
+	return nil
+}
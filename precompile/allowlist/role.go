@@ -0,0 +1,117 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Roles of an address in an allow list:
+//  1. NoRole - the default role of every address, equivalent to common.Hash{}.
+//  2. EnabledRole - allowed to use the resource gated by the allow list.
+//  3. AdminRole - allowed to use the resource and to modify the allow list.
+//  4. ManagerRole - allowed to use the resource and to add and remove enabled
+//     addresses from the allow list.
+//
+// Roles are stored in the state, so their values must never be changed.
+var (
+	NoRole      = Role(common.BigToHash(common.Big0))
+	EnabledRole = Role(common.BigToHash(common.Big1))
+	AdminRole   = Role(common.BigToHash(common.Big2))
+	ManagerRole = Role(common.BigToHash(common.Big3))
+
+	ErrInvalidRole = errors.New("invalid role")
+)
+
+// Role is the role of an address in an allow list, as stored in the state.
+type Role common.Hash
+
+// Valid returns true iff [r] is a known role.
+func (r Role) Valid() bool {
+	switch r {
+	case NoRole, EnabledRole, AdminRole, ManagerRole:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNoRole returns true if [r] grants no permissions.
+func (r Role) IsNoRole() bool {
+	return r == NoRole
+}
+
+// IsAdmin returns true if [r] is the admin role.
+func (r Role) IsAdmin() bool {
+	return r == AdminRole
+}
+
+// IsManager returns true if [r] is the manager role.
+func (r Role) IsManager() bool {
+	return r == ManagerRole
+}
+
+// IsEnabled returns true if [r] has permission to use the resource gated by
+// the allow list.
+func (r Role) IsEnabled() bool {
+	switch r {
+	case EnabledRole, AdminRole, ManagerRole:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanModify returns true if an address with role [r] is allowed to change the
+// role of another address from [from] to [target].
+// Admins can make any change, while managers can only add and remove enabled
+// addresses.
+func (r Role) CanModify(from, target Role) bool {
+	switch r {
+	case AdminRole:
+		return true
+	case ManagerRole:
+		return (from == EnabledRole || from == NoRole) && (target == EnabledRole || target == NoRole)
+	default:
+		return false
+	}
+}
+
+// Bytes returns the 32 byte representation of [r].
+func (r Role) Bytes() []byte {
+	return common.Hash(r).Bytes()
+}
+
+// Big returns the big.Int representation of [r].
+func (r Role) Big() *big.Int {
+	return common.Hash(r).Big()
+}
+
+func (r Role) String() string {
+	switch r {
+	case NoRole:
+		return "NoRole"
+	case EnabledRole:
+		return "EnabledRole"
+	case AdminRole:
+		return "AdminRole"
+	case ManagerRole:
+		return "ManagerRole"
+	default:
+		return "UnknownRole"
+	}
+}
+
+// FromBig converts [b] to a Role, returning ErrInvalidRole if it is not a
+// known role.
+func FromBig(b *big.Int) (Role, error) {
+	role := Role(common.BigToHash(b))
+	if !role.Valid() {
+		return Role{}, ErrInvalidRole
+	}
+	return role, nil
+}
@@ -0,0 +1,43 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleCanModify(t *testing.T) {
+	tests := []struct {
+		role     Role
+		from     Role
+		target   Role
+		expected bool
+	}{
+		{role: AdminRole, from: AdminRole, target: NoRole, expected: true},
+		{role: AdminRole, from: NoRole, target: ManagerRole, expected: true},
+		{role: ManagerRole, from: NoRole, target: EnabledRole, expected: true},
+		{role: ManagerRole, from: EnabledRole, target: NoRole, expected: true},
+		{role: ManagerRole, from: NoRole, target: AdminRole, expected: false},
+		{role: ManagerRole, from: ManagerRole, target: NoRole, expected: false},
+		{role: ManagerRole, from: AdminRole, target: EnabledRole, expected: false},
+		{role: EnabledRole, from: NoRole, target: EnabledRole, expected: false},
+		{role: NoRole, from: NoRole, target: EnabledRole, expected: false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, test.role.CanModify(test.from, test.target), "%s changing %s to %s", test.role, test.from, test.target)
+	}
+}
+
+func TestRoleFromBig(t *testing.T) {
+	for _, role := range []Role{NoRole, EnabledRole, AdminRole, ManagerRole} {
+		parsed, err := FromBig(role.Big())
+		require.NoError(t, err)
+		require.Equal(t, role, parsed)
+	}
+	_, err := FromBig(big.NewInt(4))
+	require.ErrorIs(t, err, ErrInvalidRole)
+}
@@ -0,0 +1,38 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"github.com/ava-labs/coreth/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LogGasCost returns the gas cost of a log with [numTopics] topics and
+// [dataLen] bytes of data, as charged by the LOG opcodes.
+func LogGasCost(numTopics int, dataLen int) uint64 {
+	return LogGas + uint64(numTopics)*LogTopicGas + uint64(dataLen)*LogDataGas
+}
+
+// PackEvent returns the topics and the data of a log of [event] with [args],
+// given in the order of the event definition, as packed by abi.ABI.PackEvent,
+// so the log can be decoded with the ABI of the precompile.
+func PackEvent(event abi.Event, args ...interface{}) ([]common.Hash, []byte, error) {
+	eventABI := abi.ABI{Events: map[string]abi.Event{event.Name: event}}
+	return eventABI.PackEvent(event.Name, args...)
+}
+
+// EmitEvent adds a log of [event] with [args] by the precompile at [address]
+// to the state of [accessibleState], after deducting the gas of the log from
+// [suppliedGas].
+func EmitEvent(accessibleState AccessibleState, address common.Address, event abi.Event, suppliedGas uint64, args ...interface{}) (remainingGas uint64, err error) {
+	topics, data, err := PackEvent(event, args...)
+	if err != nil {
+		return suppliedGas, err
+	}
+	if remainingGas, err = DeductGas(suppliedGas, LogGasCost(len(topics), len(data))); err != nil {
+		return 0, err
+	}
+	accessibleState.GetStateDB().AddLog(address, topics, data, accessibleState.GetBlockContext().Number().Uint64())
+	return remainingGas, nil
+}
@@ -0,0 +1,125 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/accounts/abi"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testEventsABI = ParseABI(`[
+	{
+		"anonymous": false,
+		"inputs": [
+			{ "indexed": true, "internalType": "address", "name": "sender", "type": "address" },
+			{ "indexed": true, "internalType": "string", "name": "label", "type": "string" },
+			{ "indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256" },
+			{ "indexed": false, "internalType": "bytes", "name": "memo", "type": "bytes" }
+		],
+		"name": "Transfer",
+		"type": "event"
+	},
+	{
+		"anonymous": true,
+		"inputs": [
+			{ "indexed": true, "internalType": "uint256", "name": "id", "type": "uint256" }
+		],
+		"name": "Anonymous",
+		"type": "event"
+	}
+]`)
+
+func TestPackEvent(t *testing.T) {
+	require := require.New(t)
+
+	event := testEventsABI.Events["Transfer"]
+	sender := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	topics, data, err := PackEvent(event, sender, "label", big.NewInt(7), []byte("memo"))
+	require.NoError(err)
+
+	// The log packed from the event definition can be decoded with the ABI.
+	require.Len(topics, 3)
+	require.Equal(event.ID, topics[0])
+	var indexedArgs abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexedArgs = append(indexedArgs, input)
+		}
+	}
+	indexed := make(map[string]interface{})
+	require.NoError(abi.ParseTopicsIntoMap(indexed, indexedArgs, topics[1:]))
+	require.Equal(sender, indexed["sender"])
+	nonIndexed := make(map[string]interface{})
+	require.NoError(testEventsABI.UnpackIntoMap(nonIndexed, "Transfer", data))
+	require.Equal(big.NewInt(7), nonIndexed["amount"])
+	require.Equal([]byte("memo"), nonIndexed["memo"])
+
+	// The log is the same as packed through the ABI.
+	abiTopics, abiData, err := testEventsABI.PackEvent("Transfer", sender, "label", big.NewInt(7), []byte("memo"))
+	require.NoError(err)
+	require.Equal(abiTopics, topics)
+	require.Equal(abiData, data)
+
+	// Anonymous events have no topic for their ID.
+	topics, data, err = PackEvent(testEventsABI.Events["Anonymous"], big.NewInt(1))
+	require.NoError(err)
+	require.Equal([]common.Hash{common.BigToHash(big.NewInt(1))}, topics)
+	require.Empty(data)
+
+	_, _, err = PackEvent(event, sender)
+	require.ErrorContains(err, "unexpected number of inputs 1")
+	_, _, err = PackEvent(event, sender, "label", "not a number", []byte("memo"))
+	require.Error(err)
+}
+
+func TestEmitEvent(t *testing.T) {
+	event := testEventsABI.Events["Transfer"]
+	sender := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	precompileAddr := common.HexToAddress("0x0200000000000000000000000000000000000010")
+	topics, data, err := PackEvent(event, sender, "label", big.NewInt(7), []byte("memo"))
+	require.NoError(t, err)
+	gasCost := LogGasCost(len(topics), len(data))
+	require.Equal(t, LogGas+3*LogTopicGas+uint64(len(data))*LogDataGas, gasCost)
+
+	tests := map[string]struct {
+		suppliedGas  uint64
+		expectLog    bool
+		remainingGas uint64
+		expectedErr  error
+	}{
+		"sufficient gas": {
+			suppliedGas:  gasCost + 1,
+			expectLog:    true,
+			remainingGas: 1,
+		},
+		"insufficient gas": {
+			suppliedGas: gasCost - 1,
+			expectedErr: vmerrs.ErrOutOfGas,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			stateDB := NewMockStateDB(ctrl)
+			blockContext := NewMockBlockContext(ctrl)
+			blockContext.EXPECT().Number().Return(big.NewInt(5)).AnyTimes()
+			accessibleState := NewMockAccessibleState(ctrl)
+			accessibleState.EXPECT().GetStateDB().Return(stateDB).AnyTimes()
+			accessibleState.EXPECT().GetBlockContext().Return(blockContext).AnyTimes()
+			if test.expectLog {
+				stateDB.EXPECT().AddLog(precompileAddr, topics, data, uint64(5))
+			}
+
+			remainingGas, err := EmitEvent(accessibleState, precompileAddr, event, test.suppliedGas, sender, "label", big.NewInt(7), []byte("memo"))
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.remainingGas, remainingGas)
+		})
+	}
+}
@@ -58,3 +58,26 @@ func ParseABI(rawABI string) abi.ABI {
 
 	return parsed
 }
+
+// MergeABIs returns an ABI with the methods, events and errors of all [abis],
+// such as the ABI of a precompile and the one of its allow list.
+// If several ABIs define the same name, the last one wins.
+func MergeABIs(abis ...abi.ABI) abi.ABI {
+	merged := abi.ABI{
+		Methods: make(map[string]abi.Method),
+		Events:  make(map[string]abi.Event),
+		Errors:  make(map[string]abi.Error),
+	}
+	for _, a := range abis {
+		for name, method := range a.Methods {
+			merged.Methods[name] = method
+		}
+		for name, event := range a.Events {
+			merged.Events[name] = event
+		}
+		for name, abiErr := range a.Errors {
+			merged.Errors[name] = abiErr
+		}
+	}
+	return merged
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFunctionSignatureRegex(t *testing.T) {
@@ -64,3 +65,17 @@ func TestFunctionSignatureRegex(t *testing.T) {
 		assert.Equal(t, test.pass, functionSignatureRegex.MatchString(test.str), "unexpected result for %q", test.str)
 	}
 }
+
+func TestMergeABIs(t *testing.T) {
+	functionsABI := ParseABI(`[
+		{ "inputs": [], "name": "get", "outputs": [], "stateMutability": "view", "type": "function" }
+	]`)
+	merged := MergeABIs(functionsABI, testEventsABI)
+	require.Len(t, merged.Methods, 1)
+	require.Len(t, merged.Events, 2)
+
+	method, err := merged.MethodById(functionsABI.Methods["get"].ID)
+	require.NoError(t, err)
+	require.Equal(t, "get", method.Name)
+	require.Equal(t, testEventsABI.Events["Transfer"].ID, merged.Events["Transfer"].ID)
+}
@@ -0,0 +1,62 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package deployerallowlist
+
+import (
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for ContractDeployerAllowList.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// ContractDeployerAllowList with the given [admins], [enableds] and [managers] as members of the allow list.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade: precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables ContractDeployerAllowList.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the ContractDeployerAllowList precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
+}
+
+// Equal returns true if [s] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(s precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (s).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig)
+}
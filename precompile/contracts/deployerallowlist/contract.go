@@ -0,0 +1,27 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package deployerallowlist
+
+import (
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractDeployerAllowListPrecompile is the singleton StatefulPrecompiledContract
+// managing the addresses allowed to deploy contracts.
+var ContractDeployerAllowListPrecompile = allowlist.CreateAllowListPrecompile(ContractAddress)
+
+// GetContractDeployerAllowListStatus returns the role of [address] for the contract deployer
+// allow list.
+func GetContractDeployerAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetContractDeployerAllowListStatus sets the permissions of [address] to [role] for the
+// contract deployer allow list.
+// Assumes [role] has already been verified as valid.
+func SetContractDeployerAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
@@ -0,0 +1,85 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for FeeManager.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	// InitialFeeConfig is stored when the precompile is activated. If nil, the
+	// defaults of the network upgrades apply until a fee config is set.
+	InitialFeeConfig *params.FeeConfig `json:"initialFeeConfig,omitempty"`
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// FeeManager with the given [admins], [enableds] and [managers] as members of the
+// allow list, and stores [initialConfig] if it is not nil.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, initialConfig *params.FeeConfig) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:          precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialFeeConfig: initialConfig,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables FeeManager.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the FeeManager precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if err := c.AllowListConfig.Verify(chainConfig, c.Upgrade); err != nil {
+		return err
+	}
+	if c.InitialFeeConfig == nil {
+		return nil
+	}
+	if err := c.InitialFeeConfig.Verify(); err != nil {
+		return fmt.Errorf("invalid initial fee config: %w", err)
+	}
+	return nil
+}
+
+// Equal returns true if [s] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(s precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (s).(*Config)
+	if !ok {
+		return false
+	}
+	if !c.Upgrade.Equal(&other.Upgrade) || !c.AllowListConfig.Equal(&other.AllowListConfig) {
+		return false
+	}
+	if c.InitialFeeConfig == nil {
+		return other.InitialFeeConfig == nil
+	}
+	return c.InitialFeeConfig.Equal(other.InitialFeeConfig)
+}
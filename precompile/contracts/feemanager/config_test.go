@@ -0,0 +1,102 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+var testFeeConfig = params.FeeConfig{
+	GasLimit:        big.NewInt(8_000_000),
+	TargetBlockRate: 2, // in seconds
+
+	MinBaseFee:               big.NewInt(25_000_000_000),
+	TargetGas:                big.NewInt(15_000_000),
+	BaseFeeChangeDenominator: big.NewInt(36),
+
+	MinBlockGasCost:  big.NewInt(0),
+	MaxBlockGasCost:  big.NewInt(1_000_000),
+	BlockGasCostStep: big.NewInt(200_000),
+}
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	invalidFeeConfig := testFeeConfig
+	invalidFeeConfig.MinBlockGasCost = big.NewInt(2_000_000)
+	missingFeeConfig := testFeeConfig
+	missingFeeConfig.TargetGas = nil
+	zeroGasLimitFeeConfig := testFeeConfig
+	zeroGasLimitFeeConfig.GasLimit = big.NewInt(0)
+
+	tests := allowlisttest.VerifyTests(t, Module)
+	tests["disable config"] = testutils.ConfigVerifyTest{
+		Config: NewDisableConfig(utils.NewUint64(3)),
+	}
+	tests["valid initial fee config"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &testFeeConfig),
+	}
+	tests["min block gas cost greater than max"] = testutils.ConfigVerifyTest{
+		Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, &invalidFeeConfig),
+		ExpectedError: "minBlockGasCost = 2000000 cannot be greater than maxBlockGasCost = 1000000",
+	}
+	tests["missing target gas"] = testutils.ConfigVerifyTest{
+		Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, &missingFeeConfig),
+		ExpectedError: "targetGas cannot be nil",
+	}
+	tests["zero gas limit"] = testutils.ConfigVerifyTest{
+		Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, &zeroGasLimitFeeConfig),
+		ExpectedError: "gasLimit = 0 must be in [5000, 9223372036854775807]",
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	enableds := []common.Address{allowlisttest.TestEnabledAddr}
+	managers := []common.Address{allowlisttest.TestManagerAddr}
+	otherFeeConfig := testFeeConfig
+	otherFeeConfig.GasLimit = big.NewInt(15_000_000)
+
+	tests := allowlisttest.EqualTests(Module)
+	tests["non-nil config and nil other"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    nil,
+		Expected: false,
+	}
+	tests["different type"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+		Expected: false,
+	}
+	tests["different timestamp"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    NewConfig(utils.NewUint64(4), admins, enableds, managers, nil),
+		Expected: false,
+	}
+	tests["nil and non-nil initial fee config"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers, &testFeeConfig),
+		Expected: false,
+	}
+	tests["different initial fee config"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, &testFeeConfig),
+		Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers, &otherFeeConfig),
+		Expected: false,
+	}
+	tests["same config"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, &testFeeConfig),
+		Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers, &testFeeConfig),
+		Expected: true,
+	}
+	testutils.RunEqualTests(t, tests)
+}
@@ -0,0 +1,242 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/vmerrs"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Gas costs of the functions of the precompile.
+const (
+	SetFeeConfigGasCost              = contract.WriteGasCostPerSlot * (numFeeConfigFields + 1) // plus one for the last changed at block number
+	GetFeeConfigGasCost              = contract.ReadGasCostPerSlot * numFeeConfigFields
+	GetFeeConfigLastChangedAtGasCost = contract.ReadGasCostPerSlot
+)
+
+var (
+	// FeeManagerRawABI contains the raw ABI of the functions of the fee
+	// manager, in addition to the ones of its allow list.
+	//go:embed contract.abi
+	FeeManagerRawABI string
+
+	FeeManagerABI = contract.ParseABI(FeeManagerRawABI)
+
+	// FeeManagerPrecompile is the singleton StatefulPrecompiledContract
+	// storing the fee configuration.
+	FeeManagerPrecompile = createFeeManagerPrecompile()
+
+	// ErrCannotChangeFee is returned when the caller is not enabled in the
+	// allow list of the fee manager.
+	ErrCannotChangeFee = errors.New("non-enabled cannot change fee config")
+)
+
+// FeeConfigABIStruct is the ABI representation of a FeeConfig, as taken by
+// setFeeConfig and returned by getFeeConfig.
+type FeeConfigABIStruct struct {
+	GasLimit                 *big.Int
+	TargetBlockRate          *big.Int
+	MinBaseFee               *big.Int
+	TargetGas                *big.Int
+	BaseFeeChangeDenominator *big.Int
+	MinBlockGasCost          *big.Int
+	MaxBlockGasCost          *big.Int
+	BlockGasCostStep         *big.Int
+}
+
+// GetFeeManagerStatus returns the role of [address] for the fee manager.
+func GetFeeManagerStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetFeeManagerStatus sets the permissions of [address] to [role] for the fee
+// manager.
+// Assumes [role] has already been verified as valid.
+func SetFeeManagerStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// toABIStruct converts [feeConfig] to its ABI representation.
+func toABIStruct(feeConfig *params.FeeConfig) FeeConfigABIStruct {
+	return FeeConfigABIStruct{
+		GasLimit:                 feeConfig.GasLimit,
+		TargetBlockRate:          new(big.Int).SetUint64(feeConfig.TargetBlockRate),
+		MinBaseFee:               feeConfig.MinBaseFee,
+		TargetGas:                feeConfig.TargetGas,
+		BaseFeeChangeDenominator: feeConfig.BaseFeeChangeDenominator,
+		MinBlockGasCost:          feeConfig.MinBlockGasCost,
+		MaxBlockGasCost:          feeConfig.MaxBlockGasCost,
+		BlockGasCostStep:         feeConfig.BlockGasCostStep,
+	}
+}
+
+// PackSetFeeConfig packs [feeConfig] into the input of setFeeConfig.
+// This function is mostly used for tests.
+func PackSetFeeConfig(feeConfig *params.FeeConfig) ([]byte, error) {
+	s := toABIStruct(feeConfig)
+	return FeeManagerABI.Pack("setFeeConfig",
+		s.GasLimit,
+		s.TargetBlockRate,
+		s.MinBaseFee,
+		s.TargetGas,
+		s.BaseFeeChangeDenominator,
+		s.MinBlockGasCost,
+		s.MaxBlockGasCost,
+		s.BlockGasCostStep,
+	)
+}
+
+// UnpackSetFeeConfigInput unpacks [input] into the fee configuration it sets.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetFeeConfigInput(input []byte) (*params.FeeConfig, error) {
+	s := FeeConfigABIStruct{}
+	// Strict mode is not used since it was disabled with Durango.
+	if err := FeeManagerABI.UnpackInputIntoInterface(&s, "setFeeConfig", input, false); err != nil {
+		return nil, err
+	}
+	if !s.TargetBlockRate.IsUint64() {
+		return nil, fmt.Errorf("targetBlockRate = %d is not a uint64", s.TargetBlockRate)
+	}
+	return &params.FeeConfig{
+		GasLimit:                 s.GasLimit,
+		TargetBlockRate:          s.TargetBlockRate.Uint64(),
+		MinBaseFee:               s.MinBaseFee,
+		TargetGas:                s.TargetGas,
+		BaseFeeChangeDenominator: s.BaseFeeChangeDenominator,
+		MinBlockGasCost:          s.MinBlockGasCost,
+		MaxBlockGasCost:          s.MaxBlockGasCost,
+		BlockGasCostStep:         s.BlockGasCostStep,
+	}, nil
+}
+
+// PackGetFeeConfig packs the selector of getFeeConfig.
+// This function is mostly used for tests.
+func PackGetFeeConfig() ([]byte, error) {
+	return FeeManagerABI.Pack("getFeeConfig")
+}
+
+// PackGetFeeConfigOutput packs [feeConfig] into the output of getFeeConfig.
+func PackGetFeeConfigOutput(feeConfig *params.FeeConfig) ([]byte, error) {
+	s := toABIStruct(feeConfig)
+	return FeeManagerABI.PackOutput("getFeeConfig",
+		s.GasLimit,
+		s.TargetBlockRate,
+		s.MinBaseFee,
+		s.TargetGas,
+		s.BaseFeeChangeDenominator,
+		s.MinBlockGasCost,
+		s.MaxBlockGasCost,
+		s.BlockGasCostStep,
+	)
+}
+
+// PackGetFeeConfigLastChangedAt packs the selector of getFeeConfigLastChangedAt.
+// This function is mostly used for tests.
+func PackGetFeeConfigLastChangedAt() ([]byte, error) {
+	return FeeManagerABI.Pack("getFeeConfigLastChangedAt")
+}
+
+// PackGetFeeConfigLastChangedAtOutput packs [blockNumber] into the output of
+// getFeeConfigLastChangedAt.
+func PackGetFeeConfigLastChangedAtOutput(blockNumber *big.Int) ([]byte, error) {
+	return FeeManagerABI.PackOutput("getFeeConfigLastChangedAt", blockNumber)
+}
+
+// setFeeConfig stores the fee configuration given in [input], which applies
+// from the next block on. The caller must be enabled in the allow list.
+func setFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	feeConfig, err := UnpackSetFeeConfigInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("invalid setFeeConfig input: %w", err)
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	if callerStatus := GetFeeManagerStatus(stateDB, caller); !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
+	}
+	if err := StoreFeeConfig(stateDB, feeConfig, accessibleState.GetBlockContext().Number()); err != nil {
+		return nil, remainingGas, err
+	}
+	return []byte{}, remainingGas, nil
+}
+
+// getFeeConfig returns the stored fee configuration, which is all zeros if
+// none has been stored and the defaults of the network upgrades apply.
+func getFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+	feeConfig := GetStoredFeeConfig(accessibleState.GetStateDB())
+	if feeConfig == nil {
+		feeConfig = &params.FeeConfig{
+			GasLimit:                 new(big.Int),
+			MinBaseFee:               new(big.Int),
+			TargetGas:                new(big.Int),
+			BaseFeeChangeDenominator: new(big.Int),
+			MinBlockGasCost:          new(big.Int),
+			MaxBlockGasCost:          new(big.Int),
+			BlockGasCostStep:         new(big.Int),
+		}
+	}
+	output, err := PackGetFeeConfigOutput(feeConfig)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// getFeeConfigLastChangedAt returns the number of the block in which the fee
+// configuration was last stored.
+func getFeeConfigLastChangedAt(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetFeeConfigLastChangedAtGasCost); err != nil {
+		return nil, 0, err
+	}
+	output, err := PackGetFeeConfigLastChangedAtOutput(GetFeeConfigLastChangedAt(accessibleState.GetStateDB()))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// createFeeManagerPrecompile returns a StatefulPrecompiledContract with the
+// functions of the fee manager and of its allow list.
+func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
+	functions := allowlist.CreateAllowListFunctions(ContractAddress)
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"setFeeConfig":              setFeeConfig,
+		"getFeeConfig":              getFeeConfig,
+		"getFeeConfigLastChangedAt": getFeeConfigLastChangedAt,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := FeeManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,141 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContract(t *testing.T) {
+	config := allowlisttest.MkConfigWithAllowList(Module, &allowlist.AllowListConfig{
+		AdminAddresses:   []common.Address{allowlisttest.TestAdminAddr},
+		EnabledAddresses: []common.Address{allowlisttest.TestEnabledAddr},
+	})
+	setFeeConfigInput, err := PackSetFeeConfig(&testFeeConfig)
+	require.NoError(t, err)
+	getFeeConfigInput, err := PackGetFeeConfig()
+	require.NoError(t, err)
+	getLastChangedAtInput, err := PackGetFeeConfigLastChangedAt()
+	require.NoError(t, err)
+
+	invalidFeeConfig := testFeeConfig
+	invalidFeeConfig.TargetGas = big.NewInt(0)
+	invalidSetFeeConfigInput, err := PackSetFeeConfig(&invalidFeeConfig)
+	require.NoError(t, err)
+
+	storedFeeConfigOutput, err := PackGetFeeConfigOutput(&testFeeConfig)
+	require.NoError(t, err)
+	unsetFeeConfigOutput := make([]byte, numFeeConfigFields*common.HashLength)
+	lastChangedAtOutput, err := PackGetFeeConfigLastChangedAtOutput(big.NewInt(7))
+	require.NoError(t, err)
+
+	storeTestFeeConfig := func(t testing.TB, state contract.StateDB) {
+		require.NoError(t, StoreFeeConfig(state, &testFeeConfig, big.NewInt(7)))
+	}
+	expectFeeConfig := func(expected *params.FeeConfig) func(t testing.TB, state contract.StateDB) {
+		return func(t testing.TB, state contract.StateDB) {
+			stored := GetStoredFeeConfig(state)
+			if expected == nil {
+				require.Nil(t, stored)
+				return
+			}
+			require.True(t, expected.Equal(stored), "expected %+v, got %+v", expected, stored)
+		}
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"admin set fee config": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       setFeeConfigInput,
+			SuppliedGas: SetFeeConfigGasCost,
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				expectFeeConfig(&testFeeConfig)(t, state)
+				require.Zero(t, GetFeeConfigLastChangedAt(state).Sign())
+			},
+		},
+		"enabled set fee config": {
+			Caller:      allowlisttest.TestEnabledAddr,
+			Input:       setFeeConfigInput,
+			SuppliedGas: SetFeeConfigGasCost,
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectFeeConfig(&testFeeConfig),
+		},
+		"no role set fee config": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       setFeeConfigInput,
+			SuppliedGas: SetFeeConfigGasCost,
+			Config:      config,
+			ExpectedErr: ErrCannotChangeFee.Error(),
+			AfterHook:   expectFeeConfig(nil),
+		},
+		"set invalid fee config": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       invalidSetFeeConfigInput,
+			SuppliedGas: SetFeeConfigGasCost,
+			Config:      config,
+			ExpectedErr: "targetGas = 0 must be in [1, 80000000]",
+			AfterHook:   expectFeeConfig(nil),
+		},
+		"set fee config readOnly": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       setFeeConfigInput,
+			SuppliedGas: SetFeeConfigGasCost,
+			ReadOnly:    true,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"set fee config insufficient gas": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       setFeeConfigInput,
+			SuppliedGas: SetFeeConfigGasCost - 1,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get unset fee config": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       getFeeConfigInput,
+			SuppliedGas: GetFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: unsetFeeConfigOutput,
+			Config:      config,
+		},
+		"get stored fee config": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       getFeeConfigInput,
+			SuppliedGas: GetFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: storedFeeConfigOutput,
+			Config:      config,
+			BeforeHook:  storeTestFeeConfig,
+		},
+		"get fee config last changed at": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       getLastChangedAtInput,
+			SuppliedGas: GetFeeConfigLastChangedAtGasCost,
+			ReadOnly:    true,
+			ExpectedRes: lastChangedAtOutput,
+			Config:      config,
+			BeforeHook:  storeTestFeeConfig,
+		},
+		"initial fee config": {
+			Config:    NewConfig(new(uint64), nil, nil, nil, &testFeeConfig),
+			AfterHook: expectFeeConfig(&testFeeConfig),
+		},
+	}
+	allowlisttest.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
@@ -0,0 +1,91 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package feemanager
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Indices of the fields of the stored fee configuration in its storage keys.
+// The keys cannot collide with the keys of the allow list, which are left
+// padded addresses, and must not rely on the lowest bit of their first byte,
+// which is cleared when the state normalizes them.
+const (
+	gasLimitKey = iota + 1
+	targetBlockRateKey
+	minBaseFeeKey
+	targetGasKey
+	baseFeeChangeDenominatorKey
+	minBlockGasCostKey
+	maxBlockGasCostKey
+	blockGasCostStepKey
+
+	numFeeConfigFields = blockGasCostStepKey
+)
+
+var feeConfigLastChangedAtKey = common.Hash{'l', 'c', 'a'}
+
+// feeConfigFieldKey returns the storage key of the field [field] of the fee
+// configuration.
+func feeConfigFieldKey(field int) common.Hash {
+	return common.Hash{'f', 'c', 'k', byte(field)}
+}
+
+// GetStoredFeeConfig returns the fee configuration stored in [stateDB], or nil
+// if none has been stored.
+func GetStoredFeeConfig(stateDB contract.StateDB) *params.FeeConfig {
+	get := func(field int) *big.Int {
+		return stateDB.GetState(ContractAddress, feeConfigFieldKey(field)).Big()
+	}
+	// A stored configuration always has a positive gas limit.
+	gasLimit := get(gasLimitKey)
+	if gasLimit.Sign() == 0 {
+		return nil
+	}
+	return &params.FeeConfig{
+		GasLimit:                 gasLimit,
+		TargetBlockRate:          get(targetBlockRateKey).Uint64(),
+		MinBaseFee:               get(minBaseFeeKey),
+		TargetGas:                get(targetGasKey),
+		BaseFeeChangeDenominator: get(baseFeeChangeDenominatorKey),
+		MinBlockGasCost:          get(minBlockGasCostKey),
+		MaxBlockGasCost:          get(maxBlockGasCostKey),
+		BlockGasCostStep:         get(blockGasCostStepKey),
+	}
+}
+
+// GetFeeConfigLastChangedAt returns the number of the block in which the
+// stored fee configuration was last changed.
+func GetFeeConfigLastChangedAt(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, feeConfigLastChangedAtKey).Big()
+}
+
+// StoreFeeConfig stores [feeConfig] in [stateDB] and records [blockNumber] as
+// the block it was last changed at.
+// Returns an error if [feeConfig] is invalid.
+func StoreFeeConfig(stateDB contract.StateDB, feeConfig *params.FeeConfig, blockNumber *big.Int) error {
+	if err := feeConfig.Verify(); err != nil {
+		return fmt.Errorf("cannot store invalid fee config: %w", err)
+	}
+	values := [numFeeConfigFields + 1]*big.Int{
+		gasLimitKey:                 feeConfig.GasLimit,
+		targetBlockRateKey:          new(big.Int).SetUint64(feeConfig.TargetBlockRate),
+		minBaseFeeKey:               feeConfig.MinBaseFee,
+		targetGasKey:                feeConfig.TargetGas,
+		baseFeeChangeDenominatorKey: feeConfig.BaseFeeChangeDenominator,
+		minBlockGasCostKey:          feeConfig.MinBlockGasCost,
+		maxBlockGasCostKey:          feeConfig.MaxBlockGasCost,
+		blockGasCostStepKey:         feeConfig.BlockGasCostStep,
+	}
+	for field := gasLimitKey; field <= numFeeConfigFields; field++ {
+		stateDB.SetState(ContractAddress, feeConfigFieldKey(field), common.BigToHash(values[field]))
+	}
+	stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(blockNumber))
+	return nil
+}
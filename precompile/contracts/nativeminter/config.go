@@ -0,0 +1,109 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeminter
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for ContractNativeMinter.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	// InitialMint is minted to each of its addresses when the precompile is
+	// activated, and counts towards the total minted.
+	InitialMint map[common.Address]*math.HexOrDecimal256 `json:"initialMint,omitempty"`
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// ContractNativeMinter with the given [admins], [enableds] and [managers] as members
+// of the allow list, and mints [initialMint] on activation.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, initialMint map[common.Address]*math.HexOrDecimal256) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:     precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialMint: initialMint,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables ContractNativeMinter.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the ContractNativeMinter precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if err := c.AllowListConfig.Verify(chainConfig, c.Upgrade); err != nil {
+		return err
+	}
+	total := new(big.Int)
+	for addr, amount := range c.InitialMint {
+		if amount == nil {
+			return fmt.Errorf("initial mint cannot contain nil amount for %s", addr)
+		}
+		bigIntAmount := (*big.Int)(amount)
+		if bigIntAmount.Sign() <= 0 {
+			return fmt.Errorf("initial mint amount for %s must be positive, got %d", addr, bigIntAmount)
+		}
+		total.Add(total, bigIntAmount)
+	}
+	if total.BitLen() > 256 {
+		return fmt.Errorf("initial mint total %d overflows 256 bits", total)
+	}
+	return nil
+}
+
+// Equal returns true if [s] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(s precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (s).(*Config)
+	if !ok {
+		return false
+	}
+	if !c.Upgrade.Equal(&other.Upgrade) || !c.AllowListConfig.Equal(&other.AllowListConfig) {
+		return false
+	}
+	if len(c.InitialMint) != len(other.InitialMint) {
+		return false
+	}
+	for addr, amount := range c.InitialMint {
+		otherAmount, ok := other.InitialMint[addr]
+		if !ok {
+			return false
+		}
+		if amount == nil || otherAmount == nil {
+			if amount != otherAmount {
+				return false
+			}
+			continue
+		}
+		if (*big.Int)(amount).Cmp((*big.Int)(otherAmount)) != 0 {
+			return false
+		}
+	}
+	return true
+}
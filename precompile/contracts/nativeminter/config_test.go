@@ -0,0 +1,110 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeminter
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+
+	tests := allowlisttest.VerifyTests(t, Module)
+	tests["disable config"] = testutils.ConfigVerifyTest{
+		Config: NewDisableConfig(utils.NewUint64(3)),
+	}
+	tests["valid initial mint"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, map[common.Address]*math.HexOrDecimal256{
+			allowlisttest.TestEnabledAddr: math.NewHexOrDecimal256(1),
+			allowlisttest.TestNoRoleAddr:  math.NewHexOrDecimal256(2),
+		}),
+	}
+	tests["nil initial mint amount"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, map[common.Address]*math.HexOrDecimal256{
+			allowlisttest.TestEnabledAddr: nil,
+		}),
+		ExpectedError: "initial mint cannot contain nil amount",
+	}
+	tests["zero initial mint amount"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, map[common.Address]*math.HexOrDecimal256{
+			allowlisttest.TestEnabledAddr: math.NewHexOrDecimal256(0),
+		}),
+		ExpectedError: "must be positive",
+	}
+	tests["negative initial mint amount"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, map[common.Address]*math.HexOrDecimal256{
+			allowlisttest.TestEnabledAddr: math.NewHexOrDecimal256(-1),
+		}),
+		ExpectedError: "must be positive",
+	}
+	tests["initial mint total overflows"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, map[common.Address]*math.HexOrDecimal256{
+			allowlisttest.TestEnabledAddr: (*math.HexOrDecimal256)(math.MaxBig256),
+			allowlisttest.TestNoRoleAddr:  math.NewHexOrDecimal256(1),
+		}),
+		ExpectedError: "overflows 256 bits",
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	enableds := []common.Address{allowlisttest.TestEnabledAddr}
+	managers := []common.Address{allowlisttest.TestManagerAddr}
+	initialMint := map[common.Address]*math.HexOrDecimal256{
+		allowlisttest.TestEnabledAddr: math.NewHexOrDecimal256(1),
+	}
+
+	tests := allowlisttest.EqualTests(Module)
+	tests["non-nil config and nil other"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    nil,
+		Expected: false,
+	}
+	tests["different type"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+		Expected: false,
+	}
+	tests["different timestamp"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    NewConfig(utils.NewUint64(4), admins, enableds, managers, nil),
+		Expected: false,
+	}
+	tests["nil and non-nil initial mint"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers, initialMint),
+		Expected: false,
+	}
+	tests["different initial mint amount"] = testutils.ConfigEqualTest{
+		Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, initialMint),
+		Other: NewConfig(utils.NewUint64(3), admins, enableds, managers, map[common.Address]*math.HexOrDecimal256{
+			allowlisttest.TestEnabledAddr: math.NewHexOrDecimal256(2),
+		}),
+		Expected: false,
+	}
+	tests["different initial mint address"] = testutils.ConfigEqualTest{
+		Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, initialMint),
+		Other: NewConfig(utils.NewUint64(3), admins, enableds, managers, map[common.Address]*math.HexOrDecimal256{
+			allowlisttest.TestNoRoleAddr: math.NewHexOrDecimal256(1),
+		}),
+		Expected: false,
+	}
+	tests["same config"] = testutils.ConfigEqualTest{
+		Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, initialMint),
+		Other: NewConfig(utils.NewUint64(3), admins, enableds, managers, map[common.Address]*math.HexOrDecimal256{
+			allowlisttest.TestEnabledAddr: math.NewHexOrDecimal256(1),
+		}),
+		Expected: true,
+	}
+	testutils.RunEqualTests(t, tests)
+}
@@ -0,0 +1,202 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeminter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/holiman/uint256"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Gas costs of the functions of the precompile.
+const (
+	// MintGasCost covers the balance update and the update of the total
+	// minted counter.
+	MintGasCost        = 2 * contract.WriteGasCostPerSlot
+	TotalMintedGasCost = contract.ReadGasCostPerSlot
+)
+
+// totalMintedKey is the storage key of the total amount minted by the
+// precompile. It cannot collide with the keys of the allow list, which are
+// left-padded addresses.
+var totalMintedKey = common.Hash{'t', 'm', 's'}
+
+var (
+	// NativeMinterRawABI contains the raw ABI of the functions and events of
+	// the native minter, in addition to the ones of its allow list.
+	//go:embed contract.abi
+	NativeMinterRawABI string
+
+	NativeMinterABI = contract.ParseABI(NativeMinterRawABI)
+
+	// ContractNativeMinterPrecompile is the singleton StatefulPrecompiledContract
+	// minting the native coin.
+	ContractNativeMinterPrecompile = createNativeMinterPrecompile()
+
+	// ErrCannotMint is returned when the caller is not enabled in the allow
+	// list of the native minter.
+	ErrCannotMint = errors.New("non-enabled cannot mint")
+	// ErrInvalidMintAmount is returned when minting a non-positive amount.
+	ErrInvalidMintAmount = errors.New("mint amount must be positive")
+	// ErrMintOverflow is returned when minting would overflow the balance of
+	// the recipient or the total minted.
+	ErrMintOverflow = errors.New("mint amount overflows")
+)
+
+// MintNativeCoinInput is the ABI representation of the input of mintNativeCoin.
+type MintNativeCoinInput struct {
+	Addr   common.Address
+	Amount *big.Int
+}
+
+// GetContractNativeMinterStatus returns the role of [address] for the native
+// minter.
+func GetContractNativeMinterStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetContractNativeMinterStatus sets the permissions of [address] to [role] for
+// the native minter.
+// Assumes [role] has already been verified as valid.
+func SetContractNativeMinterStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// GetTotalMinted returns the total amount of native coin minted by the
+// precompile, including the initial mints of its config.
+func GetTotalMinted(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, totalMintedKey).Big()
+}
+
+// Mint credits [amount] of native coin to [address], creating the account if
+// it does not exist, and adds it to the total minted. Neither the balance of
+// [address] nor the total minted may overflow 256 bits.
+// Assumes [amount] is positive.
+func Mint(stateDB contract.StateDB, address common.Address, amount *big.Int) error {
+	value, overflow := uint256.FromBig(amount)
+	if overflow {
+		return fmt.Errorf("%w: %d", ErrMintOverflow, amount)
+	}
+	if _, overflow := new(uint256.Int).AddOverflow(stateDB.GetBalance(address), value); overflow {
+		return fmt.Errorf("%w: balance of %s", ErrMintOverflow, address)
+	}
+	total, overflow := new(uint256.Int).AddOverflow(uint256.MustFromBig(GetTotalMinted(stateDB)), value)
+	if overflow {
+		return fmt.Errorf("%w: total minted", ErrMintOverflow)
+	}
+
+	if !stateDB.Exist(address) {
+		stateDB.CreateAccount(address)
+	}
+	stateDB.AddBalance(address, value)
+	stateDB.SetState(ContractAddress, totalMintedKey, total.Bytes32())
+	return nil
+}
+
+// PackMintNativeCoin packs [address] and [amount] into the input of
+// mintNativeCoin.
+// This function is mostly used for tests.
+func PackMintNativeCoin(address common.Address, amount *big.Int) ([]byte, error) {
+	return NativeMinterABI.Pack("mintNativeCoin", address, amount)
+}
+
+// UnpackMintNativeCoinInput unpacks [input] into the recipient and the amount
+// to mint.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackMintNativeCoinInput(input []byte) (common.Address, *big.Int, error) {
+	inputStruct := MintNativeCoinInput{}
+	// Strict mode is not used since it was disabled with Durango.
+	if err := NativeMinterABI.UnpackInputIntoInterface(&inputStruct, "mintNativeCoin", input, false); err != nil {
+		return common.Address{}, nil, err
+	}
+	return inputStruct.Addr, inputStruct.Amount, nil
+}
+
+// PackTotalMinted packs the selector of totalMinted.
+// This function is mostly used for tests.
+func PackTotalMinted() ([]byte, error) {
+	return NativeMinterABI.Pack("totalMinted")
+}
+
+// PackTotalMintedOutput packs [amount] into the output of totalMinted.
+func PackTotalMintedOutput(amount *big.Int) ([]byte, error) {
+	return NativeMinterABI.PackOutput("totalMinted", amount)
+}
+
+// mintNativeCoin mints the amount of native coin given in [input] to the
+// address given in [input]. The caller must be enabled in the allow list.
+func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, MintGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	to, amount, err := UnpackMintNativeCoinInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("invalid mintNativeCoin input: %w", err)
+	}
+	if amount.Sign() <= 0 {
+		return nil, remainingGas, fmt.Errorf("%w: %d", ErrInvalidMintAmount, amount)
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	if callerStatus := GetContractNativeMinterStatus(stateDB, caller); !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotMint, caller)
+	}
+	if err := Mint(stateDB, to, amount); err != nil {
+		return nil, remainingGas, err
+	}
+	if remainingGas, err = contract.EmitEvent(accessibleState, ContractAddress, NativeMinterABI.Events["NativeCoinMinted"], remainingGas, caller, to, amount); err != nil {
+		return nil, remainingGas, err
+	}
+	return []byte{}, remainingGas, nil
+}
+
+// totalMinted returns the total amount of native coin minted by the
+// precompile.
+func totalMinted(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, TotalMintedGasCost); err != nil {
+		return nil, 0, err
+	}
+	output, err := PackTotalMintedOutput(GetTotalMinted(accessibleState.GetStateDB()))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// createNativeMinterPrecompile returns a StatefulPrecompiledContract with the
+// functions of the native minter and of its allow list.
+func createNativeMinterPrecompile() contract.StatefulPrecompiledContract {
+	functions := allowlist.CreateAllowListFunctions(ContractAddress)
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"mintNativeCoin": mintNativeCoin,
+		"totalMinted":    totalMinted,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := NativeMinterABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
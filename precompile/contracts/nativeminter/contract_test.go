@@ -0,0 +1,148 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeminter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContract(t *testing.T) {
+	config := allowlisttest.MkConfigWithAllowList(Module, &allowlist.AllowListConfig{
+		AdminAddresses:   []common.Address{allowlisttest.TestAdminAddr},
+		EnabledAddresses: []common.Address{allowlisttest.TestEnabledAddr},
+	})
+	amount := big.NewInt(1_000)
+	mintInput, err := PackMintNativeCoin(allowlisttest.TestNoRoleAddr, amount)
+	require.NoError(t, err)
+	zeroMintInput, err := PackMintNativeCoin(allowlisttest.TestNoRoleAddr, new(big.Int))
+	require.NoError(t, err)
+	overflowMintInput, err := PackMintNativeCoin(allowlisttest.TestNoRoleAddr, math.MaxBig256)
+	require.NoError(t, err)
+	totalMintedInput, err := PackTotalMinted()
+	require.NoError(t, err)
+	totalMintedOutput, err := PackTotalMintedOutput(amount)
+	require.NoError(t, err)
+
+	expectMinted := func(caller common.Address, balance *big.Int, total *big.Int) func(t testing.TB, state contract.StateDB) {
+		return func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, uint256.MustFromBig(balance), state.GetBalance(allowlisttest.TestNoRoleAddr))
+			require.Zero(t, total.Cmp(GetTotalMinted(state)))
+			testutils.RequireEvents(t, state, testutils.ExpectedEvent{
+				Event: NativeMinterABI.Events["NativeCoinMinted"],
+				Args:  []interface{}{caller, allowlisttest.TestNoRoleAddr, amount},
+			})
+		}
+	}
+	expectNotMinted := func(t testing.TB, state contract.StateDB) {
+		require.Zero(t, state.GetBalance(allowlisttest.TestNoRoleAddr).Sign())
+		require.Zero(t, GetTotalMinted(state).Sign())
+		testutils.RequireEvents(t, state)
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"admin mint": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       mintInput,
+			SuppliedGas: MintGasCost + contract.LogGasCost(3, common.HashLength),
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectMinted(allowlisttest.TestAdminAddr, amount, amount),
+		},
+		"enabled mint": {
+			Caller:      allowlisttest.TestEnabledAddr,
+			Input:       mintInput,
+			SuppliedGas: MintGasCost + contract.LogGasCost(3, common.HashLength),
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook:   expectMinted(allowlisttest.TestEnabledAddr, amount, amount),
+		},
+		"mint adds to total minted": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       mintInput,
+			SuppliedGas: MintGasCost + contract.LogGasCost(3, common.HashLength),
+			ExpectedRes: []byte{},
+			Config:      config,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				require.NoError(t, Mint(state, allowlisttest.TestEnabledAddr, big.NewInt(5)))
+			},
+			AfterHook: expectMinted(allowlisttest.TestAdminAddr, amount, big.NewInt(1_005)),
+		},
+		"no role mint": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       mintInput,
+			SuppliedGas: MintGasCost,
+			Config:      config,
+			ExpectedErr: ErrCannotMint.Error(),
+			AfterHook:   expectNotMinted,
+		},
+		"mint zero": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       zeroMintInput,
+			SuppliedGas: MintGasCost,
+			Config:      config,
+			ExpectedErr: ErrInvalidMintAmount.Error(),
+			AfterHook:   expectNotMinted,
+		},
+		"mint overflows total minted": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       overflowMintInput,
+			SuppliedGas: MintGasCost,
+			Config:      config,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				require.NoError(t, Mint(state, allowlisttest.TestEnabledAddr, big.NewInt(1)))
+			},
+			ExpectedErr: ErrMintOverflow.Error(),
+		},
+		"mint readOnly": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       mintInput,
+			SuppliedGas: MintGasCost,
+			ReadOnly:    true,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"mint insufficient gas": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       mintInput,
+			SuppliedGas: MintGasCost + contract.LogGasCost(3, common.HashLength) - 1,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"total minted": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       totalMintedInput,
+			SuppliedGas: TotalMintedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: totalMintedOutput,
+			Config:      config,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				require.NoError(t, Mint(state, allowlisttest.TestEnabledAddr, amount))
+			},
+		},
+		"initial mint": {
+			Config: NewConfig(new(uint64), nil, nil, nil, map[common.Address]*math.HexOrDecimal256{
+				allowlisttest.TestEnabledAddr: math.NewHexOrDecimal256(2),
+				allowlisttest.TestNoRoleAddr:  math.NewHexOrDecimal256(3),
+			}),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, uint256.NewInt(2), state.GetBalance(allowlisttest.TestEnabledAddr))
+				require.Equal(t, uint256.NewInt(3), state.GetBalance(allowlisttest.TestNoRoleAddr))
+				require.Zero(t, big.NewInt(5).Cmp(GetTotalMinted(state)))
+			},
+		},
+	}
+	allowlisttest.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
@@ -0,0 +1,73 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nativeminter
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/modules"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ contract.Configurator = &configurator{}
+
+// ConfigKey is the key used in json config files to specify this precompile config.
+// must be unique across all precompiles.
+const ConfigKey = "contractNativeMinterConfig"
+
+// ContractAddress is the address of the native minter precompile contract
+var ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000001")
+
+// Module is the precompile module. It is used to register the precompile contract.
+var Module = modules.Module{
+	ConfigKey:    ConfigKey,
+	Address:      ContractAddress,
+	Contract:     ContractNativeMinterPrecompile,
+	ABI:          contract.MergeABIs(allowlist.AllowListABI, NativeMinterABI),
+	Configurator: &configurator{},
+}
+
+type configurator struct{}
+
+func init() {
+	// Register the precompile module.
+	// Each precompile contract registers itself through [RegisterModule] function.
+	if err := modules.RegisterModule(Module); err != nil {
+		panic(err)
+	}
+}
+
+// MakeConfig returns a new precompile config instance.
+// This is required to Marshal/Unmarshal the precompile config.
+func (*configurator) MakeConfig() precompileconfig.Config {
+	return new(Config)
+}
+
+// Configure mints the initial amounts from [cfg], if any, and sets the
+// initial roles of the allow list.
+func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg precompileconfig.Config, state contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	config, ok := cfg.(*Config)
+	if !ok {
+		return fmt.Errorf("expected config type %T, got %T: %v", &Config{}, cfg, cfg)
+	}
+	// Mint in address order so the configuration does not depend on map
+	// iteration.
+	addrs := make([]common.Address, 0, len(config.InitialMint))
+	for addr := range config.InitialMint {
+		addrs = append(addrs, addr)
+	}
+	slices.SortFunc(addrs, func(a, b common.Address) int { return a.Cmp(b) })
+	for _, addr := range addrs {
+		if err := Mint(state, addr, (*big.Int)(config.InitialMint[addr])); err != nil {
+			return fmt.Errorf("cannot mint initial amount to %s: %w", addr, err)
+		}
+	}
+	return config.AllowListConfig.Configure(chainConfig, ContractAddress, state, blockContext)
+}
@@ -0,0 +1,112 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rewardmanager
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+var errBothRewardsEnabled = errors.New("cannot both allow fee recipients and set a reward address")
+
+// InitialRewardConfig is the reward configuration stored when the reward
+// manager is activated. At most one of its fields may be set. If neither is
+// set, fees are burned.
+type InitialRewardConfig struct {
+	AllowFeeRecipients bool           `json:"allowFeeRecipients"`
+	RewardAddress      common.Address `json:"rewardAddress,omitempty"`
+}
+
+// Verify returns an error if [i] both allows fee recipients and sets a
+// reward address.
+func (i *InitialRewardConfig) Verify() error {
+	if i.AllowFeeRecipients && i.RewardAddress != (common.Address{}) {
+		return errBothRewardsEnabled
+	}
+	return nil
+}
+
+// Equal returns true if [i] and [other] are identical.
+func (i *InitialRewardConfig) Equal(other *InitialRewardConfig) bool {
+	if other == nil {
+		return false
+	}
+	return i.AllowFeeRecipients == other.AllowFeeRecipients && i.RewardAddress == other.RewardAddress
+}
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for RewardManager.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	// InitialRewardConfig is stored when the precompile is activated. If nil,
+	// fees are burned until the reward configuration is changed.
+	InitialRewardConfig *InitialRewardConfig `json:"initialRewardConfig,omitempty"`
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// RewardManager with the given [admins], [enableds] and [managers] as members of the
+// allow list, and stores [initialConfig] if it is not nil.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, initialConfig *InitialRewardConfig) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:             precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialRewardConfig: initialConfig,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables RewardManager.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the RewardManager precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if err := c.AllowListConfig.Verify(chainConfig, c.Upgrade); err != nil {
+		return err
+	}
+	if c.InitialRewardConfig == nil {
+		return nil
+	}
+	if err := c.InitialRewardConfig.Verify(); err != nil {
+		return fmt.Errorf("invalid initial reward config: %w", err)
+	}
+	return nil
+}
+
+// Equal returns true if [s] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(s precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (s).(*Config)
+	if !ok {
+		return false
+	}
+	if !c.Upgrade.Equal(&other.Upgrade) || !c.AllowListConfig.Equal(&other.AllowListConfig) {
+		return false
+	}
+	if c.InitialRewardConfig == nil {
+		return other.InitialRewardConfig == nil
+	}
+	return c.InitialRewardConfig.Equal(other.InitialRewardConfig)
+}
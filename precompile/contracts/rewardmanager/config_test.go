@@ -0,0 +1,81 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rewardmanager
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+
+	tests := allowlisttest.VerifyTests(t, Module)
+	tests["disable config"] = testutils.ConfigVerifyTest{
+		Config: NewDisableConfig(utils.NewUint64(3)),
+	}
+	tests["valid reward address"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+			RewardAddress: allowlisttest.TestNoRoleAddr,
+		}),
+	}
+	tests["valid allow fee recipients"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+			AllowFeeRecipients: true,
+		}),
+	}
+	tests["both reward address and allow fee recipients"] = testutils.ConfigVerifyTest{
+		Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+			AllowFeeRecipients: true,
+			RewardAddress:      allowlisttest.TestNoRoleAddr,
+		}),
+		ExpectedError: errBothRewardsEnabled.Error(),
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	enableds := []common.Address{allowlisttest.TestEnabledAddr}
+	managers := []common.Address{allowlisttest.TestManagerAddr}
+
+	tests := allowlisttest.EqualTests(Module)
+	tests["non-nil config and nil other"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    nil,
+		Expected: false,
+	}
+	tests["different type"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+		Expected: false,
+	}
+	tests["different timestamp"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    NewConfig(utils.NewUint64(4), admins, enableds, managers, nil),
+		Expected: false,
+	}
+	tests["nil and non-nil initial reward config"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, nil),
+		Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{AllowFeeRecipients: true}),
+		Expected: false,
+	}
+	tests["different initial reward config"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{AllowFeeRecipients: true}),
+		Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{RewardAddress: allowlisttest.TestNoRoleAddr}),
+		Expected: false,
+	}
+	tests["same config"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{RewardAddress: allowlisttest.TestNoRoleAddr}),
+		Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{RewardAddress: allowlisttest.TestNoRoleAddr}),
+		Expected: true,
+	}
+	testutils.RunEqualTests(t, tests)
+}
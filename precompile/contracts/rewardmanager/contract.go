@@ -0,0 +1,291 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rewardmanager
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/accounts/abi"
+	"github.com/ava-labs/coreth/constants"
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/vmerrs"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Gas costs of the functions of the precompile.
+const (
+	AllowFeeRecipientsGasCost      = contract.WriteGasCostPerSlot
+	AreFeeRecipientsAllowedGasCost = contract.ReadGasCostPerSlot
+	CurrentRewardAddressGasCost    = contract.ReadGasCostPerSlot
+	DisableRewardsGasCost          = contract.WriteGasCostPerSlot
+	// SetRewardAddressGasCost covers reading the previous reward address,
+	// emitted with the new one, and storing the new one.
+	SetRewardAddressGasCost = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+)
+
+var (
+	// rewardAddressKey is the storage key of the reward configuration. It
+	// cannot collide with the keys of the allow list, which are left-padded
+	// addresses.
+	rewardAddressKey = common.Hash{'r', 'a', 'k'}
+	// allowFeeRecipientsValue is stored at rewardAddressKey when block
+	// producers choose their fee recipient. It cannot be a left-padded
+	// address.
+	allowFeeRecipientsValue = common.Hash{1}
+)
+
+var (
+	// RewardManagerRawABI contains the raw ABI of the functions and events of
+	// the reward manager, in addition to the ones of its allow list.
+	//go:embed contract.abi
+	RewardManagerRawABI string
+
+	RewardManagerABI = contract.ParseABI(RewardManagerRawABI)
+
+	// RewardManagerPrecompile is the singleton StatefulPrecompiledContract
+	// storing the reward configuration.
+	RewardManagerPrecompile = createRewardManagerPrecompile()
+
+	// ErrCannotChangeRewards is returned when the caller is not enabled in
+	// the allow list of the reward manager.
+	ErrCannotChangeRewards = errors.New("non-enabled cannot change rewards")
+	// ErrInvalidRewardAddress is returned when setting the zero address or
+	// the blackhole address as the reward address. Fees are burned with
+	// disableRewards instead.
+	ErrInvalidRewardAddress = errors.New("invalid reward address")
+)
+
+// GetRewardManagerStatus returns the role of [address] for the reward
+// manager.
+func GetRewardManagerStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetRewardManagerStatus sets the permissions of [address] to [role] for the
+// reward manager.
+// Assumes [role] has already been verified as valid.
+func SetRewardManagerStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// GetStoredRewardAddress returns the address receiving the fees of the
+// blocks, which is the blackhole address if fees are burned, and true if
+// block producers choose their fee recipient instead, in which case the
+// returned address is the zero address.
+func GetStoredRewardAddress(stateDB contract.StateDB) (common.Address, bool) {
+	value := stateDB.GetState(ContractAddress, rewardAddressKey)
+	switch value {
+	case allowFeeRecipientsValue:
+		return common.Address{}, true
+	case common.Hash{}:
+		return constants.BlackholeAddr, false
+	default:
+		return common.BytesToAddress(value.Bytes()), false
+	}
+}
+
+// StoreRewardAddress sets [address] as the address receiving the fees of the
+// blocks. Storing the blackhole address burns the fees.
+func StoreRewardAddress(stateDB contract.StateDB, address common.Address) {
+	stateDB.SetState(ContractAddress, rewardAddressKey, common.BytesToHash(address.Bytes()))
+}
+
+// EnableAllowFeeRecipients lets block producers choose the recipient of the
+// fees of their blocks.
+func EnableAllowFeeRecipients(stateDB contract.StateDB) {
+	stateDB.SetState(ContractAddress, rewardAddressKey, allowFeeRecipientsValue)
+}
+
+// DisableFeeRewards burns the fees of the blocks.
+func DisableFeeRewards(stateDB contract.StateDB) {
+	StoreRewardAddress(stateDB, constants.BlackholeAddr)
+}
+
+// PackAllowFeeRecipients packs the selector of allowFeeRecipients.
+// This function is mostly used for tests.
+func PackAllowFeeRecipients() ([]byte, error) {
+	return RewardManagerABI.Pack("allowFeeRecipients")
+}
+
+// PackAreFeeRecipientsAllowed packs the selector of areFeeRecipientsAllowed.
+// This function is mostly used for tests.
+func PackAreFeeRecipientsAllowed() ([]byte, error) {
+	return RewardManagerABI.Pack("areFeeRecipientsAllowed")
+}
+
+// PackAreFeeRecipientsAllowedOutput packs [isAllowed] into the output of
+// areFeeRecipientsAllowed.
+func PackAreFeeRecipientsAllowedOutput(isAllowed bool) ([]byte, error) {
+	return RewardManagerABI.PackOutput("areFeeRecipientsAllowed", isAllowed)
+}
+
+// PackCurrentRewardAddress packs the selector of currentRewardAddress.
+// This function is mostly used for tests.
+func PackCurrentRewardAddress() ([]byte, error) {
+	return RewardManagerABI.Pack("currentRewardAddress")
+}
+
+// PackCurrentRewardAddressOutput packs [rewardAddress] into the output of
+// currentRewardAddress.
+func PackCurrentRewardAddressOutput(rewardAddress common.Address) ([]byte, error) {
+	return RewardManagerABI.PackOutput("currentRewardAddress", rewardAddress)
+}
+
+// PackDisableRewards packs the selector of disableRewards.
+// This function is mostly used for tests.
+func PackDisableRewards() ([]byte, error) {
+	return RewardManagerABI.Pack("disableRewards")
+}
+
+// PackSetRewardAddress packs [address] into the input of setRewardAddress.
+// This function is mostly used for tests.
+func PackSetRewardAddress(address common.Address) ([]byte, error) {
+	return RewardManagerABI.Pack("setRewardAddress", address)
+}
+
+// UnpackSetRewardAddressInput unpacks [input] into the reward address it
+// sets.
+// Assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetRewardAddressInput(input []byte) (common.Address, error) {
+	// Strict mode is not used since it was disabled with Durango.
+	res, err := RewardManagerABI.UnpackInput("setRewardAddress", input, false)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(res[0], new(common.Address)).(*common.Address), nil
+}
+
+// allowFeeRecipients lets block producers choose the recipient of the fees of
+// their blocks, from the next block on. The caller must be enabled in the
+// allow list.
+func allowFeeRecipients(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, AllowFeeRecipientsGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	if callerStatus := GetRewardManagerStatus(stateDB, caller); !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeRewards, caller)
+	}
+	EnableAllowFeeRecipients(stateDB)
+	if remainingGas, err = contract.EmitEvent(accessibleState, ContractAddress, RewardManagerABI.Events["FeeRecipientsAllowed"], remainingGas, caller); err != nil {
+		return nil, remainingGas, err
+	}
+	return []byte{}, remainingGas, nil
+}
+
+// areFeeRecipientsAllowed returns true if block producers choose the
+// recipient of the fees of their blocks.
+func areFeeRecipientsAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, AreFeeRecipientsAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	_, isAllowed := GetStoredRewardAddress(accessibleState.GetStateDB())
+	output, err := PackAreFeeRecipientsAllowedOutput(isAllowed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// currentRewardAddress returns the address receiving the fees of the blocks,
+// as returned by GetStoredRewardAddress.
+func currentRewardAddress(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, CurrentRewardAddressGasCost); err != nil {
+		return nil, 0, err
+	}
+	rewardAddress, _ := GetStoredRewardAddress(accessibleState.GetStateDB())
+	output, err := PackCurrentRewardAddressOutput(rewardAddress)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// disableRewards burns the fees of the blocks from the next block on. The
+// caller must be enabled in the allow list.
+func disableRewards(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, DisableRewardsGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	if callerStatus := GetRewardManagerStatus(stateDB, caller); !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeRewards, caller)
+	}
+	DisableFeeRewards(stateDB)
+	if remainingGas, err = contract.EmitEvent(accessibleState, ContractAddress, RewardManagerABI.Events["RewardsDisabled"], remainingGas, caller); err != nil {
+		return nil, remainingGas, err
+	}
+	return []byte{}, remainingGas, nil
+}
+
+// setRewardAddress sends the fees of the blocks to the address given in
+// [input], from the next block on. The caller must be enabled in the allow
+// list.
+func setRewardAddress(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetRewardAddressGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	rewardAddress, err := UnpackSetRewardAddressInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("invalid setRewardAddress input: %w", err)
+	}
+	if rewardAddress == (common.Address{}) || rewardAddress == constants.BlackholeAddr {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrInvalidRewardAddress, rewardAddress)
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	if callerStatus := GetRewardManagerStatus(stateDB, caller); !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeRewards, caller)
+	}
+	oldRewardAddress, _ := GetStoredRewardAddress(stateDB)
+	StoreRewardAddress(stateDB, rewardAddress)
+	if remainingGas, err = contract.EmitEvent(accessibleState, ContractAddress, RewardManagerABI.Events["RewardAddressChanged"], remainingGas, caller, oldRewardAddress, rewardAddress); err != nil {
+		return nil, remainingGas, err
+	}
+	return []byte{}, remainingGas, nil
+}
+
+// createRewardManagerPrecompile returns a StatefulPrecompiledContract with the
+// functions of the reward manager and of its allow list.
+func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
+	functions := allowlist.CreateAllowListFunctions(ContractAddress)
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"allowFeeRecipients":      allowFeeRecipients,
+		"areFeeRecipientsAllowed": areFeeRecipientsAllowed,
+		"currentRewardAddress":    currentRewardAddress,
+		"disableRewards":          disableRewards,
+		"setRewardAddress":        setRewardAddress,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := RewardManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
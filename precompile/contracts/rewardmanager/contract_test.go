@@ -0,0 +1,184 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rewardmanager
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/constants"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContract(t *testing.T) {
+	config := allowlisttest.MkConfigWithAllowList(Module, &allowlist.AllowListConfig{
+		AdminAddresses:   []common.Address{allowlisttest.TestAdminAddr},
+		EnabledAddresses: []common.Address{allowlisttest.TestEnabledAddr},
+	})
+	rewardAddress := common.Address{'r', 'e', 'w', 'a', 'r', 'd'}
+	// configWith returns [config] storing [initialConfig] on activation.
+	configWith := func(initialConfig *InitialRewardConfig) *Config {
+		return NewConfig(new(uint64), []common.Address{allowlisttest.TestAdminAddr}, []common.Address{allowlisttest.TestEnabledAddr}, nil, initialConfig)
+	}
+	allowFeeRecipientsInput, err := PackAllowFeeRecipients()
+	require.NoError(t, err)
+	areFeeRecipientsAllowedInput, err := PackAreFeeRecipientsAllowed()
+	require.NoError(t, err)
+	allowedOutput, err := PackAreFeeRecipientsAllowedOutput(true)
+	require.NoError(t, err)
+	currentRewardAddressInput, err := PackCurrentRewardAddress()
+	require.NoError(t, err)
+	rewardAddressOutput, err := PackCurrentRewardAddressOutput(rewardAddress)
+	require.NoError(t, err)
+	blackholeOutput, err := PackCurrentRewardAddressOutput(constants.BlackholeAddr)
+	require.NoError(t, err)
+	disableRewardsInput, err := PackDisableRewards()
+	require.NoError(t, err)
+	setRewardAddressInput, err := PackSetRewardAddress(rewardAddress)
+	require.NoError(t, err)
+	setBlackholeInput, err := PackSetRewardAddress(constants.BlackholeAddr)
+	require.NoError(t, err)
+
+	expectRewardAddress := func(expected common.Address, allowFeeRecipients bool) func(t testing.TB, state contract.StateDB) {
+		return func(t testing.TB, state contract.StateDB) {
+			address, allowed := GetStoredRewardAddress(state)
+			require.Equal(t, expected, address)
+			require.Equal(t, allowFeeRecipients, allowed)
+		}
+	}
+	expectUnchanged := func(t testing.TB, state contract.StateDB) {
+		expectRewardAddress(constants.BlackholeAddr, false)(t, state)
+		testutils.RequireEvents(t, state)
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"admin set reward address": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       setRewardAddressInput,
+			SuppliedGas: SetRewardAddressGasCost + contract.LogGasCost(4, 0),
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				expectRewardAddress(rewardAddress, false)(t, state)
+				testutils.RequireEvents(t, state, testutils.ExpectedEvent{
+					Event: RewardManagerABI.Events["RewardAddressChanged"],
+					Args:  []interface{}{allowlisttest.TestAdminAddr, constants.BlackholeAddr, rewardAddress},
+				})
+			},
+		},
+		"enabled allow fee recipients": {
+			Caller:      allowlisttest.TestEnabledAddr,
+			Input:       allowFeeRecipientsInput,
+			SuppliedGas: AllowFeeRecipientsGasCost + contract.LogGasCost(2, 0),
+			ExpectedRes: []byte{},
+			Config:      config,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				expectRewardAddress(common.Address{}, true)(t, state)
+				testutils.RequireEvents(t, state, testutils.ExpectedEvent{
+					Event: RewardManagerABI.Events["FeeRecipientsAllowed"],
+					Args:  []interface{}{allowlisttest.TestEnabledAddr},
+				})
+			},
+		},
+		"admin disable rewards": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       disableRewardsInput,
+			SuppliedGas: DisableRewardsGasCost + contract.LogGasCost(2, 0),
+			ExpectedRes: []byte{},
+			Config:      configWith(&InitialRewardConfig{AllowFeeRecipients: true}),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				expectRewardAddress(constants.BlackholeAddr, false)(t, state)
+				testutils.RequireEvents(t, state, testutils.ExpectedEvent{
+					Event: RewardManagerABI.Events["RewardsDisabled"],
+					Args:  []interface{}{allowlisttest.TestAdminAddr},
+				})
+			},
+		},
+		"no role set reward address": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       setRewardAddressInput,
+			SuppliedGas: SetRewardAddressGasCost,
+			Config:      config,
+			ExpectedErr: ErrCannotChangeRewards.Error(),
+			AfterHook:   expectUnchanged,
+		},
+		"no role allow fee recipients": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       allowFeeRecipientsInput,
+			SuppliedGas: AllowFeeRecipientsGasCost,
+			Config:      config,
+			ExpectedErr: ErrCannotChangeRewards.Error(),
+			AfterHook:   expectUnchanged,
+		},
+		"no role disable rewards": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       disableRewardsInput,
+			SuppliedGas: DisableRewardsGasCost,
+			Config:      config,
+			ExpectedErr: ErrCannotChangeRewards.Error(),
+		},
+		"set blackhole reward address": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       setBlackholeInput,
+			SuppliedGas: SetRewardAddressGasCost,
+			Config:      config,
+			ExpectedErr: ErrInvalidRewardAddress.Error(),
+			AfterHook:   expectUnchanged,
+		},
+		"set reward address readOnly": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       setRewardAddressInput,
+			SuppliedGas: SetRewardAddressGasCost,
+			ReadOnly:    true,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"set reward address insufficient gas": {
+			Caller:      allowlisttest.TestAdminAddr,
+			Input:       setRewardAddressInput,
+			SuppliedGas: SetRewardAddressGasCost + contract.LogGasCost(4, 0) - 1,
+			Config:      config,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"current reward address": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       currentRewardAddressInput,
+			SuppliedGas: CurrentRewardAddressGasCost,
+			ReadOnly:    true,
+			ExpectedRes: rewardAddressOutput,
+			Config:      configWith(&InitialRewardConfig{RewardAddress: rewardAddress}),
+		},
+		"current reward address burning fees": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       currentRewardAddressInput,
+			SuppliedGas: CurrentRewardAddressGasCost,
+			ReadOnly:    true,
+			ExpectedRes: blackholeOutput,
+			Config:      config,
+		},
+		"are fee recipients allowed": {
+			Caller:      allowlisttest.TestNoRoleAddr,
+			Input:       areFeeRecipientsAllowedInput,
+			SuppliedGas: AreFeeRecipientsAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: allowedOutput,
+			Config:      configWith(&InitialRewardConfig{AllowFeeRecipients: true}),
+		},
+		"initial reward address": {
+			Config:    configWith(&InitialRewardConfig{RewardAddress: rewardAddress}),
+			AfterHook: expectRewardAddress(rewardAddress, false),
+		},
+		"initial allow fee recipients": {
+			Config:    configWith(&InitialRewardConfig{AllowFeeRecipients: true}),
+			AfterHook: expectRewardAddress(common.Address{}, true),
+		},
+	}
+	allowlisttest.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
@@ -0,0 +1,51 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txallowlist
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/precompile/allowlist/allowlisttest"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	tests := allowlisttest.VerifyTests(t, Module)
+	tests["disable config"] = testutils.ConfigVerifyTest{
+		Config: NewDisableConfig(utils.NewUint64(3)),
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlisttest.TestAdminAddr}
+	enableds := []common.Address{allowlisttest.TestEnabledAddr}
+	managers := []common.Address{allowlisttest.TestManagerAddr}
+	tests := allowlisttest.EqualTests(Module)
+	tests["non-nil config and nil other"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers),
+		Other:    nil,
+		Expected: false,
+	}
+	tests["different type"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers),
+		Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+		Expected: false,
+	}
+	tests["different timestamp"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers),
+		Other:    NewConfig(utils.NewUint64(4), admins, enableds, managers),
+		Expected: false,
+	}
+	tests["same config"] = testutils.ConfigEqualTest{
+		Config:   NewConfig(utils.NewUint64(3), admins, enableds, managers),
+		Other:    NewConfig(utils.NewUint64(3), admins, enableds, managers),
+		Expected: true,
+	}
+	testutils.RunEqualTests(t, tests)
+}
@@ -0,0 +1,26 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txallowlist
+
+import (
+	"github.com/ava-labs/coreth/precompile/allowlist"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxAllowListPrecompile is the singleton StatefulPrecompiledContract managing
+// the addresses allowed to submit transactions.
+var TxAllowListPrecompile = allowlist.CreateAllowListPrecompile(ContractAddress)
+
+// GetTxAllowListStatus returns the role of [address] for the transaction allow list.
+func GetTxAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+}
+
+// SetTxAllowListStatus sets the permissions of [address] to [role] for the
+// transaction allow list.
+// Assumes [role] has already been verified as valid.
+func SetTxAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
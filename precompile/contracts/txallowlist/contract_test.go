@@ -0,0 +1,15 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txallowlist
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/precompile/allowlist/allowlisttest"
+)
+
+func TestContract(t *testing.T) {
+	allowlisttest.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, nil)
+}
@@ -24,10 +24,12 @@ var ContractAddress = common.HexToAddress("0x02000000000000000000000000000000000
 
 // Module is the precompile module. It is used to register the precompile contract.
 var Module = modules.Module{
-	ConfigKey:    ConfigKey,
-	Address:      ContractAddress,
-	Contract:     WarpPrecompile,
-	Configurator: &configurator{},
+	ConfigKey:        ConfigKey,
+	Address:          ContractAddress,
+	Contract:         WarpPrecompile,
+	ABI:              WarpABI,
+	EnabledByDefault: true, // activated with Durango on every chain
+	Configurator:     &configurator{},
 }
 
 type configurator struct{}
@@ -6,6 +6,7 @@ package modules
 import (
 	"bytes"
 
+	"github.com/ava-labs/coreth/accounts/abi"
 	"github.com/ava-labs/coreth/precompile/contract"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -18,6 +19,12 @@ type Module struct {
 	// Contract returns a thread-safe singleton that can be used as the StatefulPrecompiledContract when
 	// this config is enabled.
 	Contract contract.StatefulPrecompiledContract
+	// ABI describes the functions and events of Contract. It is optional and only
+	// used to decode calls to the precompile for tracers.
+	ABI abi.ABI
+	// EnabledByDefault is true if the module is enabled whatever the modules
+	// selected with EnableModules, as it is activated by the network upgrades.
+	EnabledByDefault bool
 	// Configurator is used to configure the stateful precompile when the config is enabled.
 	contract.Configurator
 }
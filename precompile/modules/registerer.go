@@ -4,8 +4,10 @@
 package modules
 
 import (
+	"errors"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/ava-labs/coreth/constants"
 	"github.com/ava-labs/coreth/utils"
@@ -17,6 +19,20 @@ var (
 	// for deterministic iteration
 	registeredModules = make([]Module, 0)
 
+	// enabledModules is the sorted list of the registered modules enabled on
+	// this node by EnableModules. If nil, every registered module is enabled.
+	// It is guarded by enabledModulesLock, as the VM replaces it while the
+	// precompiles may be looked up by other goroutines.
+	enabledModules     []Module
+	enabledModulesLock sync.RWMutex
+
+	// ErrUnknownModule is returned when enabling a module that is not
+	// registered.
+	ErrUnknownModule = errors.New("unknown precompile module")
+	// ErrModuleAddressMismatch is returned when enabling a module at another
+	// address than the one it is registered at.
+	ErrModuleAddressMismatch = errors.New("precompile module address mismatch")
+
 	reservedRanges = []utils.AddressRange{
 		{
 			Start: common.HexToAddress("0x0100000000000000000000000000000000000000"),
@@ -69,8 +85,93 @@ func RegisterModule(stm Module) error {
 	return nil
 }
 
+// ModuleSelection selects a registered module to enable on the node.
+// The address of the module is given along with its config key, so that every
+// node enabling the module agrees on where it is deployed: a node whose build
+// registers the module at another address refuses to enable it.
+type ModuleSelection struct {
+	ConfigKey string         `json:"configKey"`
+	Address   common.Address `json:"address"`
+}
+
+// EnableModules restricts the modules usable on this node to the ones enabled
+// by default and the ones in [selections]. The other registered modules are
+// compiled in, but behave as if they were not registered: their configs are
+// rejected and they are never activated.
+// It replaces the selection of any previous call, which is restored by the
+// returned function.
+func EnableModules(selections []ModuleSelection) (func(), error) {
+	selected := make(map[string]struct{}, len(selections))
+	for _, selection := range selections {
+		module, ok := getRegisteredModule(selection.ConfigKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownModule, selection.ConfigKey)
+		}
+		if module.Address != selection.Address {
+			return nil, fmt.Errorf("%w: %s is registered at %s, not %s", ErrModuleAddressMismatch, selection.ConfigKey, module.Address, selection.Address)
+		}
+		if _, ok := selected[selection.ConfigKey]; ok {
+			return nil, fmt.Errorf("precompile module %s selected more than once", selection.ConfigKey)
+		}
+		selected[selection.ConfigKey] = struct{}{}
+	}
+
+	enabled := make([]Module, 0, len(registeredModules))
+	for _, module := range registeredModules {
+		if _, ok := selected[module.ConfigKey]; ok || module.EnabledByDefault {
+			enabled = append(enabled, module)
+		}
+	}
+	enabledModulesLock.Lock()
+	previous := enabledModules
+	enabledModules = enabled
+	enabledModulesLock.Unlock()
+
+	restore := func() {
+		enabledModulesLock.Lock()
+		enabledModules = previous
+		enabledModulesLock.Unlock()
+	}
+	return restore, nil
+}
+
+// EnableAllModules enables every registered module, which is the behavior
+// before EnableModules is called.
+func EnableAllModules() {
+	enabledModulesLock.Lock()
+	enabledModules = nil
+	enabledModulesLock.Unlock()
+}
+
+// EqualSelections returns true iff [a] and [b] select the same modules at the
+// same addresses, in any order.
+func EqualSelections(a, b []ModuleSelection) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[ModuleSelection]int, len(a))
+	for _, selection := range a {
+		counts[selection]++
+	}
+	for _, selection := range b {
+		if counts[selection] == 0 {
+			return false
+		}
+		counts[selection]--
+	}
+	return true
+}
+
+// IsRegisteredModule returns true if a module is registered with [key], even
+// if it is not enabled.
+func IsRegisteredModule(key string) bool {
+	_, ok := getRegisteredModule(key)
+	return ok
+}
+
+// GetPrecompileModuleByAddress returns the enabled module at [address].
 func GetPrecompileModuleByAddress(address common.Address) (Module, bool) {
-	for _, stm := range registeredModules {
+	for _, stm := range RegisteredModules() {
 		if stm.Address == address {
 			return stm, true
 		}
@@ -78,8 +179,9 @@ func GetPrecompileModuleByAddress(address common.Address) (Module, bool) {
 	return Module{}, false
 }
 
+// GetPrecompileModule returns the enabled module with the config key [key].
 func GetPrecompileModule(key string) (Module, bool) {
-	for _, stm := range registeredModules {
+	for _, stm := range RegisteredModules() {
 		if stm.ConfigKey == key {
 			return stm, true
 		}
@@ -87,10 +189,27 @@ func GetPrecompileModule(key string) (Module, bool) {
 	return Module{}, false
 }
 
+// RegisteredModules returns the registered modules enabled on this node,
+// sorted by address.
 func RegisteredModules() []Module {
+	enabledModulesLock.RLock()
+	defer enabledModulesLock.RUnlock()
+
+	if enabledModules != nil {
+		return enabledModules
+	}
 	return registeredModules
 }
 
+func getRegisteredModule(key string) (Module, bool) {
+	for _, stm := range registeredModules {
+		if stm.ConfigKey == key {
+			return stm, true
+		}
+	}
+	return Module{}, false
+}
+
 func insertSortedByAddress(data []Module, stm Module) []Module {
 	data = append(data, stm)
 	sort.Sort(moduleArray(data))
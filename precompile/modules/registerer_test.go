@@ -57,3 +57,69 @@ func TestRegisterModuleInvalidAddresses(t *testing.T) {
 	err = RegisterModule(m)
 	require.ErrorContains(t, err, "not in a reserved range")
 }
+
+func TestEnableModules(t *testing.T) {
+	t.Cleanup(EnableAllModules)
+
+	var (
+		optional = Module{
+			ConfigKey: "optionalConfig",
+			Address:   common.HexToAddress("0x03000000000000000000000000000000000000f0"),
+		}
+		byDefault = Module{
+			ConfigKey:        "byDefaultConfig",
+			Address:          common.HexToAddress("0x03000000000000000000000000000000000000f1"),
+			EnabledByDefault: true,
+		}
+	)
+	require.NoError(t, RegisterModule(optional))
+	require.NoError(t, RegisterModule(byDefault))
+
+	// Every registered module is enabled until a selection is made.
+	_, ok := GetPrecompileModule(optional.ConfigKey)
+	require.True(t, ok)
+
+	restoreAll, err := EnableModules(nil)
+	require.NoError(t, err)
+	_, ok = GetPrecompileModule(optional.ConfigKey)
+	require.False(t, ok)
+	_, ok = GetPrecompileModuleByAddress(optional.Address)
+	require.False(t, ok)
+	require.True(t, IsRegisteredModule(optional.ConfigKey))
+	require.Equal(t, []Module{byDefault}, RegisteredModules())
+
+	restoreDefault, err := EnableModules([]ModuleSelection{{ConfigKey: optional.ConfigKey, Address: optional.Address}})
+	require.NoError(t, err)
+	require.Equal(t, []Module{optional, byDefault}, RegisteredModules())
+
+	_, err = EnableModules([]ModuleSelection{{ConfigKey: optional.ConfigKey, Address: byDefault.Address}})
+	require.ErrorIs(t, err, ErrModuleAddressMismatch)
+	_, err = EnableModules([]ModuleSelection{{ConfigKey: "unknownConfig", Address: optional.Address}})
+	require.ErrorIs(t, err, ErrUnknownModule)
+	_, err = EnableModules([]ModuleSelection{
+		{ConfigKey: optional.ConfigKey, Address: optional.Address},
+		{ConfigKey: optional.ConfigKey, Address: optional.Address},
+	})
+	require.ErrorContains(t, err, "selected more than once")
+	// A failed selection keeps the previous one.
+	require.Equal(t, []Module{optional, byDefault}, RegisteredModules())
+
+	// Restoring a selection enables the modules in place before it.
+	restoreDefault()
+	require.Equal(t, []Module{byDefault}, RegisteredModules())
+	restoreAll()
+	_, ok = GetPrecompileModule(optional.ConfigKey)
+	require.True(t, ok)
+}
+
+func TestEqualSelections(t *testing.T) {
+	var (
+		a = ModuleSelection{ConfigKey: "aConfig", Address: common.Address{1}}
+		b = ModuleSelection{ConfigKey: "bConfig", Address: common.Address{2}}
+	)
+	require.True(t, EqualSelections(nil, []ModuleSelection{}))
+	require.True(t, EqualSelections([]ModuleSelection{a, b}, []ModuleSelection{b, a}))
+	require.False(t, EqualSelections([]ModuleSelection{a}, []ModuleSelection{a, b}))
+	require.False(t, EqualSelections([]ModuleSelection{a, a}, []ModuleSelection{a, b}))
+	require.False(t, EqualSelections([]ModuleSelection{a}, []ModuleSelection{{ConfigKey: a.ConfigKey, Address: b.Address}}))
+}
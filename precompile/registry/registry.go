@@ -5,7 +5,13 @@
 package registry
 
 // Force imports of each precompile to ensure each precompile's init function runs and registers itself
-// with the registry.
+// with the registry. Registered precompiles are compiled in, but a node only enables the ones selected
+// with modules.EnableModules, in addition to the ones enabled by default.
 import (
+	_ "github.com/ava-labs/coreth/precompile/contracts/deployerallowlist"
+	_ "github.com/ava-labs/coreth/precompile/contracts/feemanager"
+	_ "github.com/ava-labs/coreth/precompile/contracts/nativeminter"
+	_ "github.com/ava-labs/coreth/precompile/contracts/rewardmanager"
+	_ "github.com/ava-labs/coreth/precompile/contracts/txallowlist"
 	_ "github.com/ava-labs/coreth/precompile/contracts/warp"
 )
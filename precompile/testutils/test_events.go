@@ -0,0 +1,34 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package testutils
+
+import (
+	"testing"
+
+	"github.com/ava-labs/coreth/accounts/abi"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/stretchr/testify/require"
+)
+
+// ExpectedEvent is a log of Event with Args expected to be emitted by a
+// precompile. Args are given in the order of the event definition.
+type ExpectedEvent struct {
+	Event abi.Event
+	Args  []interface{}
+}
+
+// RequireEvents requires the logs added to [state] to be the logs of
+// [expected], in order.
+func RequireEvents(t testing.TB, state contract.StateDB, expected ...ExpectedEvent) {
+	t.Helper()
+
+	topics, data := state.GetLogData()
+	require.Len(t, topics, len(expected), "unexpected number of logs")
+	for i, event := range expected {
+		expectedTopics, expectedData, err := contract.PackEvent(event.Event, event.Args...)
+		require.NoError(t, err)
+		require.Equal(t, expectedTopics, topics[i], "unexpected topics of log %d (%s)", i, event.Event.Name)
+		require.Equal(t, expectedData, data[i], "unexpected data of log %d (%s)", i, event.Event.Name)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/types"
@@ -45,6 +46,7 @@ var (
 	}
 	errEmptyResponse          = errors.New("empty response")
 	errTooManyBlocks          = errors.New("response contains more blocks than requested")
+	errTooManyReceipts        = errors.New("response contains receipts of more blocks than requested")
 	errHashMismatch           = errors.New("hash does not match expected value")
 	errInvalidRangeProof      = errors.New("failed to verify range proof")
 	errTooManyLeaves          = errors.New("response contains more than requested leaves")
@@ -65,6 +67,11 @@ type Client interface {
 	// specified range from height to height-parents is inclusive
 	GetBlocks(ctx context.Context, blockHash common.Hash, height uint64, parents uint16) ([]*types.Block, error)
 
+	// GetReceipts synchronously retrieves the receipts of [blocks], which must be contiguous
+	// and in descending order of height. The response may contain the receipts of a prefix of [blocks].
+	// Note: this verifies the receipts against the receipts root of each block.
+	GetReceipts(ctx context.Context, blocks []*types.Block) ([]types.Receipts, error)
+
 	// GetCode synchronously retrieves code associated with the given hashes
 	GetCode(ctx context.Context, hashes []common.Hash) ([][]byte, error)
 }
@@ -240,6 +247,59 @@ func (c *client) parseBlocks(codec codec.Manager, req message.Request, data []by
 	return blocks, len(blocks), nil
 }
 
+func (c *client) GetReceipts(ctx context.Context, blocks []*types.Block) ([]types.Receipts, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	req := message.ReceiptsRequest{
+		Hash:    blocks[0].Hash(),
+		Height:  blocks[0].NumberU64(),
+		Parents: uint16(len(blocks)),
+	}
+
+	data, err := c.get(ctx, req, receiptsParser(blocks))
+	if err != nil {
+		return nil, fmt.Errorf("could not get receipts (%s) due to %w", req.Hash, err)
+	}
+
+	return data.([]types.Receipts), nil
+}
+
+// receiptsParser returns a parseResponseFn validating given object as message.ReceiptsResponse
+// for the receipts of [blocks]
+// assumes req is of type message.ReceiptsRequest
+// returns []types.Receipts as interface{}
+// returns a non-nil error if the request should be retried
+func receiptsParser(blocks []*types.Block) parseResponseFn {
+	return func(codec codec.Manager, _ message.Request, data []byte) (interface{}, int, error) {
+		var response message.ReceiptsResponse
+		if _, err := codec.Unmarshal(data, &response); err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", errUnmarshalResponse, err)
+		}
+		if len(response.Receipts) == 0 {
+			return nil, 0, errEmptyResponse
+		}
+		if len(response.Receipts) > len(blocks) {
+			return nil, 0, errTooManyReceipts
+		}
+
+		receipts := make([]types.Receipts, len(response.Receipts))
+		numReceipts := 0
+		for i, receiptsBytes := range response.Receipts {
+			if err := rlp.DecodeBytes(receiptsBytes, &receipts[i]); err != nil {
+				return nil, 0, fmt.Errorf("%s: %w", errUnmarshalResponse, err)
+			}
+			block := blocks[i]
+			if hash := types.DeriveSha(receipts[i], trie.NewStackTrie(nil)); hash != block.ReceiptHash() {
+				return nil, 0, fmt.Errorf("%w for receipts of block %s: (got %v) (expected %v)", errHashMismatch, block.Hash(), hash, block.ReceiptHash())
+			}
+			numReceipts += len(receipts[i])
+		}
+
+		return receipts, numReceipts, nil
+	}
+}
+
 func (c *client) GetCode(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
 	req := message.NewCodeRequest(hashes)
 
@@ -31,6 +31,9 @@ type MockClient struct {
 	codeReceived   int32
 	blocksHandler  *handlers.BlockRequestHandler
 	blocksReceived int32
+	// receiptsHandler serves GetReceipts requests if set
+	receiptsHandler  *handlers.ReceiptsRequestHandler
+	receiptsReceived int32
 	// GetLeafsIntercept is called on every GetLeafs request if set to a non-nil callback.
 	// The returned response will be returned by MockClient to the caller.
 	GetLeafsIntercept func(req message.LeafsRequest, res message.LeafsResponse) (message.LeafsResponse, error)
@@ -40,6 +43,9 @@ type MockClient struct {
 	// GetBlocksIntercept is called on every GetBlocks request if set to a non-nil callback.
 	// The returned response will be returned by MockClient to the caller.
 	GetBlocksIntercept func(blockReq message.BlockRequest, blocks types.Blocks) (types.Blocks, error)
+	// GetReceiptsIntercept is called on every GetReceipts request if set to a non-nil callback.
+	// The returned response will be returned by MockClient to the caller.
+	GetReceiptsIntercept func(receiptsReq message.ReceiptsRequest, receipts []types.Receipts) ([]types.Receipts, error)
 }
 
 func NewMockClient(
@@ -138,6 +144,42 @@ func (ml *MockClient) BlocksReceived() int32 {
 	return atomic.LoadInt32(&ml.blocksReceived)
 }
 
+// SetReceiptsHandler sets the handler serving GetReceipts requests.
+func (ml *MockClient) SetReceiptsHandler(receiptsHandler *handlers.ReceiptsRequestHandler) {
+	ml.receiptsHandler = receiptsHandler
+}
+
+func (ml *MockClient) GetReceipts(ctx context.Context, blocks []*types.Block) ([]types.Receipts, error) {
+	if ml.receiptsHandler == nil {
+		panic("no receipts handler for mock client")
+	}
+	request := message.ReceiptsRequest{
+		Hash:    blocks[0].Hash(),
+		Height:  blocks[0].NumberU64(),
+		Parents: uint16(len(blocks)),
+	}
+	response, err := ml.receiptsHandler.OnReceiptsRequest(ctx, ids.GenerateTestNodeID(), 1, request)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptsRes, _, err := receiptsParser(blocks)(ml.codec, request, response)
+	if err != nil {
+		return nil, err
+	}
+	receipts := receiptsRes.([]types.Receipts)
+	if ml.GetReceiptsIntercept != nil {
+		receipts, err = ml.GetReceiptsIntercept(request, receipts)
+	}
+	atomic.AddInt32(&ml.receiptsReceived, int32(len(receipts)))
+	return receipts, err
+}
+
+// ReceiptsReceived returns the number of blocks whose receipts were received.
+func (ml *MockClient) ReceiptsReceived() int32 {
+	return atomic.LoadInt32(&ml.receiptsReceived)
+}
+
 type testBlockParser struct{}
 
 func (t *testBlockParser) ParseEthBlock(b []byte) (*types.Block, error) {
@@ -78,7 +78,8 @@ type clientSyncerStats struct {
 	atomicTrieLeavesMetric,
 	stateTrieLeavesMetric,
 	codeRequestMetric,
-	blockRequestMetric MessageMetric
+	blockRequestMetric,
+	receiptsRequestMetric MessageMetric
 }
 
 // NewClientSyncerStats returns stats for the client syncer
@@ -88,6 +89,7 @@ func NewClientSyncerStats() ClientSyncerStats {
 		stateTrieLeavesMetric:  NewMessageMetric("sync_state_trie_leaves"),
 		codeRequestMetric:      NewMessageMetric("sync_code"),
 		blockRequestMetric:     NewMessageMetric("sync_blocks"),
+		receiptsRequestMetric:  NewMessageMetric("sync_receipts"),
 	}
 }
 
@@ -96,6 +98,8 @@ func (c *clientSyncerStats) GetMetric(msgIntf message.Request) (MessageMetric, e
 	switch msg := msgIntf.(type) {
 	case message.BlockRequest:
 		return c.blockRequestMetric, nil
+	case message.ReceiptsRequest:
+		return c.receiptsRequestMetric, nil
 	case message.CodeRequest:
 		return c.codeRequestMetric, nil
 	case message.LeafsRequest:
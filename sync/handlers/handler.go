@@ -7,17 +7,34 @@ import (
 	"github.com/ava-labs/coreth/core/state/snapshot"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 )
 
 type BlockProvider interface {
 	GetBlock(common.Hash, uint64) *types.Block
 }
 
+// ReceiptProvider provides the receipts of blocks, walking their parents
+// through the embedded [BlockProvider].
+type ReceiptProvider interface {
+	BlockProvider
+	GetReceiptsByHash(common.Hash) types.Receipts
+}
+
 type SnapshotProvider interface {
 	Snapshots() *snapshot.Tree
 }
 
+// LeafsIndex provides sorted key-value access to the leafs of a trie, which
+// is used in place of a snapshot to serve leafs of tries without one.
+type LeafsIndex interface {
+	// LeafsIterator returns an iterator over the leafs of the trie with
+	// [root] in key order, starting at [start]. Returns false if [root] is
+	// not indexed.
+	LeafsIterator(root common.Hash, start []byte) (ethdb.Iterator, bool)
+}
+
 type SyncDataProvider interface {
-	BlockProvider
+	ReceiptProvider
 	SnapshotProvider
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/state/snapshot"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/plugin/evm/message"
@@ -45,13 +46,23 @@ const (
 type LeafsRequestHandler struct {
 	trieDB           *triedb.Database
 	snapshotProvider SnapshotProvider
+	leafsIndex       LeafsIndex
 	codec            codec.Manager
 	stats            stats.LeafsRequestHandlerStats
 	pool             sync.Pool
+
+	// stateRoots is non-nil when [trieDB] is path-based, in which case
+	// leafs can be served for any state root retained by the database.
+	stateRoots *stateRootResolver
 }
 
+// NewLeafsRequestHandler returns a handler serving leafs from [trieDB].
+// If [trieDB] uses the path-based scheme, requests are served for every
+// state root within its retained history rather than only for roots whose
+// tries are persisted on disk. In this case [trieDB] must be the database
+// used by the chain, since the retained layers are only kept in memory.
 func NewLeafsRequestHandler(trieDB *triedb.Database, snapshotProvider SnapshotProvider, codec codec.Manager, syncerStats stats.LeafsRequestHandlerStats) *LeafsRequestHandler {
-	return &LeafsRequestHandler{
+	lrh := &LeafsRequestHandler{
 		trieDB:           trieDB,
 		snapshotProvider: snapshotProvider,
 		codec:            codec,
@@ -60,6 +71,20 @@ func NewLeafsRequestHandler(trieDB *triedb.Database, snapshotProvider SnapshotPr
 			New: func() interface{} { return make([][]byte, 0, maxLeavesLimit) },
 		},
 	}
+	if trieDB.Scheme() == rawdb.PathScheme {
+		lrh.stateRoots = newStateRootResolver(trieDB)
+	}
+	return lrh
+}
+
+// NewIndexedLeafsRequestHandler returns a handler serving leafs from
+// [trieDB], reading them optimistically from [leafsIndex] when the requested
+// root is indexed. Leafs read from the index are validated with range proofs
+// in the same way as leafs read from the snapshot.
+func NewIndexedLeafsRequestHandler(trieDB *triedb.Database, leafsIndex LeafsIndex, codec codec.Manager, syncerStats stats.LeafsRequestHandlerStats) *LeafsRequestHandler {
+	lrh := NewLeafsRequestHandler(trieDB, nil, codec, syncerStats)
+	lrh.leafsIndex = leafsIndex
+	return lrh
 }
 
 // OnLeafsRequest returns encoded message.LeafsResponse for a given message.LeafsRequest
@@ -99,11 +124,7 @@ func (lrh *LeafsRequestHandler) OnLeafsRequest(ctx context.Context, nodeID ids.N
 		return nil, nil
 	}
 
-	// TODO: We should know the state root that accounts correspond to,
-	// as this information will be necessary to access storage tries when
-	// the trie is path based.
-	// stateRoot := common.Hash{}
-	t, err := trie.New(trie.TrieID(leafsRequest.Root), lrh.trieDB)
+	t, err := lrh.openTrie(leafsRequest)
 	if err != nil {
 		log.Debug("error opening trie when processing request, dropping request", "nodeID", nodeID, "requestID", requestID, "root", leafsRequest.Root, "err", err)
 		lrh.stats.IncMissingRoot()
@@ -142,6 +163,13 @@ func (lrh *LeafsRequestHandler) OnLeafsRequest(ctx context.Context, nodeID ids.N
 	if lrh.snapshotProvider != nil {
 		responseBuilder.snap = lrh.snapshotProvider.Snapshots()
 	}
+	// use the leafs index instead if the requested root is indexed
+	if lrh.leafsIndex != nil {
+		if it, ok := lrh.leafsIndex.LeafsIterator(leafsRequest.Root, leafsRequest.Start); ok {
+			responseBuilder.indexIt = it
+			defer it.Release()
+		}
+	}
 	err = responseBuilder.handleRequest(ctx)
 
 	// ensure metrics are captured properly on all return paths
@@ -171,11 +199,30 @@ func (lrh *LeafsRequestHandler) OnLeafsRequest(ctx context.Context, nodeID ids.N
 	return responseBytes, nil
 }
 
+// openTrie opens the trie requested by [leafsRequest]. For path-based
+// databases, storage tries are opened through a retained state root in
+// which the requested account has the requested storage root.
+func (lrh *LeafsRequestHandler) openTrie(leafsRequest message.LeafsRequest) (*trie.Trie, error) {
+	if lrh.stateRoots == nil {
+		return trie.New(trie.TrieID(leafsRequest.Root), lrh.trieDB)
+	}
+	if leafsRequest.Account == (common.Hash{}) {
+		lrh.stateRoots.addRecent(leafsRequest.Root)
+		return trie.New(trie.StateTrieID(leafsRequest.Root), lrh.trieDB)
+	}
+	stateRoot, err := lrh.stateRoots.resolve(leafsRequest.Account, leafsRequest.Root)
+	if err != nil {
+		return nil, err
+	}
+	return trie.New(trie.StorageTrieID(stateRoot, leafsRequest.Account, leafsRequest.Root), lrh.trieDB)
+}
+
 type responseBuilder struct {
 	request   *message.LeafsRequest
 	response  *message.LeafsResponse
 	t         *trie.Trie
 	snap      *snapshot.Tree
+	indexIt   ethdb.Iterator // iterator over the leafs index, used instead of [snap] if non-nil
 	keyLength int
 	limit     uint16
 
@@ -186,8 +233,8 @@ type responseBuilder struct {
 }
 
 func (rb *responseBuilder) handleRequest(ctx context.Context) error {
-	// Read from snapshot if a [snapshot.Tree] was provided in initialization
-	if rb.snap != nil {
+	// Read from snapshot if a [snapshot.Tree] or leafs index was provided in initialization
+	if rb.snap != nil || rb.indexIt != nil {
 		if done, err := rb.fillFromSnapshot(ctx); err != nil {
 			return err
 		} else if done {
@@ -468,8 +515,9 @@ func getKeyLength(nodeType message.NodeType) (int, error) {
 }
 
 // readLeafsFromSnapshot iterates the storage snapshot of the requested account
-// (or the main account trie if account is empty). Returns up to [rb.limit] key/value
-// pairs for keys that are in the request's range (inclusive).
+// (or the main account trie if account is empty), or the leafs index if one is
+// available for the requested root. Returns up to [rb.limit] key/value pairs
+// for keys that are in the request's range (inclusive).
 func (rb *responseBuilder) readLeafsFromSnapshot(ctx context.Context) ([][]byte, [][]byte, error) {
 	var (
 		snapIt    ethdb.Iterator
@@ -478,13 +526,18 @@ func (rb *responseBuilder) readLeafsFromSnapshot(ctx context.Context) ([][]byte,
 		vals      = make([][]byte, 0, rb.limit)
 	)
 
-	// Get an iterator into the storage or the main account snapshot.
-	if rb.request.Account == (common.Hash{}) {
+	// Get an iterator into the leafs index, or the storage or the main account snapshot.
+	switch {
+	case rb.indexIt != nil:
+		// released by the handler
+		snapIt = rb.indexIt
+	case rb.request.Account == (common.Hash{}):
 		snapIt = &syncutils.AccountIterator{AccountIterator: rb.snap.DiskAccountIterator(startHash)}
-	} else {
+		defer snapIt.Release()
+	default:
 		snapIt = &syncutils.StorageIterator{StorageIterator: rb.snap.DiskStorageIterator(rb.request.Account, startHash)}
+		defer snapIt.Release()
 	}
-	defer snapIt.Release()
 	for snapIt.Next() {
 		// if we're at the end, break this loop
 		if len(rb.request.End) > 0 && bytes.Compare(snapIt.Key(), rb.request.End) > 0 {
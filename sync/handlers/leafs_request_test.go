@@ -6,11 +6,13 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"math/big"
 	"math/rand"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
 	"github.com/ava-labs/coreth/core/state/snapshot"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/plugin/evm/message"
@@ -18,6 +20,7 @@ import (
 	"github.com/ava-labs/coreth/sync/syncutils"
 	"github.com/ava-labs/coreth/trie"
 	"github.com/ava-labs/coreth/triedb"
+	"github.com/ava-labs/coreth/triedb/pathdb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -723,3 +726,66 @@ func assertRangeProofIsValid(t *testing.T, request *message.LeafsRequest, respon
 	assert.NoError(t, err)
 	assert.Equal(t, expectMore, more)
 }
+
+func TestLeafsRequestHandler_PathSchemeHistoricalRoots(t *testing.T) {
+	memdb := rawdb.NewMemoryDatabase()
+	trieDB := triedb.NewDatabase(memdb, &triedb.Config{PathDB: pathdb.Defaults})
+	stateDB := state.NewDatabaseWithNodeDB(memdb, trieDB)
+
+	var (
+		addr      = common.Address{0x01}
+		addrHash  = crypto.Keccak256Hash(addr[:])
+		root      = types.EmptyRootHash
+		roots     []common.Hash
+		storages  []common.Hash
+		numSlots  = 100
+		numBlocks = 3
+	)
+	for i := 0; i < numBlocks; i++ {
+		statedb, err := state.New(root, stateDB, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statedb.SetNonce(addr, uint64(i+1))
+		for j := 0; j < numSlots; j++ {
+			statedb.SetState(addr, common.BigToHash(big.NewInt(int64(j))), common.BigToHash(big.NewInt(int64(i*numSlots+j+1))))
+		}
+		root, err = statedb.Commit(uint64(i+1), true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, root)
+		storages = append(storages, statedb.GetStorageRoot(addr))
+	}
+
+	mockHandlerStats := &stats.MockHandlerStats{}
+	leafsHandler := NewLeafsRequestHandler(trieDB, nil, message.Codec, mockHandlerStats)
+	for i := range roots {
+		for _, request := range []message.LeafsRequest{
+			{Root: roots[i], Limit: maxLeavesLimit, NodeType: message.StateTrieNode},
+			{Root: storages[i], Account: addrHash, Limit: maxLeavesLimit, NodeType: message.StateTrieNode},
+		} {
+			response, err := leafsHandler.OnLeafsRequest(context.Background(), ids.GenerateTestNodeID(), 1, request)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, response)
+
+			var leafsResponse message.LeafsResponse
+			_, err = message.Codec.Unmarshal(response, &leafsResponse)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, leafsResponse.Keys)
+			assertRangeProofIsValid(t, &request, &leafsResponse, false)
+		}
+	}
+	assert.Zero(t, mockHandlerStats.MissingRootCount)
+
+	// a storage root that is not part of any retained state is not served
+	response, err := leafsHandler.OnLeafsRequest(context.Background(), ids.GenerateTestNodeID(), 1, message.LeafsRequest{
+		Root:     common.Hash{0xff},
+		Account:  addrHash,
+		Limit:    maxLeavesLimit,
+		NodeType: message.StateTrieNode,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	assert.EqualValues(t, 1, mockHandlerStats.MissingRootCount)
+}
@@ -0,0 +1,115 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	"github.com/ava-labs/coreth/sync/handlers/stats"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReceiptsRequestHandler is a peer.RequestHandler for message.ReceiptsRequest
+// serving the receipts of the requested blocks starting at specified hash
+type ReceiptsRequestHandler struct {
+	stats           stats.ReceiptsRequestHandlerStats
+	receiptProvider ReceiptProvider
+	codec           codec.Manager
+}
+
+func NewReceiptsRequestHandler(receiptProvider ReceiptProvider, codec codec.Manager, handlerStats stats.ReceiptsRequestHandlerStats) *ReceiptsRequestHandler {
+	return &ReceiptsRequestHandler{
+		receiptProvider: receiptProvider,
+		codec:           codec,
+		stats:           handlerStats,
+	}
+}
+
+// OnReceiptsRequest handles incoming message.ReceiptsRequest, returning the
+// receipts of the requested blocks in consensus encoding
+// Never returns error
+// Returns empty response or subset of requested receipts if ctx expires during fetch
+// Assumes ctx is active
+func (r *ReceiptsRequestHandler) OnReceiptsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, receiptsRequest message.ReceiptsRequest) ([]byte, error) {
+	startTime := time.Now()
+	r.stats.IncReceiptsRequest()
+
+	// override given Parents limit if it is greater than parentLimit
+	parents := receiptsRequest.Parents
+	if parents > parentLimit {
+		parents = parentLimit
+	}
+	receipts := make([][]byte, 0, parents)
+	totalBytes := 0
+
+	// ensure metrics are captured properly on all return paths
+	defer func() {
+		r.stats.UpdateReceiptsRequestProcessingTime(time.Since(startTime))
+		r.stats.UpdateReceiptsReturned(uint16(len(receipts)))
+	}()
+
+	hash := receiptsRequest.Hash
+	height := receiptsRequest.Height
+	for i := 0; i < int(parents); i++ {
+		// we return whatever we have until ctx errors, limit is exceeded, or we reach the genesis block
+		if ctx.Err() != nil {
+			break
+		}
+
+		if (hash == common.Hash{}) {
+			break
+		}
+
+		block := r.receiptProvider.GetBlock(hash, height)
+		if block == nil {
+			r.stats.IncMissingReceipts()
+			break
+		}
+		blockReceipts := r.receiptProvider.GetReceiptsByHash(hash)
+		if blockReceipts == nil {
+			r.stats.IncMissingReceipts()
+			break
+		}
+
+		receiptsBytes, err := rlp.EncodeToBytes(blockReceipts)
+		if err != nil {
+			log.Error("failed to RLP encode receipts", "hash", hash, "height", height, "err", err)
+			return nil, nil
+		}
+
+		if len(receiptsBytes)+totalBytes > targetMessageByteSize && len(receipts) > 0 {
+			log.Debug("Skipping receipts due to max total bytes size", "totalReceiptsDataSize", totalBytes, "receiptsSize", len(receiptsBytes), "maxTotalBytesSize", targetMessageByteSize)
+			break
+		}
+
+		receipts = append(receipts, receiptsBytes)
+		totalBytes += len(receiptsBytes)
+		hash = block.ParentHash()
+		height--
+	}
+
+	if len(receipts) == 0 {
+		// drop this request
+		log.Debug("no requested receipts found, dropping request", "nodeID", nodeID, "requestID", requestID, "hash", receiptsRequest.Hash, "parents", receiptsRequest.Parents)
+		return nil, nil
+	}
+
+	response := message.ReceiptsResponse{
+		Receipts: receipts,
+	}
+	responseBytes, err := r.codec.Marshal(message.Version, response)
+	if err != nil {
+		log.Error("failed to marshal ReceiptsResponse, dropping request", "nodeID", nodeID, "requestID", requestID, "hash", receiptsRequest.Hash, "parents", receiptsRequest.Parents, "receiptsLen", len(response.Receipts), "err", err)
+		return nil, nil
+	}
+
+	return responseBytes, nil
+}
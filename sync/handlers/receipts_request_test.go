@@ -0,0 +1,130 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	"github.com/ava-labs/coreth/sync/handlers/stats"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiptsRequestHandler(t *testing.T) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		funds   = big.NewInt(1000000000000000000)
+		gspec   = &core.Genesis{
+			Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
+			Alloc:  types.GenesisAlloc{addr1: {Balance: funds}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	memdb := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(memdb, nil)
+	genesis := gspec.MustCommit(memdb, tdb)
+	engine := dummy.NewETHFaker()
+	blocks, receipts, err := core.GenerateChain(gspec.Config, genesis, engine, memdb, 32, 0, func(i int, b *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(b.TxNonce(addr1), addr1, big.NewInt(10000), params.TxGas, big.NewInt(1), nil), signer, key1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.AddTx(tx)
+	})
+	if err != nil {
+		t.Fatal("unexpected error when generating test blockchain", err)
+	}
+
+	blocksDB := make(map[common.Hash]*types.Block, len(blocks))
+	receiptsDB := make(map[common.Hash]types.Receipts, len(blocks))
+	for i, blk := range blocks {
+		blocksDB[blk.Hash()] = blk
+		receiptsDB[blk.Hash()] = receipts[i]
+	}
+	// receipts of the first block are missing
+	delete(receiptsDB, blocks[0].Hash())
+	receiptProvider := &TestReceiptProvider{
+		TestBlockProvider: TestBlockProvider{
+			GetBlockFn: func(hash common.Hash, height uint64) *types.Block {
+				blk, ok := blocksDB[hash]
+				if !ok || blk.NumberU64() != height {
+					return nil
+				}
+				return blk
+			},
+		},
+		GetReceiptsFn: func(hash common.Hash) types.Receipts {
+			return receiptsDB[hash]
+		},
+	}
+	mockHandlerStats := &stats.MockHandlerStats{}
+	receiptsRequestHandler := NewReceiptsRequestHandler(receiptProvider, message.Codec, mockHandlerStats)
+
+	tests := map[string]struct {
+		startBlockIndex  int
+		requestedParents uint16
+		expectedReceipts int
+	}{
+		"handler_returns_receipts_as_requested": {
+			startBlockIndex:  20,
+			requestedParents: 10,
+			expectedReceipts: 10,
+		},
+		"handler_stops_at_missing_receipts": {
+			startBlockIndex:  4,
+			requestedParents: 10,
+			expectedReceipts: 4,
+		},
+		"handler_drops_request_without_receipts": {
+			startBlockIndex:  0,
+			requestedParents: 10,
+			expectedReceipts: 0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockHandlerStats.Reset()
+			startBlock := blocks[test.startBlockIndex]
+			responseBytes, err := receiptsRequestHandler.OnReceiptsRequest(context.Background(), ids.GenerateTestNodeID(), 1, message.ReceiptsRequest{
+				Hash:    startBlock.Hash(),
+				Height:  startBlock.NumberU64(),
+				Parents: test.requestedParents,
+			})
+			assert.NoError(t, err)
+			if test.expectedReceipts == 0 {
+				assert.Nil(t, responseBytes)
+				assert.EqualValues(t, 1, mockHandlerStats.MissingReceiptsCount)
+				return
+			}
+
+			var response message.ReceiptsResponse
+			_, err = message.Codec.Unmarshal(responseBytes, &response)
+			assert.NoError(t, err)
+			assert.Len(t, response.Receipts, test.expectedReceipts)
+			assert.EqualValues(t, test.expectedReceipts, mockHandlerStats.ReceiptsReturnedSum)
+
+			// the receipts of each block match the receipts root in its header
+			for i, receiptsBytes := range response.Receipts {
+				var blockReceipts types.Receipts
+				assert.NoError(t, rlp.DecodeBytes(receiptsBytes, &blockReceipts))
+				block := blocks[test.startBlockIndex-i]
+				assert.Equal(t, block.ReceiptHash(), types.DeriveSha(blockReceipts, trie.NewStackTrie(nil)))
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/coreth/sync/handlers/stats"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"golang.org/x/time/rate"
+)
+
+// maxLimitedPeers is the maximum number of peers for which a token bucket is
+// retained. Buckets of the least recently seen peers are evicted first.
+const maxLimitedPeers = 4096
+
+// RequestType is the class of a sync request used for fair queuing.
+type RequestType int
+
+const (
+	LeafsRequestType RequestType = iota
+	BlockRequestType
+	CodeRequestType
+
+	numRequestTypes
+)
+
+// RequestLimiterConfig configures a RequestLimiter.
+type RequestLimiterConfig struct {
+	// PeerRequestsPerSecond is the rate at which tokens are added to each
+	// peer's bucket. Rate limiting is disabled if zero.
+	PeerRequestsPerSecond float64
+	// PeerBurst is the capacity of each peer's bucket. Defaults to
+	// PeerRequestsPerSecond rounded up if zero.
+	PeerBurst int
+
+	// MaxConcurrentRequests is the number of requests processed at once.
+	// Requests exceeding it wait in a queue per request type until their
+	// deadline. Queuing is disabled if zero.
+	MaxConcurrentRequests int
+	// LeafsWeight, BlockWeight and CodeWeight are the relative shares of
+	// processing slots granted to each request type while requests queue.
+	LeafsWeight int
+	BlockWeight int
+	CodeWeight  int
+}
+
+// RequestLimiter protects the sync request handlers from peers issuing more
+// requests than the node is willing to serve. Each peer is limited by a token
+// bucket, and requests admitted by their bucket share a bounded number of
+// processing slots through a weighted fair queue across request types, so one
+// request type cannot starve the others or the rest of the node.
+type RequestLimiter struct {
+	config RequestLimiterConfig
+	stats  stats.RequestLimiterStats
+
+	peersLock sync.Mutex
+	peers     *lru.BasicLRU[ids.NodeID, *rate.Limiter]
+
+	lock    sync.Mutex
+	active  int
+	queued  int
+	weights [numRequestTypes]int
+	current [numRequestTypes]int
+	queues  [numRequestTypes][]*queuedRequest
+}
+
+type queuedRequest struct {
+	ready   chan struct{}
+	granted bool
+}
+
+func NewRequestLimiter(config RequestLimiterConfig, stats stats.RequestLimiterStats) *RequestLimiter {
+	if config.PeerBurst == 0 {
+		config.PeerBurst = int(math.Ceil(config.PeerRequestsPerSecond))
+	}
+	peers := lru.NewBasicLRU[ids.NodeID, *rate.Limiter](maxLimitedPeers)
+	return &RequestLimiter{
+		config:  config,
+		stats:   stats,
+		peers:   &peers,
+		weights: [numRequestTypes]int{config.LeafsWeight, config.BlockWeight, config.CodeWeight},
+	}
+}
+
+// Acquire returns true if a request of [requestType] from [nodeID] should be
+// processed, blocking until a processing slot is available. The returned
+// function must be called once processing finishes to release the slot.
+// Returns false if the peer exceeded its rate limit or [ctx] expired before
+// a slot became available.
+func (l *RequestLimiter) Acquire(ctx context.Context, nodeID ids.NodeID, requestType RequestType) (func(), bool) {
+	if !l.allow(nodeID) {
+		l.stats.IncRateLimitedRequest()
+		return nil, false
+	}
+	if l.config.MaxConcurrentRequests <= 0 {
+		return func() {}, true
+	}
+
+	l.lock.Lock()
+	if l.active < l.config.MaxConcurrentRequests {
+		l.active++
+		l.lock.Unlock()
+		return l.release, true
+	}
+	req := &queuedRequest{ready: make(chan struct{})}
+	l.queues[requestType] = append(l.queues[requestType], req)
+	l.queued++
+	l.stats.UpdateQueuedRequests(l.queued)
+	l.lock.Unlock()
+
+	startTime := time.Now()
+	select {
+	case <-req.ready:
+		l.stats.UpdateQueueWaitTime(time.Since(startTime))
+		return l.release, true
+	case <-ctx.Done():
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if req.granted {
+		// The slot was handed over concurrently with the context expiring,
+		// pass it on to the next queued request.
+		l.releaseLocked()
+	} else {
+		l.removeLocked(requestType, req)
+	}
+	l.stats.IncQueueExpiredRequest()
+	return nil, false
+}
+
+// allow returns true if [nodeID] has a token available in its bucket.
+func (l *RequestLimiter) allow(nodeID ids.NodeID) bool {
+	if l.config.PeerRequestsPerSecond <= 0 {
+		return true
+	}
+	l.peersLock.Lock()
+	defer l.peersLock.Unlock()
+
+	limiter, ok := l.peers.Get(nodeID)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.config.PeerRequestsPerSecond), l.config.PeerBurst)
+		l.peers.Add(nodeID, limiter)
+	}
+	return limiter.Allow()
+}
+
+func (l *RequestLimiter) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.releaseLocked()
+}
+
+// releaseLocked hands the caller's processing slot to the next queued request
+// or frees it if no request is waiting. Assumes [l.lock] is held.
+func (l *RequestLimiter) releaseLocked() {
+	requestType, ok := l.nextLocked()
+	if !ok {
+		l.active--
+		return
+	}
+	req := l.queues[requestType][0]
+	l.queues[requestType][0] = nil
+	l.queues[requestType] = l.queues[requestType][1:]
+	l.queued--
+	l.stats.UpdateQueuedRequests(l.queued)
+	req.granted = true
+	close(req.ready)
+}
+
+// nextLocked selects the request type to serve next among the types with
+// queued requests using smooth weighted round robin.
+// Assumes [l.lock] is held.
+func (l *RequestLimiter) nextLocked() (RequestType, bool) {
+	var (
+		selected RequestType
+		found    bool
+		total    int
+	)
+	for requestType := RequestType(0); requestType < numRequestTypes; requestType++ {
+		if len(l.queues[requestType]) == 0 {
+			continue
+		}
+		weight := max(l.weights[requestType], 1)
+		l.current[requestType] += weight
+		total += weight
+		if !found || l.current[requestType] > l.current[selected] {
+			selected = requestType
+			found = true
+		}
+	}
+	if found {
+		l.current[selected] -= total
+	}
+	return selected, found
+}
+
+// removeLocked removes [req] from the queue of [requestType].
+// Assumes [l.lock] is held.
+func (l *RequestLimiter) removeLocked(requestType RequestType, req *queuedRequest) {
+	queue := l.queues[requestType]
+	for i, queuedReq := range queue {
+		if queuedReq == req {
+			copy(queue[i:], queue[i+1:])
+			queue[len(queue)-1] = nil
+			l.queues[requestType] = queue[:len(queue)-1]
+			l.queued--
+			l.stats.UpdateQueuedRequests(l.queued)
+			return
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/coreth/sync/handlers/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLimiterPeerRateLimit(t *testing.T) {
+	mockHandlerStats := &stats.MockHandlerStats{}
+	limiter := NewRequestLimiter(RequestLimiterConfig{
+		PeerRequestsPerSecond: 0.001,
+		PeerBurst:             2,
+	}, mockHandlerStats)
+
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 2; i++ {
+		release, ok := limiter.Acquire(context.Background(), nodeID, LeafsRequestType)
+		assert.True(t, ok)
+		release()
+	}
+	_, ok := limiter.Acquire(context.Background(), nodeID, LeafsRequestType)
+	assert.False(t, ok)
+	assert.EqualValues(t, 1, mockHandlerStats.RateLimitedRequestCount)
+
+	// other peers have their own bucket
+	release, ok := limiter.Acquire(context.Background(), ids.GenerateTestNodeID(), LeafsRequestType)
+	assert.True(t, ok)
+	release()
+}
+
+func TestRequestLimiterQueueExpiry(t *testing.T) {
+	mockHandlerStats := &stats.MockHandlerStats{}
+	limiter := NewRequestLimiter(RequestLimiterConfig{
+		MaxConcurrentRequests: 1,
+		LeafsWeight:           1,
+		BlockWeight:           1,
+		CodeWeight:            1,
+	}, mockHandlerStats)
+
+	release, ok := limiter.Acquire(context.Background(), ids.GenerateTestNodeID(), LeafsRequestType)
+	assert.True(t, ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, ok = limiter.Acquire(ctx, ids.GenerateTestNodeID(), BlockRequestType)
+	assert.False(t, ok)
+	assert.EqualValues(t, 1, mockHandlerStats.QueueExpiredRequestCount)
+	assert.Zero(t, mockHandlerStats.QueuedRequests)
+
+	// the slot is available again once released
+	release()
+	release, ok = limiter.Acquire(context.Background(), ids.GenerateTestNodeID(), BlockRequestType)
+	assert.True(t, ok)
+	release()
+}
+
+func TestRequestLimiterWeightedFairQueue(t *testing.T) {
+	limiter := NewRequestLimiter(RequestLimiterConfig{
+		MaxConcurrentRequests: 1,
+		LeafsWeight:           1,
+		BlockWeight:           3,
+		CodeWeight:            1,
+	}, stats.NewNoopHandlerStats())
+
+	release, ok := limiter.Acquire(context.Background(), ids.GenerateTestNodeID(), LeafsRequestType)
+	assert.True(t, ok)
+
+	// queue 4 requests of each type while the only slot is taken
+	const numQueued = 4
+	served := make(chan RequestType, 3*numQueued)
+	for _, requestType := range []RequestType{LeafsRequestType, BlockRequestType, CodeRequestType} {
+		for i := 0; i < numQueued; i++ {
+			go func(requestType RequestType) {
+				release, ok := limiter.Acquire(context.Background(), ids.GenerateTestNodeID(), requestType)
+				assert.True(t, ok)
+				served <- requestType
+				release()
+			}(requestType)
+		}
+	}
+	assert.Eventually(t, func() bool {
+		limiter.lock.Lock()
+		defer limiter.lock.Unlock()
+		return limiter.queued == 3*numQueued
+	}, time.Second, time.Millisecond)
+
+	// out of the first 5 requests served, 3 are block requests
+	release()
+	counts := make(map[RequestType]int)
+	for i := 0; i < 5; i++ {
+		counts[<-served]++
+	}
+	assert.Equal(t, 3, counts[BlockRequestType])
+	assert.Equal(t, 1, counts[LeafsRequestType])
+	assert.Equal(t, 1, counts[CodeRequestType])
+	for i := 5; i < 3*numQueued; i++ {
+		<-served
+	}
+}
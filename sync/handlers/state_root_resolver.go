@@ -0,0 +1,169 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// storageRootCacheSize is the number of storage tries for which the
+	// owning state root is remembered.
+	storageRootCacheSize = 4096
+
+	// recentStateRootsLimit is the number of state roots recently requested
+	// by peers that are tried first when locating a storage trie.
+	recentStateRootsLimit = 8
+
+	// maxResolveCandidates bounds the number of state tries opened to
+	// locate a storage trie that is not cached.
+	maxResolveCandidates = 32
+
+	// accountRootCacheSize is the number of storage roots of accounts read
+	// from state tries that are remembered, so candidates are not re-read
+	// for every request.
+	accountRootCacheSize = 16384
+)
+
+type storageTrieKey struct {
+	account common.Hash
+	root    common.Hash
+}
+
+type accountKey struct {
+	stateRoot common.Hash
+	account   common.Hash
+}
+
+// stateRootResolver locates the state root a storage trie belongs to.
+// Path-based trie databases index storage trie nodes by their owning
+// account and resolve them through the layer of a specific state root, so
+// serving a storage trie requires knowing a state root in which the account
+// has the requested storage root. LeafsRequest only carries the storage root,
+// so the resolver searches the state roots retained by the trie database.
+type stateRootResolver struct {
+	trieDB       *triedb.Database
+	cache        *lru.Cache[storageTrieKey, common.Hash]
+	accountRoots *lru.Cache[accountKey, common.Hash] // storage root of an account in a state, zero if absent
+
+	lock   sync.Mutex
+	recent []common.Hash // most recently requested state roots, newest first
+}
+
+func newStateRootResolver(trieDB *triedb.Database) *stateRootResolver {
+	return &stateRootResolver{
+		trieDB:       trieDB,
+		cache:        lru.NewCache[storageTrieKey, common.Hash](storageRootCacheSize),
+		accountRoots: lru.NewCache[accountKey, common.Hash](accountRootCacheSize),
+	}
+}
+
+// addRecent records [root] as a state root requested by a peer, so it is
+// checked first when resolving storage tries requested afterwards.
+func (r *stateRootResolver) addRecent(root common.Hash) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for i, recent := range r.recent {
+		if recent == root {
+			copy(r.recent[1:i+1], r.recent[:i])
+			r.recent[0] = root
+			return
+		}
+	}
+	if len(r.recent) < recentStateRootsLimit {
+		r.recent = append(r.recent, common.Hash{})
+	}
+	copy(r.recent[1:], r.recent[:len(r.recent)-1])
+	r.recent[0] = root
+}
+
+// candidates returns at most [maxResolveCandidates] distinct state roots to
+// search, recently requested roots first.
+func (r *stateRootResolver) candidates() ([]common.Hash, error) {
+	retained, err := r.trieDB.StateRoots()
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	roots := make([]common.Hash, 0, len(r.recent)+len(retained))
+	roots = append(roots, r.recent...)
+	r.lock.Unlock()
+	roots = append(roots, retained...)
+
+	candidates := make([]common.Hash, 0, maxResolveCandidates)
+	seen := make(map[common.Hash]struct{}, maxResolveCandidates)
+	for _, root := range roots {
+		if len(candidates) == maxResolveCandidates {
+			break
+		}
+		if _, ok := seen[root]; ok {
+			continue
+		}
+		seen[root] = struct{}{}
+		candidates = append(candidates, root)
+	}
+	return candidates, nil
+}
+
+// resolve returns a state root retained by the trie database in which
+// [account] has storage root [root].
+func (r *stateRootResolver) resolve(account common.Hash, root common.Hash) (common.Hash, error) {
+	key := storageTrieKey{account: account, root: root}
+	if stateRoot, ok := r.cache.Get(key); ok && r.matches(stateRoot, account, root) {
+		return stateRoot, nil
+	}
+	candidates, err := r.candidates()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	for _, stateRoot := range candidates {
+		if r.matches(stateRoot, account, root) {
+			r.cache.Add(key, stateRoot)
+			return stateRoot, nil
+		}
+	}
+	return common.Hash{}, fmt.Errorf("no retained state root contains storage root %s for account %s", root, account)
+}
+
+// matches returns true if [account] has storage root [root] in the state
+// identified by [stateRoot] and that state is still accessible.
+func (r *stateRootResolver) matches(stateRoot common.Hash, account common.Hash, root common.Hash) bool {
+	key := accountKey{stateRoot: stateRoot, account: account}
+	if accountRoot, ok := r.accountRoots.Get(key); ok {
+		// The state may have been dropped from the retained history since it
+		// was read, so it must still be accessible.
+		if accountRoot != root {
+			return false
+		}
+		_, err := r.trieDB.Reader(stateRoot)
+		return err == nil
+	}
+	t, err := trie.New(trie.StateTrieID(stateRoot), r.trieDB)
+	if err != nil {
+		return false
+	}
+	accBytes, err := t.Get(account[:])
+	if err != nil {
+		return false
+	}
+	var accountRoot common.Hash
+	if len(accBytes) > 0 {
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(accBytes, &acc); err != nil {
+			return false
+		}
+		accountRoot = acc.Root
+	}
+	r.accountRoots.Add(key, accountRoot)
+	return accountRoot == root
+}
@@ -0,0 +1,74 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ava-labs/coreth/triedb/pathdb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateRootResolver(t *testing.T) {
+	require := require.New(t)
+	memdb := rawdb.NewMemoryDatabase()
+	trieDB := triedb.NewDatabase(memdb, &triedb.Config{PathDB: pathdb.Defaults})
+	stateDB := state.NewDatabaseWithNodeDB(memdb, trieDB)
+
+	var (
+		addr      = common.Address{0x01}
+		addrHash  = crypto.Keccak256Hash(addr[:])
+		root      = types.EmptyRootHash
+		roots     []common.Hash
+		storages  []common.Hash
+		numBlocks = maxResolveCandidates + 8
+	)
+	for i := 0; i < numBlocks; i++ {
+		statedb, err := state.New(root, stateDB, nil)
+		require.NoError(err)
+		statedb.SetNonce(addr, uint64(i+1))
+		statedb.SetState(addr, common.Hash{}, common.BigToHash(big.NewInt(int64(i+1))))
+		root, err = statedb.Commit(uint64(i+1), true, false)
+		require.NoError(err)
+		roots = append(roots, root)
+		storages = append(storages, statedb.GetStorageRoot(addr))
+	}
+
+	resolver := newStateRootResolver(trieDB)
+	newest := len(roots) - 1
+	candidates, err := resolver.candidates()
+	require.NoError(err)
+	require.Len(candidates, maxResolveCandidates)
+	require.Equal(roots[newest], candidates[0])
+
+	// Storage tries of recent states are resolved, and the storage roots read
+	// while searching are cached.
+	stateRoot, err := resolver.resolve(addrHash, storages[newest-1])
+	require.NoError(err)
+	require.Equal(roots[newest-1], stateRoot)
+	require.Equal(2, resolver.accountRoots.Len())
+
+	// Storage tries beyond the bounded search are only resolved once their
+	// state root was requested by a peer.
+	_, err = resolver.resolve(addrHash, storages[0])
+	require.Error(err)
+	resolver.addRecent(roots[0])
+	stateRoot, err = resolver.resolve(addrHash, storages[0])
+	require.NoError(err)
+	require.Equal(roots[0], stateRoot)
+
+	// Recent roots are not searched twice.
+	resolver.addRecent(roots[newest])
+	candidates, err = resolver.candidates()
+	require.NoError(err)
+	require.Len(candidates, maxResolveCandidates)
+	require.Equal([]common.Hash{roots[newest], roots[0], roots[newest-1]}, candidates[:3])
+}
@@ -19,6 +19,11 @@ type MockHandlerStats struct {
 	BlocksReturnedSum uint32
 	BlockRequestProcessingTimeSum time.Duration
 
+	ReceiptsRequestCount,
+	MissingReceiptsCount,
+	ReceiptsReturnedSum uint32
+	ReceiptsRequestProcessingTimeSum time.Duration
+
 	CodeRequestCount,
 	MissingCodeHashCount,
 	TooManyHashesRequested,
@@ -42,6 +47,11 @@ type MockHandlerStats struct {
 	SnapshotReadTime,
 	GenerateRangeProofTime,
 	LeafRequestProcessingTimeSum time.Duration
+
+	RateLimitedRequestCount,
+	QueueExpiredRequestCount uint32
+	QueueWaitTimeSum time.Duration
+	QueuedRequests   int
 }
 
 func (m *MockHandlerStats) Reset() {
@@ -51,6 +61,10 @@ func (m *MockHandlerStats) Reset() {
 	m.MissingBlockHashCount = 0
 	m.BlocksReturnedSum = 0
 	m.BlockRequestProcessingTimeSum = 0
+	m.ReceiptsRequestCount = 0
+	m.MissingReceiptsCount = 0
+	m.ReceiptsReturnedSum = 0
+	m.ReceiptsRequestProcessingTimeSum = 0
 	m.CodeRequestCount = 0
 	m.MissingCodeHashCount = 0
 	m.TooManyHashesRequested = 0
@@ -73,6 +87,10 @@ func (m *MockHandlerStats) Reset() {
 	m.SnapshotReadTime = 0
 	m.GenerateRangeProofTime = 0
 	m.LeafRequestProcessingTimeSum = 0
+	m.RateLimitedRequestCount = 0
+	m.QueueExpiredRequestCount = 0
+	m.QueueWaitTimeSum = 0
+	m.QueuedRequests = 0
 }
 
 func (m *MockHandlerStats) IncBlockRequest() {
@@ -99,6 +117,30 @@ func (m *MockHandlerStats) UpdateBlockRequestProcessingTime(duration time.Durati
 	m.BlockRequestProcessingTimeSum += duration
 }
 
+func (m *MockHandlerStats) IncReceiptsRequest() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ReceiptsRequestCount++
+}
+
+func (m *MockHandlerStats) IncMissingReceipts() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.MissingReceiptsCount++
+}
+
+func (m *MockHandlerStats) UpdateReceiptsReturned(num uint16) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ReceiptsReturnedSum += uint32(num)
+}
+
+func (m *MockHandlerStats) UpdateReceiptsRequestProcessingTime(duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ReceiptsRequestProcessingTimeSum += duration
+}
+
 func (m *MockHandlerStats) IncCodeRequest() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -230,3 +272,27 @@ func (m *MockHandlerStats) IncSnapshotSegmentInvalid() {
 	defer m.lock.Unlock()
 	m.SnapshotSegmentInvalidCount++
 }
+
+func (m *MockHandlerStats) IncRateLimitedRequest() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.RateLimitedRequestCount++
+}
+
+func (m *MockHandlerStats) IncQueueExpiredRequest() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.QueueExpiredRequestCount++
+}
+
+func (m *MockHandlerStats) UpdateQueueWaitTime(duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.QueueWaitTimeSum += duration
+}
+
+func (m *MockHandlerStats) UpdateQueuedRequests(queued int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.QueuedRequests = queued
+}
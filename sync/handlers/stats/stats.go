@@ -12,8 +12,10 @@ import (
 // HandlerStats reports prometheus metrics for the state sync handlers
 type HandlerStats interface {
 	BlockRequestHandlerStats
+	ReceiptsRequestHandlerStats
 	CodeRequestHandlerStats
 	LeafsRequestHandlerStats
+	RequestLimiterStats
 }
 
 type BlockRequestHandlerStats interface {
@@ -23,6 +25,13 @@ type BlockRequestHandlerStats interface {
 	UpdateBlockRequestProcessingTime(duration time.Duration)
 }
 
+type ReceiptsRequestHandlerStats interface {
+	IncReceiptsRequest()
+	IncMissingReceipts()
+	UpdateReceiptsReturned(num uint16)
+	UpdateReceiptsRequestProcessingTime(duration time.Duration)
+}
+
 type CodeRequestHandlerStats interface {
 	IncCodeRequest()
 	IncMissingCodeHash()
@@ -51,6 +60,13 @@ type LeafsRequestHandlerStats interface {
 	IncSnapshotSegmentInvalid()
 }
 
+type RequestLimiterStats interface {
+	IncRateLimitedRequest()
+	IncQueueExpiredRequest()
+	UpdateQueueWaitTime(duration time.Duration)
+	UpdateQueuedRequests(queued int)
+}
+
 type handlerStats struct {
 	// BlockRequestHandler metrics
 	blockRequest               metrics.Counter
@@ -58,6 +74,12 @@ type handlerStats struct {
 	blocksReturned             metrics.Histogram
 	blockRequestProcessingTime metrics.Timer
 
+	// ReceiptsRequestHandler metrics
+	receiptsRequest               metrics.Counter
+	missingReceipts               metrics.Counter
+	receiptsReturned              metrics.Histogram
+	receiptsRequestProcessingTime metrics.Timer
+
 	// CodeRequestHandler stats
 	codeRequest              metrics.Counter
 	missingCodeHash          metrics.Counter
@@ -83,6 +105,12 @@ type handlerStats struct {
 	snapshotReadSuccess        metrics.Counter
 	snapshotSegmentValid       metrics.Counter
 	snapshotSegmentInvalid     metrics.Counter
+
+	// RequestLimiter stats
+	rateLimitedRequest  metrics.Counter
+	queueExpiredRequest metrics.Counter
+	queueWaitTime       metrics.Timer
+	queuedRequests      metrics.Gauge
 }
 
 func (h *handlerStats) IncBlockRequest() {
@@ -101,6 +129,22 @@ func (h *handlerStats) UpdateBlockRequestProcessingTime(duration time.Duration)
 	h.blockRequestProcessingTime.Update(duration)
 }
 
+func (h *handlerStats) IncReceiptsRequest() {
+	h.receiptsRequest.Inc(1)
+}
+
+func (h *handlerStats) IncMissingReceipts() {
+	h.missingReceipts.Inc(1)
+}
+
+func (h *handlerStats) UpdateReceiptsReturned(num uint16) {
+	h.receiptsReturned.Update(int64(num))
+}
+
+func (h *handlerStats) UpdateReceiptsRequestProcessingTime(duration time.Duration) {
+	h.receiptsRequestProcessingTime.Update(duration)
+}
+
 func (h *handlerStats) IncCodeRequest() {
 	h.codeRequest.Inc(1)
 }
@@ -166,6 +210,17 @@ func (h *handlerStats) IncSnapshotReadSuccess()    { h.snapshotReadSuccess.Inc(1
 func (h *handlerStats) IncSnapshotSegmentValid()   { h.snapshotSegmentValid.Inc(1) }
 func (h *handlerStats) IncSnapshotSegmentInvalid() { h.snapshotSegmentInvalid.Inc(1) }
 
+func (h *handlerStats) IncRateLimitedRequest()  { h.rateLimitedRequest.Inc(1) }
+func (h *handlerStats) IncQueueExpiredRequest() { h.queueExpiredRequest.Inc(1) }
+
+func (h *handlerStats) UpdateQueueWaitTime(duration time.Duration) {
+	h.queueWaitTime.Update(duration)
+}
+
+func (h *handlerStats) UpdateQueuedRequests(queued int) {
+	h.queuedRequests.Update(int64(queued))
+}
+
 func NewHandlerStats(enabled bool) HandlerStats {
 	if !enabled {
 		return NewNoopHandlerStats()
@@ -177,6 +232,12 @@ func NewHandlerStats(enabled bool) HandlerStats {
 		blocksReturned:             metrics.GetOrRegisterHistogram("block_request_total_blocks", nil, metrics.NewExpDecaySample(1028, 0.015)),
 		blockRequestProcessingTime: metrics.GetOrRegisterTimer("block_request_processing_time", nil),
 
+		// initialize receipts request stats
+		receiptsRequest:               metrics.GetOrRegisterCounter("receipts_request_count", nil),
+		missingReceipts:               metrics.GetOrRegisterCounter("receipts_request_missing_receipts", nil),
+		receiptsReturned:              metrics.GetOrRegisterHistogram("receipts_request_total_receipts", nil, metrics.NewExpDecaySample(1028, 0.015)),
+		receiptsRequestProcessingTime: metrics.GetOrRegisterTimer("receipts_request_processing_time", nil),
+
 		// initialize code request stats
 		codeRequest:              metrics.GetOrRegisterCounter("code_request_count", nil),
 		missingCodeHash:          metrics.GetOrRegisterCounter("code_request_missing_code_hash", nil),
@@ -202,6 +263,12 @@ func NewHandlerStats(enabled bool) HandlerStats {
 		snapshotReadSuccess:        metrics.GetOrRegisterCounter("leafs_request_snapshot_read_success", nil),
 		snapshotSegmentValid:       metrics.GetOrRegisterCounter("leafs_request_snapshot_segment_valid", nil),
 		snapshotSegmentInvalid:     metrics.GetOrRegisterCounter("leafs_request_snapshot_segment_invalid", nil),
+
+		// initialize request limiter stats
+		rateLimitedRequest:  metrics.GetOrRegisterCounter("sync_request_rate_limited", nil),
+		queueExpiredRequest: metrics.GetOrRegisterCounter("sync_request_queue_expired", nil),
+		queueWaitTime:       metrics.GetOrRegisterTimer("sync_request_queue_wait_time", nil),
+		queuedRequests:      metrics.GetOrRegisterGauge("sync_request_queued", nil),
 	}
 }
 
@@ -217,6 +284,10 @@ func (n *noopHandlerStats) IncBlockRequest()
 func (n *noopHandlerStats) IncMissingBlockHash()                                {}
 func (n *noopHandlerStats) UpdateBlocksReturned(uint16)                         {}
 func (n *noopHandlerStats) UpdateBlockRequestProcessingTime(time.Duration)      {}
+func (n *noopHandlerStats) IncReceiptsRequest()                                 {}
+func (n *noopHandlerStats) IncMissingReceipts()                                 {}
+func (n *noopHandlerStats) UpdateReceiptsReturned(uint16)                       {}
+func (n *noopHandlerStats) UpdateReceiptsRequestProcessingTime(time.Duration)   {}
 func (n *noopHandlerStats) IncCodeRequest()                                     {}
 func (n *noopHandlerStats) IncMissingCodeHash()                                 {}
 func (n *noopHandlerStats) IncTooManyHashesRequested()                          {}
@@ -239,3 +310,7 @@ func (n *noopHandlerStats) IncSnapshotReadAttempt()
 func (n *noopHandlerStats) IncSnapshotReadSuccess()                             {}
 func (n *noopHandlerStats) IncSnapshotSegmentValid()                            {}
 func (n *noopHandlerStats) IncSnapshotSegmentInvalid()                          {}
+func (n *noopHandlerStats) IncRateLimitedRequest()                              {}
+func (n *noopHandlerStats) IncQueueExpiredRequest()                             {}
+func (n *noopHandlerStats) UpdateQueueWaitTime(duration time.Duration)          {}
+func (n *noopHandlerStats) UpdateQueuedRequests(queued int)                     {}
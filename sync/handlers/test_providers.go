@@ -11,6 +11,7 @@ import (
 
 var (
 	_ BlockProvider    = &TestBlockProvider{}
+	_ ReceiptProvider  = &TestReceiptProvider{}
 	_ SnapshotProvider = &TestSnapshotProvider{}
 )
 
@@ -22,6 +23,15 @@ func (t *TestBlockProvider) GetBlock(hash common.Hash, number uint64) *types.Blo
 	return t.GetBlockFn(hash, number)
 }
 
+type TestReceiptProvider struct {
+	TestBlockProvider
+	GetReceiptsFn func(common.Hash) types.Receipts
+}
+
+func (t *TestReceiptProvider) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return t.GetReceiptsFn(hash)
+}
+
 type TestSnapshotProvider struct {
 	Snapshot *snapshot.Tree
 }
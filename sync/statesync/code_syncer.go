@@ -16,6 +16,7 @@ import (
 	statesyncclient "github.com/ava-labs/coreth/sync/client"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 const (
@@ -134,6 +135,10 @@ func (c *codeSyncer) addCodeToFetchFromDBToQueue() error {
 			return fmt.Errorf("failed to write batch removing old code markers: %w", err)
 		}
 	}
+	resumedCodeHashesGauge.Update(int64(len(codeHashes)))
+	if len(codeHashes) > 0 {
+		log.Info("code sync: resuming outstanding code requests", "codeHashes", len(codeHashes))
+	}
 	return c.addCode(codeHashes)
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 // trieQueue persists storage trie roots with their associated
@@ -39,6 +40,16 @@ func (t *trieQueue) clearIfRootDoesNotMatch(root common.Hash) error {
 			return err
 		}
 	}
+	if persistedRoot == root {
+		numStorageTries, err := t.countTries()
+		if err != nil {
+			return err
+		}
+		resumedStorageTriesGauge.Update(int64(numStorageTries))
+		log.Info("state sync: resuming sync of state trie", "root", root, "storageTries", numStorageTries)
+	} else {
+		resumedStorageTriesGauge.Update(0)
+	}
 
 	return rawdb.WriteSyncRoot(t.db, root)
 }
@@ -15,6 +15,13 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// Metrics reporting the work left over from an interrupted sync that was
+// resumed rather than restarted.
+var (
+	resumedStorageTriesGauge = metrics.NewRegisteredGauge("state_sync_resumed_storage_tries", nil)
+	resumedCodeHashesGauge   = metrics.NewRegisteredGauge("state_sync_resumed_code_hashes", nil)
+)
+
 const (
 	updateFrequency  = 1 * time.Minute
 	leafRateHalfLife = 1 * time.Minute
@@ -320,6 +320,17 @@ func (db *Database) Enable(root common.Hash) error {
 	return pdb.Enable(root)
 }
 
+// StateRoots returns the state roots which are currently accessible through
+// Reader, ordered from the most recent one. It's only supported by path-based
+// database and will return an error for others.
+func (db *Database) StateRoots() ([]common.Hash, error) {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return nil, errors.New("not supported")
+	}
+	return pdb.StateRoots(), nil
+}
+
 // Journal commits an entire diff hierarchy to disk into a single journal entry.
 // This is meant to be used during shutdown to persist the snapshot without
 // flattening everything down (bad for reorgs). It's only supported by path-based
@@ -30,6 +30,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/coreth/core/rawdb"
@@ -228,6 +229,24 @@ func (db *Database) Reader(root common.Hash) (layer, error) {
 	return l, nil
 }
 
+// StateRoots returns the state roots of all layers retained in the layer tree,
+// ordered from the most recent state down to the disk layer. Every returned
+// root can be opened with Reader as long as it is not evicted in the meantime.
+func (db *Database) StateRoots() []common.Hash {
+	var layers []layer
+	db.tree.forEach(func(l layer) {
+		layers = append(layers, l)
+	})
+	sort.Slice(layers, func(i, j int) bool {
+		return layers[i].stateID() > layers[j].stateID()
+	})
+	roots := make([]common.Hash, 0, len(layers))
+	for _, l := range layers {
+		roots = append(roots, l.rootHash())
+	}
+	return roots
+}
+
 // Update adds a new layer into the tree, if that can be linked to an existing
 // old parent. It is disallowed to insert a disk layer (the origin of all). Apart
 // from that this function will flatten the extra diff layers at bottom into disk
@@ -47,4 +47,6 @@ var (
 	ErrInvalidCode              = errors.New("invalid code: must not begin with 0xef")
 	ErrNonceUintOverflow        = errors.New("nonce uint64 overflow")
 	ErrAddrProhibited           = errors.New("prohibited address cannot be sender or created contract address")
+	ErrSenderNotAllowListed     = errors.New("cannot issue transaction from non-allow listed address")
+	ErrDeployerNotAllowListed   = errors.New("cannot deploy contract from non-allow listed address")
 )